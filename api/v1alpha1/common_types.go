@@ -56,6 +56,12 @@ type StorageSpec struct {
 	// If not specified, the default StorageClass will be used.
 	// +optional
 	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// RestoreFromSnapshot recreates the volume from the named VolumeSnapshot in the server's namespace.
+	// The server is stopped and its current volume is deleted. A volume already created from the
+	// named snapshot is left alone, so each snapshot name is restored once.
+	// +optional
+	RestoreFromSnapshot string `json:"restoreFromSnapshot,omitempty"`
 }
 
 // PortStatus contains information about an exposed port.
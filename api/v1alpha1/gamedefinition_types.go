@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // GameDefinitionSpec defines how to install and run a Steam game server.
@@ -17,7 +18,8 @@ type GameDefinitionSpec struct {
 	// +optional
 	Image string `json:"image,omitempty"`
 
-	// InstallDir is where SteamCMD installs game files.
+	// InstallDir is where SteamCMD installs game files. The server volume is mounted here,
+	// and it is the working directory of the game server.
 	// +kubebuilder:default="/data/server"
 	// +optional
 	InstallDir string `json:"installDir,omitempty"`
@@ -60,6 +62,66 @@ type GameDefinitionSpec struct {
 	// HealthCheck defines how to check if the server is healthy.
 	// +optional
 	HealthCheck *HealthCheckSpec `json:"healthCheck,omitempty"`
+
+	// SaveDirs are the absolute paths where the game server writes save data.
+	// Each is kept at saves/<path> (e.g. saves/data/saves) on the server's save volume
+	// (SteamServer.saveStorage), or on the game file volume if no save volume is configured.
+	// Existing data in a save directory inside installDir is moved there on first start.
+	// +optional
+	SaveDirs []string `json:"saveDirs,omitempty"`
+
+	// Workshop describes how the game installs Steam Workshop mods from SteamServer.mods.
+	// +optional
+	Workshop *WorkshopSpec `json:"workshop,omitempty"`
+}
+
+// WorkshopSpec describes how a game installs Steam Workshop mods.
+type WorkshopSpec struct {
+	// AppId is the Steam application ID the Workshop items belong to,
+	// usually the game client rather than the dedicated server.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	AppId int32 `json:"appId"`
+
+	// ModDir is where the game loads mods from, each in a directory named by its item ID.
+	// Relative to installDir, or an absolute path within it. If empty, mods stay in the directory SteamCMD
+	// downloads them to, steamapps/workshop/content/<appId> under installDir.
+	// +optional
+	ModDir string `json:"modDir,omitempty"`
+
+	// Method is how mods are placed in modDir: Link creates symlinks to the downloaded items,
+	// Copy copies them, for games that don't follow symlinks.
+	// +kubebuilder:validation:Enum=Link;Copy
+	// +kubebuilder:default="Link"
+	// +optional
+	Method WorkshopMethod `json:"method,omitempty"`
+
+	// ModList renders a file listing the enabled mods, such as a load-order file.
+	// +optional
+	ModList *ModListFile `json:"modList,omitempty"`
+}
+
+// WorkshopMethod is how Workshop mods are placed in WorkshopSpec.modDir.
+// +kubebuilder:validation:Enum=Link;Copy
+type WorkshopMethod string
+
+const (
+	// WorkshopMethodLink symlinks mods into modDir.
+	WorkshopMethodLink WorkshopMethod = "Link"
+	// WorkshopMethodCopy copies mods into modDir.
+	WorkshopMethodCopy WorkshopMethod = "Copy"
+)
+
+// ModListFile is a file listing the enabled Workshop mods.
+type ModListFile struct {
+	// Path is the file path, relative to installDir or absolute.
+	// +kubebuilder:validation:Required
+	Path string `json:"path"`
+
+	// Template is a Go template for the file content. .Mods holds the enabled mods in
+	// SteamServer.mods order, each with its item .ID and the .Path the game finds it at.
+	// +kubebuilder:validation:Required
+	Template string `json:"template"`
 }
 
 // ConfigSchemaEntry defines a user-configurable option.
@@ -106,7 +168,8 @@ type ConfigMapping struct {
 	// +optional
 	Value string `json:"value,omitempty"`
 
-	// Condition for "arg" type: only add if config value equals this.
+	// Condition for "arg" type: only add the flag (without a value) if config value equals this.
+	// If empty, the flag is followed by the config value (e.g., "-instanceid 1").
 	// +optional
 	Condition string `json:"condition,omitempty"`
 
@@ -119,12 +182,25 @@ type ConfigMapping struct {
 	Template string `json:"template,omitempty"`
 }
 
+// Config mapping types supported by ConfigMapping.Type.
+const (
+	// ConfigMappingArg appends a flag to the game server container args.
+	ConfigMappingArg = "arg"
+
+	// ConfigMappingEnv sets an environment variable on the game server container.
+	ConfigMappingEnv = "env"
+
+	// ConfigMappingConfigFile renders a config file into the server ConfigMap.
+	ConfigMappingConfigFile = "configFile"
+)
+
 // ConfigFileTemplate defines a static config file.
 type ConfigFileTemplate struct {
 	// Path is where to mount the file.
 	Path string `json:"path"`
 
 	// Content is the file content (can use {{.Config.key}} templates).
+	// Secret config values can't be used, since the file is stored in a ConfigMap.
 	Content string `json:"content"`
 }
 
@@ -134,6 +210,11 @@ type HealthCheckSpec struct {
 	// +optional
 	TCPSocket *corev1.TCPSocketAction `json:"tcpSocket,omitempty"`
 
+	// A2S checks health with a Steam A2S_INFO query, for servers that only listen on UDP.
+	// Takes precedence over TCPSocket.
+	// +optional
+	A2S *A2SHealthCheck `json:"a2s,omitempty"`
+
 	// InitialDelaySeconds before first check.
 	// +kubebuilder:default=120
 	// +optional
@@ -145,6 +226,19 @@ type HealthCheckSpec struct {
 	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
 }
 
+// A2SHealthCheck defines a health check using the Steam A2S_INFO query protocol.
+type A2SHealthCheck struct {
+	// Port is the query port, by number or by name from ports.
+	// +kubebuilder:validation:Required
+	Port intstr.IntOrString `json:"port"`
+
+	// TimeoutSeconds to wait for a query reply.
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+}
+
 // GameDefinitionStatus defines the observed state.
 type GameDefinitionStatus struct {
 	// Ready indicates the GameDefinition is valid and usable.
@@ -2,7 +2,9 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // SteamServerSpec defines the desired state of a Steam dedicated game server.
@@ -53,10 +55,20 @@ type SteamServerSpec struct {
 	// +optional
 	Storage *StorageSpec `json:"storage,omitempty"`
 
+	// SaveStorage keeps GameDefinition.saveDirs on a separate volume from the game files,
+	// so the game files can be wiped and reinstalled without touching save data.
+	// Backups, restores and pre-update snapshots use this volume when it is set.
+	// +optional
+	SaveStorage *SaveStorageSpec `json:"saveStorage,omitempty"`
+
 	// Resources overrides GameDefinition.defaultResources.
 	// +optional
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 
+	// HealthCheck overrides the probe thresholds derived from GameDefinition.healthCheck.
+	// +optional
+	HealthCheck *HealthCheckOverride `json:"healthCheck,omitempty"`
+
 	// ServiceType for the game server Service.
 	// +kubebuilder:validation:Enum=LoadBalancer;NodePort;ClusterIP
 	// +kubebuilder:default="LoadBalancer"
@@ -69,6 +81,11 @@ type SteamServerSpec struct {
 	// +optional
 	Beta string `json:"beta,omitempty"`
 
+	// BetaPasswordSecretRef references the Secret key holding the password of a private beta branch.
+	// Requires beta.
+	// +optional
+	BetaPasswordSecretRef *corev1.SecretKeySelector `json:"betaPasswordSecretRef,omitempty"`
+
 	// Validate game files on startup.
 	// +kubebuilder:default=true
 	// +optional
@@ -79,15 +96,430 @@ type SteamServerSpec struct {
 	// +optional
 	Anonymous *bool `json:"anonymous,omitempty"`
 
-	// SteamCredentialsSecret for authenticated login.
+	// SteamCredentialsSecret names the Secret with the "username" and "password" keys of the Steam account
+	// for authenticated login. Required when anonymous is false. The login token SteamCMD receives is kept
+	// on the server volume, so later installs log in without a new Steam Guard code.
 	// +optional
 	SteamCredentialsSecret string `json:"steamCredentialsSecret,omitempty"`
+
+	// SteamGuard computes Steam Guard codes for an account protected by the mobile authenticator.
+	// Requires anonymous false, and an operator started with --probe-image, which provides the helper
+	// that computes the codes.
+	// +optional
+	SteamGuard *SteamGuardSpec `json:"steamGuard,omitempty"`
+
+	// Mods are Steam Workshop items SteamCMD downloads with the game.
+	// Requires GameDefinition.workshop, and can't be combined with gameCache.
+	// +optional
+	Mods []WorkshopMod `json:"mods,omitempty"`
+
+	// Install controls how failed SteamCMD runs are retried before the install fails.
+	// +optional
+	Install *InstallSpec `json:"install,omitempty"`
+
+	// UpdatePolicy controls how new Steam builds of the game are picked up.
+	// +kubebuilder:validation:Enum=Manual;OnRestart;Automatic
+	// +kubebuilder:default="Manual"
+	// +optional
+	UpdatePolicy UpdatePolicy `json:"updatePolicy,omitempty"`
+
+	// GameCache installs the game from a read-only volume shared by the servers in the namespace,
+	// populated once per AppId and Steam build, instead of downloading it in every pod.
+	// The install directory links to the cached files, so games that modify their installed files
+	// can't use it. Under updatePolicy Automatic the server moves to the cache of each new build;
+	// otherwise it stays on the build it started with. Requires an operator started with
+	// --game-cache-storage-class.
+	// +optional
+	GameCache bool `json:"gameCache,omitempty"`
+
+	// UpdateStrategy controls how changes that restart the game server are applied.
+	// +optional
+	UpdateStrategy *UpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// Suspended stops the game server by scaling it to zero.
+	// The PVC and Service are kept, so clearing it resumes the server with its world intact.
+	// Set by the controller when idleShutdown stops an empty server.
+	// A server woken by wakeOnConnect runs while this stays set; see status.wokenAt.
+	// +optional
+	Suspended bool `json:"suspended,omitempty"`
+
+	// IdleShutdown suspends the server after it has had no players for a while.
+	// +optional
+	IdleShutdown *IdleShutdown `json:"idleShutdown,omitempty"`
+
+	// WakeOnConnect runs a lightweight proxy on the server's ports while it is suspended.
+	// The proxy answers A2S queries with a placeholder and resumes the server when a player connects.
+	// The proxy authenticates its wake requests with a token in the <name>-wake Secret.
+	// +optional
+	WakeOnConnect bool `json:"wakeOnConnect,omitempty"`
+
+	// Schedule configures scheduled restarts and when automatic updates may be applied.
+	// +optional
+	Schedule *ScheduleSpec `json:"schedule,omitempty"`
+
+	// Backup configures scheduled backups of save data from the server volume.
+	// +optional
+	Backup *BackupSpec `json:"backup,omitempty"`
+
+	// Snapshots takes a CSI VolumeSnapshot of the server volume before each SteamCMD update:
+	// a new target build, beta, image or AppId. The save volume is snapshotted instead when
+	// saveStorage is set. Requires the CSI snapshot controller and a driver that supports snapshots.
+	// +optional
+	Snapshots *SnapshotSpec `json:"snapshots,omitempty"`
+}
+
+// WorkshopMod is a Steam Workshop item installed with the game.
+type WorkshopMod struct {
+	// ID is the Workshop item ID, as in the id parameter of its Workshop page URL.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	ID int64 `json:"id"`
+
+	// Version is the expected manifest ID of the item, as reported in status.mods. SteamCMD always
+	// downloads the latest version, so once the item is updated the server starts with the new
+	// version and the ModVersionMismatch condition reports the difference.
+	// +kubebuilder:validation:Pattern=`^[0-9]+$`
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Enabled installs the mod. A disabled mod is not downloaded, placed in modDir, or listed in the mod list.
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// InstallSpec controls how failed SteamCMD runs are retried.
+type InstallSpec struct {
+	// Retries is how many times a failed SteamCMD run is retried, clearing its partial downloads first.
+	// The install fails, with the InstallFailed condition, once all retries failed.
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	Retries *int32 `json:"retries,omitempty"`
+
+	// Backoff is the wait before the first retry, doubled for each further retry up to 5 minutes.
+	// +kubebuilder:default="30s"
+	// +optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+}
+
+// SteamGuardSpec configures Steam Guard codes for authenticated SteamCMD logins.
+type SteamGuardSpec struct {
+	// SharedSecretRef references the Secret key holding the base64 shared_secret of the Steam Guard
+	// mobile authenticator, as exported by authenticator tools. A code is computed from it for each login.
+	// +kubebuilder:validation:Required
+	SharedSecretRef corev1.SecretKeySelector `json:"sharedSecretRef"`
+}
+
+// SaveStorageSpec defines the save data volume of a SteamServer.
+type SaveStorageSpec struct {
+	// Size is the requested storage size.
+	// +kubebuilder:validation:Required
+	Size resource.Quantity `json:"size"`
+
+	// StorageClassName is the name of the StorageClass to use.
+	// If not specified, the default StorageClass will be used.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// ReclaimPolicy is what happens to the save volume when the SteamServer is deleted.
+	// Retain keeps it, and a SteamServer recreated with the same name adopts it.
+	// +kubebuilder:default=Retain
+	// +optional
+	ReclaimPolicy SaveReclaimPolicy `json:"reclaimPolicy,omitempty"`
+
+	// RestoreFromSnapshot recreates the save volume from the named VolumeSnapshot in the server's namespace.
+	// The server is stopped and its current save volume is deleted. A volume already created from the
+	// named snapshot is left alone, so each snapshot name is restored once.
+	// +optional
+	RestoreFromSnapshot string `json:"restoreFromSnapshot,omitempty"`
+}
+
+// SaveReclaimPolicy is what happens to a save volume when its SteamServer is deleted.
+// +kubebuilder:validation:Enum=Retain;Delete
+type SaveReclaimPolicy string
+
+const (
+	// SaveReclaimRetain keeps the save volume when the SteamServer is deleted.
+	SaveReclaimRetain SaveReclaimPolicy = "Retain"
+	// SaveReclaimDelete deletes the save volume with the SteamServer.
+	SaveReclaimDelete SaveReclaimPolicy = "Delete"
+)
+
+// SnapshotSpec configures pre-update VolumeSnapshots of the server volume.
+type SnapshotSpec struct {
+	// VolumeSnapshotClassName is the VolumeSnapshotClass to use.
+	// If not specified, the default VolumeSnapshotClass of the volume's driver is used.
+	// +optional
+	VolumeSnapshotClassName *string `json:"volumeSnapshotClassName,omitempty"`
+
+	// Retention is the number of pre-update snapshots to keep.
+	// Snapshots are kept when the SteamServer is deleted, so a server recreated with the same
+	// name can restore from them.
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Retention int32 `json:"retention,omitempty"`
+}
+
+// BackupSpec configures scheduled backups of game server save data.
+type BackupSpec struct {
+	// Schedule is a cron expression (minute hour day-of-month month day-of-week) for backups,
+	// such as "0 */6 * * *".
+	// +kubebuilder:validation:Required
+	Schedule string `json:"schedule"`
+
+	// TimeZone is the IANA time zone of the schedule. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Retention is the number of backups to keep at the destination. Older backups are deleted.
+	// +kubebuilder:default=7
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Retention int32 `json:"retention,omitempty"`
+
+	// Paths are the files and directories to back up, relative to the server volume
+	// (the save volume if saveStorage is set). A GameDefinition save directory is kept
+	// at saves/<path> on that volume, such as saves/data/saves for /data/saves.
+	// +kubebuilder:validation:MinItems=1
+	Paths []string `json:"paths"`
+
+	// Destination is where backups are stored.
+	// +kubebuilder:validation:Required
+	Destination BackupDestination `json:"destination"`
+}
+
+// BackupDestination is where backups are stored. Exactly one destination must be set.
+type BackupDestination struct {
+	// PersistentVolumeClaim stores backups as files on a PVC in the server's namespace.
+	// +optional
+	PersistentVolumeClaim *PVCBackupDestination `json:"persistentVolumeClaim,omitempty"`
+
+	// S3 uploads backups to an S3-compatible object store, such as AWS S3 or MinIO.
+	// +optional
+	S3 *S3BackupDestination `json:"s3,omitempty"`
+}
+
+// PVCBackupDestination stores backups on a PersistentVolumeClaim.
+type PVCBackupDestination struct {
+	// ClaimName is the name of the PVC. It must be mountable by the backup Job alongside the server.
+	// +kubebuilder:validation:Required
+	ClaimName string `json:"claimName"`
+
+	// Path is the directory on the PVC to store backups in. Defaults to the server name.
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// S3BackupDestination stores backups in an S3-compatible bucket.
+type S3BackupDestination struct {
+	// Endpoint is the URL of the object store, such as "https://s3.us-east-1.amazonaws.com"
+	// or "http://minio.minio:9000". Requests use path-style bucket addressing.
+	// +kubebuilder:validation:Required
+	Endpoint string `json:"endpoint"`
+
+	// Bucket is the bucket to store backups in.
+	// +kubebuilder:validation:Required
+	Bucket string `json:"bucket"`
+
+	// Prefix is prepended to backup object keys, such as "boilerr/valheim/".
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Region is the region of the bucket. Defaults to us-east-1.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// CredentialsSecretRef references a Secret with AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY keys.
+	// +kubebuilder:validation:Required
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+}
+
+// BackupResult is the outcome of a backup.
+// +kubebuilder:validation:Enum=Succeeded;Failed
+type BackupResult string
+
+const (
+	// BackupSucceeded means the backup was stored.
+	BackupSucceeded BackupResult = "Succeeded"
+	// BackupFailed means the backup Job failed.
+	BackupFailed BackupResult = "Failed"
+)
+
+// BackupStatus records a completed backup Job.
+type BackupStatus struct {
+	// Job is the name of the backup Job.
+	Job string `json:"job"`
+
+	// Time is when the backup finished.
+	Time metav1.Time `json:"time"`
+
+	// Result is whether the backup succeeded.
+	Result BackupResult `json:"result"`
+
+	// Location is the file path or s3:// URL of the backup archive.
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// SizeBytes is the size of the backup archive.
+	// +optional
+	SizeBytes int64 `json:"sizeBytes,omitempty"`
+
+	// Message describes why a backup failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ScheduleSpec configures scheduled restarts and maintenance windows.
+type ScheduleSpec struct {
+	// Restarts are cron expressions (minute hour day-of-month month day-of-week) at which
+	// the game server is restarted, such as "0 5 * * *".
+	// +optional
+	Restarts []string `json:"restarts,omitempty"`
+
+	// TimeZone is the IANA time zone of the cron expressions, such as "America/Denver".
+	// Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// PreRestart sends RCON commands, such as a broadcast or world save, before a scheduled restart.
+	// +optional
+	PreRestart *RCONCommands `json:"preRestart,omitempty"`
+
+	// MaintenanceWindow limits when updatePolicy Automatic may restart the server to install a new build.
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+}
+
+// MaintenanceWindow is a recurring period in which automatic updates may be applied.
+type MaintenanceWindow struct {
+	// Start is a cron expression for when the window opens, such as "0 3 * * *".
+	// +kubebuilder:validation:Required
+	Start string `json:"start"`
+
+	// Duration is how long the window stays open.
+	// +kubebuilder:validation:Required
+	Duration metav1.Duration `json:"duration"`
+}
+
+// RCONCommands are commands sent to the game server over the Source RCON protocol.
+type RCONCommands struct {
+	// Port is the RCON port, by number or by name from ports.
+	// +kubebuilder:validation:Required
+	Port intstr.IntOrString `json:"port"`
+
+	// PasswordSecretRef references the Secret key holding the RCON password.
+	// +kubebuilder:validation:Required
+	PasswordSecretRef corev1.SecretKeySelector `json:"passwordSecretRef"`
+
+	// Commands are run in order.
+	// +kubebuilder:validation:MinItems=1
+	Commands []string `json:"commands"`
+}
+
+// IdleShutdown suspends a game server that has had no players connected.
+// The player count comes from the A2S query in status.serverInfo.
+type IdleShutdown struct {
+	// After is how long the server must report zero players before it is suspended.
+	// +kubebuilder:validation:Required
+	After metav1.Duration `json:"after"`
+}
+
+// ForceUpdateAnnotation applies a change held by updateStrategy.drain without waiting for players to leave.
+// Set it to "true" on the SteamServer; the controller removes it once the change is applied.
+const ForceUpdateAnnotation = "boilerr.dev/force-update"
+
+// UpdateStrategy controls how changes that restart the game server are applied.
+type UpdateStrategy struct {
+	// Drain holds changes that restart the pod until no players are connected.
+	// Without it, changes are applied immediately.
+	// +optional
+	Drain *DrainStrategy `json:"drain,omitempty"`
+}
+
+// DrainStrategy waits for players to leave before restarting the game server.
+// The player count comes from the A2S query in status.serverInfo, or from RCON
+// when the server has no server info. Without either, changes are applied immediately.
+type DrainStrategy struct {
+	// MaxWait is the longest a change is held while players are connected.
+	// +kubebuilder:default="1h"
+	// +optional
+	MaxWait *metav1.Duration `json:"maxWait,omitempty"`
+
+	// RCON reads the player count over RCON when status.serverInfo is missing,
+	// such as for games without an A2S query port.
+	// +optional
+	RCON *RCONPlayerCount `json:"rcon,omitempty"`
+}
+
+// RCONPlayerCount reads the number of connected players from the output of an RCON command.
+type RCONPlayerCount struct {
+	// Port is the RCON port, by number or by name from ports.
+	// +kubebuilder:validation:Required
+	Port intstr.IntOrString `json:"port"`
+
+	// PasswordSecretRef references the Secret key holding the RCON password.
+	// +kubebuilder:validation:Required
+	PasswordSecretRef corev1.SecretKeySelector `json:"passwordSecretRef"`
+
+	// Command lists the connected players, such as "list" or "ListPlayers".
+	// +kubebuilder:validation:MinLength=1
+	Command string `json:"command"`
+
+	// Pattern is a regular expression matched against the command output.
+	// With a capture group, the group of the first match is the player count
+	// (e.g. "There are ([0-9]+) of"); without one, each matching line counts as one player.
+	// +kubebuilder:validation:MinLength=1
+	Pattern string `json:"pattern"`
+}
+
+// UpdatePolicy controls how new Steam builds of a game are picked up.
+// +kubebuilder:validation:Enum=Manual;OnRestart;Automatic
+type UpdatePolicy string
+
+const (
+	// UpdatePolicyManual does not check for new builds. SteamCMD updates the game whenever the pod restarts.
+	UpdatePolicyManual UpdatePolicy = "Manual"
+
+	// UpdatePolicyOnRestart checks for new builds and reports them in status.
+	// The update is installed the next time the pod restarts.
+	UpdatePolicyOnRestart UpdatePolicy = "OnRestart"
+
+	// UpdatePolicyAutomatic checks for new builds and restarts the pod to install them.
+	UpdatePolicyAutomatic UpdatePolicy = "Automatic"
+)
+
+// HealthCheckOverride overrides the probe thresholds from GameDefinition.healthCheck.
+type HealthCheckOverride struct {
+	// InitialDelaySeconds before the startup probe begins.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+
+	// PeriodSeconds between readiness and liveness checks.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+
+	// FailureThreshold is the number of consecutive failed readiness or liveness checks
+	// before the server is marked unready or restarted.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+
+	// StartupFailureThreshold is the number of failed startup checks allowed before the
+	// container is restarted. Raise this for games with long world loads.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	StartupFailureThreshold *int32 `json:"startupFailureThreshold,omitempty"`
 }
 
 // SteamServerStatus defines the observed state of a Steam dedicated game server.
 type SteamServerStatus struct {
 	// State is the current state of the game server.
-	// +kubebuilder:validation:Enum=Pending;Installing;Starting;Running;Error
+	// +kubebuilder:validation:Enum=Pending;Installing;Starting;Running;Error;Stopped
 	// +optional
 	State ServerState `json:"state,omitempty"`
 
@@ -103,21 +535,194 @@ type SteamServerStatus struct {
 	// +optional
 	LastUpdated *metav1.Time `json:"lastUpdated,omitempty"`
 
+	// LastStoppedAt is when the server last stopped after being suspended.
+	// +optional
+	LastStoppedAt *metav1.Time `json:"lastStoppedAt,omitempty"`
+
+	// LastScheduledRestart is when the server was last restarted by schedule.restarts.
+	// +optional
+	LastScheduledRestart *metav1.Time `json:"lastScheduledRestart,omitempty"`
+
+	// NextScheduledRestart is when schedule.restarts will next restart the server.
+	// +optional
+	NextScheduledRestart *metav1.Time `json:"nextScheduledRestart,omitempty"`
+
+	// Backups lists the most recent backups, newest first, up to backup.retention entries.
+	// +optional
+	Backups []BackupStatus `json:"backups,omitempty"`
+
+	// IdleSince is when the server started reporting zero players.
+	// Only tracked while the server is Running with idleShutdown set.
+	// +optional
+	IdleSince *metav1.Time `json:"idleSince,omitempty"`
+
+	// WokenAt is when a player connecting through wakeOnConnect resumed the suspended server.
+	// While set, the server runs even though spec.suspended is set. Cleared when idleShutdown
+	// suspends the server again or spec.suspended is cleared.
+	// +optional
+	WokenAt *metav1.Time `json:"wokenAt,omitempty"`
+
 	// AppBuildId is the current Steam build ID of the installed game.
 	// +optional
 	AppBuildId string `json:"appBuildId,omitempty"`
 
+	// LatestBuildId is the latest Steam build ID published for the game's branch.
+	// Only checked when updatePolicy is OnRestart or Automatic.
+	// +optional
+	LatestBuildId string `json:"latestBuildId,omitempty"`
+
+	// Mods are the enabled Workshop items installed by the last completed install.
+	// +optional
+	Mods []InstalledMod `json:"mods,omitempty"`
+
+	// Install is the progress of the running SteamCMD install, or the outcome of the last one.
+	// Reports the attempt of an install retried according to spec.install.
+	// +optional
+	Install *InstallStatus `json:"install,omitempty"`
+
+	// GameCache is the game cache volume of the build the server targets, when spec.gameCache is set.
+	// The server keeps its current game cache until this one is populated.
+	// +optional
+	GameCache string `json:"gameCache,omitempty"`
+
 	// Message provides a human-readable status message or error.
 	// +optional
 	Message string `json:"message,omitempty"`
 
+	// ServerInfo is the live server information advertised over the Steam A2S query protocol.
+	// Cleared when the server is neither Starting nor Running, or stops replying.
+	// +optional
+	ServerInfo *ServerInfoStatus `json:"serverInfo,omitempty"`
+
 	// Conditions represent the latest available observations of the server's state.
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// InstallStatus is the progress and outcome of a SteamCMD install, parsed from its output.
+type InstallStatus struct {
+	// Attempt is the SteamCMD run of the install, from 1 to 1 + spec.install.retries.
+	// +optional
+	Attempt int32 `json:"attempt,omitempty"`
+
+	// UpdateState is the current SteamCMD update state, e.g. "downloading" or "verifying install".
+	// +optional
+	UpdateState string `json:"updateState,omitempty"`
+
+	// Progress is the percentage of the update state completed, 100 once the app is fully installed.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Progress int32 `json:"progress,omitempty"`
+
+	// BytesDownloaded is the number of bytes of the update state completed.
+	// +optional
+	BytesDownloaded int64 `json:"bytesDownloaded,omitempty"`
+
+	// BytesTotal is the number of bytes of the update state.
+	// +optional
+	BytesTotal int64 `json:"bytesTotal,omitempty"`
+
+	// Reason is why the last install failed, in CamelCase, e.g. NoSubscription, DiskWriteFailure
+	// or InvalidPassword. SteamCMDError if SteamCMD gave no specific reason.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is the SteamCMD error of the last failed install.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// InstalledMod is an installed Steam Workshop item.
+type InstalledMod struct {
+	// ID is the Workshop item ID.
+	ID int64 `json:"id"`
+
+	// Version is the manifest ID of the installed version, which mods[].version pins.
+	Version string `json:"version"`
+
+	// TimeUpdated is when the installed version was published to the Workshop.
+	// +optional
+	TimeUpdated *metav1.Time `json:"timeUpdated,omitempty"`
+}
+
+// ServerInfoStatus is the server information reported by A2S_INFO and A2S_PLAYER queries.
+type ServerInfoStatus struct {
+	// Name is the server name as advertised to players.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Map is the current map or world.
+	// +optional
+	Map string `json:"map,omitempty"`
+
+	// Players is the number of players currently connected.
+	Players int32 `json:"players"`
+
+	// MaxPlayers is the maximum number of players the server accepts.
+	MaxPlayers int32 `json:"maxPlayers"`
+
+	// PlayerNames lists the connected players, if the server answers A2S_PLAYER.
+	// +optional
+	PlayerNames []string `json:"playerNames,omitempty"`
+
+	// VAC indicates whether the server is VAC secured.
+	// +optional
+	VAC bool `json:"vac,omitempty"`
+
+	// Version is the game version string advertised by the server.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// LastUpdated is when this information last changed.
+	// +optional
+	LastUpdated *metav1.Time `json:"lastUpdated,omitempty"`
+}
+
+// Condition types for SteamServer.
+const (
+	// ConditionInstalled indicates the game files are installed.
+	// Its last transition time is when the current build was installed.
+	ConditionInstalled = "Installed"
+
+	// ConditionInstallFailed indicates SteamCMD failed on every attempt of the last install.
+	// Its reason is status.install.reason.
+	ConditionInstallFailed = "InstallFailed"
+
+	// ConditionUpdateAvailable indicates a newer Steam build is published than the one installed.
+	ConditionUpdateAvailable = "UpdateAvailable"
+
+	// ConditionPendingUpdate indicates a change that restarts the pod is held until players leave.
+	// Its last transition time is when the change started waiting.
+	ConditionPendingUpdate = "PendingUpdate"
+
+	// ConditionPreUpdateSnapshot indicates the VolumeSnapshot taken before the pending update is ready.
+	ConditionPreUpdateSnapshot = "PreUpdateSnapshot"
+
+	// ConditionRestoringSnapshot indicates the server volume is being recreated from storage.restoreFromSnapshot.
+	ConditionRestoringSnapshot = "RestoringSnapshot"
+
+	// ConditionStorageResizing indicates the server volume is being expanded to a larger storage.size.
+	ConditionStorageResizing = "StorageResizing"
+
+	// ConditionFileSystemResizePending indicates the volume was expanded and waits for the node to grow its file system.
+	ConditionFileSystemResizePending = "FileSystemResizePending"
+
+	// ConditionGameCacheReady indicates the game cache volume for the target build is populated.
+	// An update to a new build is held until it is.
+	ConditionGameCacheReady = "GameCacheReady"
+
+	// ConditionModVersionMismatch indicates an installed Workshop mod is not at its pinned mods[].version.
+	// The server still starts with the installed version.
+	ConditionModVersionMismatch = "ModVersionMismatch"
+
+	// ConditionWakeRequested indicates a player connected to a suspended server and it is starting.
+	// Cleared once the server is Running.
+	ConditionWakeRequested = "WakeRequested"
+)
+
 // ServerState represents the current state of a game server.
-// +kubebuilder:validation:Enum=Pending;Installing;Starting;Running;Error
+// +kubebuilder:validation:Enum=Pending;Installing;Starting;Running;Error;Stopped
 type ServerState string
 
 const (
@@ -135,6 +740,9 @@ const (
 
 	// ServerStateError indicates an error occurred.
 	ServerStateError ServerState = "Error"
+
+	// ServerStateStopped indicates the server is suspended and scaled to zero.
+	ServerStateStopped ServerState = "Stopped"
 )
 
 // +kubebuilder:object:root=true
@@ -143,6 +751,7 @@ const (
 // +kubebuilder:printcolumn:name="Game",type="string",JSONPath=".spec.gameDefinition",description="Game definition"
 // +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="Server state"
 // +kubebuilder:printcolumn:name="Address",type="string",JSONPath=".status.address",description="External address"
+// +kubebuilder:printcolumn:name="Players",type="integer",JSONPath=".status.serverInfo.players",description="Connected players"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // SteamServer is the Schema for the steamservers API.
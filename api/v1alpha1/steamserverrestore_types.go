@@ -0,0 +1,153 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RestoreLockAnnotation marks a SteamServer as being restored by the named SteamServerRestore.
+// While it is set, the server is kept scaled to zero and its backups and scheduled restarts are paused.
+const RestoreLockAnnotation = "boilerr.dev/restore-in-progress"
+
+// SteamServerRestoreSpec defines which archive to restore into which SteamServer.
+type SteamServerRestoreSpec struct {
+	// ServerName is the SteamServer in the same namespace to restore.
+	// The archive is extracted into its save volume if saveStorage is set, otherwise its server volume.
+	// +kubebuilder:validation:Required
+	ServerName string `json:"serverName"`
+
+	// Source is the tar.gz archive to restore, such as one listed in the server's status.backups.
+	// +kubebuilder:validation:Required
+	Source RestoreSource `json:"source"`
+}
+
+// RestoreSource is where the archive to restore is read from. Exactly one source must be set.
+type RestoreSource struct {
+	// PersistentVolumeClaim reads the archive from a file on a PVC in the same namespace.
+	// +optional
+	PersistentVolumeClaim *PVCRestoreSource `json:"persistentVolumeClaim,omitempty"`
+
+	// S3 downloads the archive from an S3-compatible object store.
+	// +optional
+	S3 *S3RestoreSource `json:"s3,omitempty"`
+}
+
+// PVCRestoreSource is an archive file on a PersistentVolumeClaim.
+type PVCRestoreSource struct {
+	// ClaimName is the name of the PVC.
+	// +kubebuilder:validation:Required
+	ClaimName string `json:"claimName"`
+
+	// Path is the path of the archive on the PVC, such as "valheim/valheim-20260101T060000Z.tar.gz".
+	// +kubebuilder:validation:Required
+	Path string `json:"path"`
+}
+
+// S3RestoreSource is an archive object in an S3-compatible bucket.
+type S3RestoreSource struct {
+	// Endpoint is the URL of the object store, such as "http://minio.minio:9000".
+	// Requests use path-style bucket addressing.
+	// +kubebuilder:validation:Required
+	Endpoint string `json:"endpoint"`
+
+	// Bucket is the bucket holding the archive.
+	// +kubebuilder:validation:Required
+	Bucket string `json:"bucket"`
+
+	// Key is the object key of the archive.
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+
+	// Region is the region of the bucket. Defaults to us-east-1.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// CredentialsSecretRef references a Secret with AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY keys.
+	// +kubebuilder:validation:Required
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+}
+
+// RestorePhase is the phase of a SteamServerRestore.
+// +kubebuilder:validation:Enum=Pending;Suspending;Restoring;Resuming;Completed;Failed
+type RestorePhase string
+
+const (
+	// RestorePhasePending means the restore is waiting to lock the server.
+	RestorePhasePending RestorePhase = "Pending"
+	// RestorePhaseSuspending means the server is locked and its pod is stopping.
+	RestorePhaseSuspending RestorePhase = "Suspending"
+	// RestorePhaseRestoring means the restore Job is extracting the archive.
+	RestorePhaseRestoring RestorePhase = "Restoring"
+	// RestorePhaseResuming means the archive was restored and the server is being unlocked.
+	RestorePhaseResuming RestorePhase = "Resuming"
+	// RestorePhaseCompleted means the archive was restored and the server unlocked.
+	RestorePhaseCompleted RestorePhase = "Completed"
+	// RestorePhaseFailed means the restore failed. The server is unlocked.
+	RestorePhaseFailed RestorePhase = "Failed"
+)
+
+// SteamServerRestoreStatus defines the observed state of a SteamServerRestore.
+type SteamServerRestoreStatus struct {
+	// Phase is the current phase of the restore.
+	// +optional
+	Phase RestorePhase `json:"phase,omitempty"`
+
+	// Progress describes the current step of the restore.
+	// +optional
+	Progress string `json:"progress,omitempty"`
+
+	// Message describes why the restore failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Job is the name of the restore Job.
+	// +optional
+	Job string `json:"job,omitempty"`
+
+	// StartTime is when the restore started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the restore completed or failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// RestoredFiles is the number of files restored.
+	// +optional
+	RestoredFiles int64 `json:"restoredFiles,omitempty"`
+
+	// RestoredBytes is the number of bytes restored.
+	// +optional
+	RestoredBytes int64 `json:"restoredBytes,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=ssr
+// +kubebuilder:printcolumn:name="Server",type="string",JSONPath=".spec.serverName",description="Server being restored"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Restore phase"
+// +kubebuilder:printcolumn:name="Progress",type="string",JSONPath=".status.progress",description="Current step"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SteamServerRestore is the Schema for the steamserverrestores API.
+// It stops a SteamServer, restores a backup archive into its volume, and starts it again.
+type SteamServerRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SteamServerRestoreSpec   `json:"spec,omitempty"`
+	Status SteamServerRestoreStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SteamServerRestoreList contains a list of SteamServerRestore.
+type SteamServerRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SteamServerRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SteamServerRestore{}, &SteamServerRestoreList{})
+}
@@ -26,6 +26,84 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *A2SHealthCheck) DeepCopyInto(out *A2SHealthCheck) {
+	*out = *in
+	out.Port = in.Port
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new A2SHealthCheck.
+func (in *A2SHealthCheck) DeepCopy() *A2SHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(A2SHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupDestination) DeepCopyInto(out *BackupDestination) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(PVCBackupDestination)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3BackupDestination)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupDestination.
+func (in *BackupDestination) DeepCopy() *BackupDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupSpec) DeepCopyInto(out *BackupSpec) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Destination.DeepCopyInto(&out.Destination)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSpec.
+func (in *BackupSpec) DeepCopy() *BackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStatus.
+func (in *BackupStatus) DeepCopy() *BackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigFile) DeepCopyInto(out *ConfigFile) {
 	*out = *in
@@ -116,6 +194,31 @@ func (in *ConfigValue) DeepCopy() *ConfigValue {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainStrategy) DeepCopyInto(out *DrainStrategy) {
+	*out = *in
+	if in.MaxWait != nil {
+		in, out := &in.MaxWait, &out.MaxWait
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RCON != nil {
+		in, out := &in.RCON, &out.RCON
+		*out = new(RCONPlayerCount)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainStrategy.
+func (in *DrainStrategy) DeepCopy() *DrainStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GameDefinition) DeepCopyInto(out *GameDefinition) {
 	*out = *in
@@ -213,6 +316,16 @@ func (in *GameDefinitionSpec) DeepCopyInto(out *GameDefinitionSpec) {
 		*out = new(HealthCheckSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SaveDirs != nil {
+		in, out := &in.SaveDirs, &out.SaveDirs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Workshop != nil {
+		in, out := &in.Workshop, &out.Workshop
+		*out = new(WorkshopSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameDefinitionSpec.
@@ -247,6 +360,41 @@ func (in *GameDefinitionStatus) DeepCopy() *GameDefinitionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckOverride) DeepCopyInto(out *HealthCheckOverride) {
+	*out = *in
+	if in.InitialDelaySeconds != nil {
+		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StartupFailureThreshold != nil {
+		in, out := &in.StartupFailureThreshold, &out.StartupFailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckOverride.
+func (in *HealthCheckOverride) DeepCopy() *HealthCheckOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HealthCheckSpec) DeepCopyInto(out *HealthCheckSpec) {
 	*out = *in
@@ -255,6 +403,11 @@ func (in *HealthCheckSpec) DeepCopyInto(out *HealthCheckSpec) {
 		*out = new(v1.TCPSocketAction)
 		**out = **in
 	}
+	if in.A2S != nil {
+		in, out := &in.A2S, &out.A2S
+		*out = new(A2SHealthCheck)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckSpec.
@@ -267,6 +420,142 @@ func (in *HealthCheckSpec) DeepCopy() *HealthCheckSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdleShutdown) DeepCopyInto(out *IdleShutdown) {
+	*out = *in
+	out.After = in.After
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdleShutdown.
+func (in *IdleShutdown) DeepCopy() *IdleShutdown {
+	if in == nil {
+		return nil
+	}
+	out := new(IdleShutdown)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallSpec) DeepCopyInto(out *InstallSpec) {
+	*out = *in
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallSpec.
+func (in *InstallSpec) DeepCopy() *InstallSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallStatus) DeepCopyInto(out *InstallStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallStatus.
+func (in *InstallStatus) DeepCopy() *InstallStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstalledMod) DeepCopyInto(out *InstalledMod) {
+	*out = *in
+	if in.TimeUpdated != nil {
+		in, out := &in.TimeUpdated, &out.TimeUpdated
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstalledMod.
+func (in *InstalledMod) DeepCopy() *InstalledMod {
+	if in == nil {
+		return nil
+	}
+	out := new(InstalledMod)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModListFile) DeepCopyInto(out *ModListFile) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModListFile.
+func (in *ModListFile) DeepCopy() *ModListFile {
+	if in == nil {
+		return nil
+	}
+	out := new(ModListFile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PVCBackupDestination) DeepCopyInto(out *PVCBackupDestination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PVCBackupDestination.
+func (in *PVCBackupDestination) DeepCopy() *PVCBackupDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(PVCBackupDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PVCRestoreSource) DeepCopyInto(out *PVCRestoreSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PVCRestoreSource.
+func (in *PVCRestoreSource) DeepCopy() *PVCRestoreSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PVCRestoreSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PortStatus) DeepCopyInto(out *PortStatus) {
 	*out = *in
@@ -282,6 +571,177 @@ func (in *PortStatus) DeepCopy() *PortStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RCONCommands) DeepCopyInto(out *RCONCommands) {
+	*out = *in
+	out.Port = in.Port
+	in.PasswordSecretRef.DeepCopyInto(&out.PasswordSecretRef)
+	if in.Commands != nil {
+		in, out := &in.Commands, &out.Commands
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RCONCommands.
+func (in *RCONCommands) DeepCopy() *RCONCommands {
+	if in == nil {
+		return nil
+	}
+	out := new(RCONCommands)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RCONPlayerCount) DeepCopyInto(out *RCONPlayerCount) {
+	*out = *in
+	out.Port = in.Port
+	in.PasswordSecretRef.DeepCopyInto(&out.PasswordSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RCONPlayerCount.
+func (in *RCONPlayerCount) DeepCopy() *RCONPlayerCount {
+	if in == nil {
+		return nil
+	}
+	out := new(RCONPlayerCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreSource) DeepCopyInto(out *RestoreSource) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(PVCRestoreSource)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3RestoreSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreSource.
+func (in *RestoreSource) DeepCopy() *RestoreSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3BackupDestination) DeepCopyInto(out *S3BackupDestination) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BackupDestination.
+func (in *S3BackupDestination) DeepCopy() *S3BackupDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(S3BackupDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3RestoreSource) DeepCopyInto(out *S3RestoreSource) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3RestoreSource.
+func (in *S3RestoreSource) DeepCopy() *S3RestoreSource {
+	if in == nil {
+		return nil
+	}
+	out := new(S3RestoreSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SaveStorageSpec) DeepCopyInto(out *SaveStorageSpec) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SaveStorageSpec.
+func (in *SaveStorageSpec) DeepCopy() *SaveStorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SaveStorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleSpec) DeepCopyInto(out *ScheduleSpec) {
+	*out = *in
+	if in.Restarts != nil {
+		in, out := &in.Restarts, &out.Restarts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreRestart != nil {
+		in, out := &in.PreRestart, &out.PreRestart
+		*out = new(RCONCommands)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleSpec.
+func (in *ScheduleSpec) DeepCopy() *ScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerInfoStatus) DeepCopyInto(out *ServerInfoStatus) {
+	*out = *in
+	if in.PlayerNames != nil {
+		in, out := &in.PlayerNames, &out.PlayerNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastUpdated != nil {
+		in, out := &in.LastUpdated, &out.LastUpdated
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerInfoStatus.
+func (in *ServerInfoStatus) DeepCopy() *ServerInfoStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerInfoStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerPort) DeepCopyInto(out *ServerPort) {
 	*out = *in
@@ -297,6 +757,42 @@ func (in *ServerPort) DeepCopy() *ServerPort {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotSpec) DeepCopyInto(out *SnapshotSpec) {
+	*out = *in
+	if in.VolumeSnapshotClassName != nil {
+		in, out := &in.VolumeSnapshotClassName, &out.VolumeSnapshotClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotSpec.
+func (in *SnapshotSpec) DeepCopy() *SnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SteamGuardSpec) DeepCopyInto(out *SteamGuardSpec) {
+	*out = *in
+	in.SharedSecretRef.DeepCopyInto(&out.SharedSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SteamGuardSpec.
+func (in *SteamGuardSpec) DeepCopy() *SteamGuardSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SteamGuardSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SteamServer) DeepCopyInto(out *SteamServer) {
 	*out = *in
@@ -356,6 +852,104 @@ func (in *SteamServerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SteamServerRestore) DeepCopyInto(out *SteamServerRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SteamServerRestore.
+func (in *SteamServerRestore) DeepCopy() *SteamServerRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(SteamServerRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SteamServerRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SteamServerRestoreList) DeepCopyInto(out *SteamServerRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SteamServerRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SteamServerRestoreList.
+func (in *SteamServerRestoreList) DeepCopy() *SteamServerRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(SteamServerRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SteamServerRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SteamServerRestoreSpec) DeepCopyInto(out *SteamServerRestoreSpec) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SteamServerRestoreSpec.
+func (in *SteamServerRestoreSpec) DeepCopy() *SteamServerRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SteamServerRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SteamServerRestoreStatus) DeepCopyInto(out *SteamServerRestoreStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SteamServerRestoreStatus.
+func (in *SteamServerRestoreStatus) DeepCopy() *SteamServerRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SteamServerRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SteamServerSpec) DeepCopyInto(out *SteamServerSpec) {
 	*out = *in
@@ -403,11 +997,26 @@ func (in *SteamServerSpec) DeepCopyInto(out *SteamServerSpec) {
 		*out = new(StorageSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SaveStorage != nil {
+		in, out := &in.SaveStorage, &out.SaveStorage
+		*out = new(SaveStorageSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(HealthCheckOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BetaPasswordSecretRef != nil {
+		in, out := &in.BetaPasswordSecretRef, &out.BetaPasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Validate != nil {
 		in, out := &in.Validate, &out.Validate
 		*out = new(bool)
@@ -418,6 +1027,48 @@ func (in *SteamServerSpec) DeepCopyInto(out *SteamServerSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.SteamGuard != nil {
+		in, out := &in.SteamGuard, &out.SteamGuard
+		*out = new(SteamGuardSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Mods != nil {
+		in, out := &in.Mods, &out.Mods
+		*out = make([]WorkshopMod, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Install != nil {
+		in, out := &in.Install, &out.Install
+		*out = new(InstallSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(UpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IdleShutdown != nil {
+		in, out := &in.IdleShutdown, &out.IdleShutdown
+		*out = new(IdleShutdown)
+		**out = **in
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(ScheduleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(BackupSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = new(SnapshotSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SteamServerSpec.
@@ -442,6 +1093,50 @@ func (in *SteamServerStatus) DeepCopyInto(out *SteamServerStatus) {
 		in, out := &in.LastUpdated, &out.LastUpdated
 		*out = (*in).DeepCopy()
 	}
+	if in.LastStoppedAt != nil {
+		in, out := &in.LastStoppedAt, &out.LastStoppedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.LastScheduledRestart != nil {
+		in, out := &in.LastScheduledRestart, &out.LastScheduledRestart
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduledRestart != nil {
+		in, out := &in.NextScheduledRestart, &out.NextScheduledRestart
+		*out = (*in).DeepCopy()
+	}
+	if in.Backups != nil {
+		in, out := &in.Backups, &out.Backups
+		*out = make([]BackupStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IdleSince != nil {
+		in, out := &in.IdleSince, &out.IdleSince
+		*out = (*in).DeepCopy()
+	}
+	if in.WokenAt != nil {
+		in, out := &in.WokenAt, &out.WokenAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Mods != nil {
+		in, out := &in.Mods, &out.Mods
+		*out = make([]InstalledMod, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Install != nil {
+		in, out := &in.Install, &out.Install
+		*out = new(InstallStatus)
+		**out = **in
+	}
+	if in.ServerInfo != nil {
+		in, out := &in.ServerInfo, &out.ServerInfo
+		*out = new(ServerInfoStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -481,3 +1176,63 @@ func (in *StorageSpec) DeepCopy() *StorageSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdateStrategy) DeepCopyInto(out *UpdateStrategy) {
+	*out = *in
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(DrainStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateStrategy.
+func (in *UpdateStrategy) DeepCopy() *UpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkshopMod) DeepCopyInto(out *WorkshopMod) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkshopMod.
+func (in *WorkshopMod) DeepCopy() *WorkshopMod {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkshopMod)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkshopSpec) DeepCopyInto(out *WorkshopSpec) {
+	*out = *in
+	if in.ModList != nil {
+		in, out := &in.ModList, &out.ModList
+		*out = new(ModListFile)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkshopSpec.
+func (in *WorkshopSpec) DeepCopy() *WorkshopSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkshopSpec)
+	in.DeepCopyInto(out)
+	return out
+}
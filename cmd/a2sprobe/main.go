@@ -0,0 +1,58 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command a2sprobe is a health check for game servers that only listen on UDP.
+// It sends an A2S_INFO query and exits 0 if the server replies with a valid response.
+//
+// It is shipped in the operator image and copied into game server pods with -install,
+// so it can run as an exec probe in the game server container.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/CraightonH/boilerr/internal/a2s"
+	"github.com/CraightonH/boilerr/internal/selfinstall"
+)
+
+func main() {
+	var addr string
+	var timeout time.Duration
+	var installPath string
+	flag.StringVar(&addr, "addr", "127.0.0.1:27015", "The host:port of the A2S query port.")
+	flag.DurationVar(&timeout, "timeout", a2s.DefaultTimeout, "How long to wait for a reply.")
+	flag.StringVar(&installPath, "install", "", "Copy this binary to the given path and exit.")
+	flag.Parse()
+
+	if installPath != "" {
+		if err := selfinstall.Install(installPath); err != nil {
+			fmt.Fprintf(os.Stderr, "install failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	info, err := a2s.NewClient(timeout).QueryInfo(context.Background(), addr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unhealthy: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("healthy: %q players=%d/%d\n", info.Name, info.Players, info.MaxPlayers)
+}
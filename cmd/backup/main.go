@@ -0,0 +1,178 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command backup archives game server save data into a directory or an S3-compatible bucket,
+// and restores those archives.
+//
+// It is shipped in the operator image. "backup create" is run by the backup CronJob of each
+// SteamServer with spec.backup, and "backup restore" by the Job of a SteamServerRestore.
+// The result is written as JSON to the termination message for the controller to record.
+// S3 credentials are read from AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/CraightonH/boilerr/internal/backup"
+)
+
+// s3Flags are the flags selecting an S3-compatible store.
+type s3Flags struct {
+	endpoint, bucket, region string
+}
+
+func (f *s3Flags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.endpoint, "s3-endpoint", "", "The URL of the S3-compatible store.")
+	fs.StringVar(&f.bucket, "s3-bucket", "", "The bucket holding archives.")
+	fs.StringVar(&f.region, "s3-region", backup.DefaultS3Region, "The region of the S3-compatible store.")
+}
+
+func (f *s3Flags) set() bool {
+	return f.endpoint != "" && f.bucket != ""
+}
+
+func (f *s3Flags) client() *backup.S3Client {
+	return &backup.S3Client{
+		Endpoint:  f.endpoint,
+		Region:    f.region,
+		AccessKey: os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	switch os.Args[1] {
+	case "create":
+		create(ctx, os.Args[2:])
+	case "restore":
+		restore(ctx, os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: backup create|restore [flags]")
+	os.Exit(2)
+}
+
+// create archives the save paths and stores the archive.
+func create(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("create", flag.ExitOnError)
+	var opts backup.Options
+	var paths string
+	var dir, s3Prefix string
+	var s3 s3Flags
+	var terminationLog string
+	fs.StringVar(&opts.Root, "root", "/serverfiles", "The directory the paths are relative to.")
+	fs.StringVar(&paths, "paths", "", "Comma-separated paths to back up, relative to -root.")
+	fs.StringVar(&opts.Name, "name", "", "The server name, used as the archive name prefix.")
+	fs.IntVar(&opts.Retention, "retention", 0, "The number of archives to keep. 0 keeps all.")
+	fs.StringVar(&dir, "dir", "", "The directory to store archives in.")
+	fs.StringVar(&s3Prefix, "s3-prefix", "", "The key prefix of uploaded archives.")
+	s3.register(fs)
+	fs.StringVar(&terminationLog, "termination-log", "/dev/termination-log", "Where to write the JSON result.")
+	_ = fs.Parse(args)
+
+	if opts.Name == "" || paths == "" {
+		fail(terminationLog, fmt.Errorf("-name and -paths are required"))
+	}
+	opts.Paths = strings.Split(paths, ",")
+
+	var store backup.Store
+	switch {
+	case dir != "":
+		store = &backup.DirStore{Dir: dir}
+	case s3.set():
+		store = &backup.S3Store{Client: s3.client(), Bucket: s3.bucket, Prefix: s3Prefix}
+	default:
+		fail(terminationLog, fmt.Errorf("-dir or -s3-endpoint and -s3-bucket are required"))
+	}
+
+	result, err := backup.Create(ctx, store, opts, time.Now())
+	if err != nil {
+		fail(terminationLog, err)
+	}
+	fmt.Printf("backed up %s (%d bytes) to %s\n", result.Name, result.Size, result.Location)
+	succeed(terminationLog, result)
+}
+
+// restore extracts an archive from a file or an S3 object into the server volume.
+func restore(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	var root, file, s3Key string
+	var s3 s3Flags
+	var terminationLog string
+	fs.StringVar(&root, "root", "/serverfiles", "The directory to restore into.")
+	fs.StringVar(&file, "file", "", "The archive file to restore.")
+	fs.StringVar(&s3Key, "s3-key", "", "The key of the archive object to restore.")
+	s3.register(fs)
+	fs.StringVar(&terminationLog, "termination-log", "/dev/termination-log", "Where to write the JSON result.")
+	_ = fs.Parse(args)
+
+	var archive io.ReadCloser
+	var err error
+	switch {
+	case file != "":
+		archive, err = os.Open(file)
+	case s3.set() && s3Key != "":
+		archive, err = s3.client().GetObject(ctx, s3.bucket, s3Key)
+	default:
+		err = fmt.Errorf("-file or -s3-endpoint, -s3-bucket and -s3-key are required")
+	}
+	if err != nil {
+		fail(terminationLog, err)
+	}
+	defer func() { _ = archive.Close() }()
+
+	result, err := backup.Restore(archive, root)
+	if err != nil {
+		fail(terminationLog, err)
+	}
+	fmt.Printf("restored %d files (%d bytes) to %s\n", result.Files, result.Size, root)
+	succeed(terminationLog, result)
+}
+
+// succeed writes the JSON result to the termination log.
+func succeed(terminationLog string, result any) {
+	data, _ := json.Marshal(result)
+	if err := os.WriteFile(terminationLog, data, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "writing termination log: %v\n", err)
+	}
+}
+
+// fail reports err in the termination log and exits.
+func fail(terminationLog string, err error) {
+	fmt.Fprintf(os.Stderr, "%s failed: %v\n", os.Args[1], err)
+	_ = os.WriteFile(terminationLog, []byte(err.Error()), 0o644)
+	os.Exit(1)
+}
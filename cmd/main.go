@@ -20,6 +20,9 @@ import (
 	"crypto/tls"
 	"flag"
 	"os"
+	"time"
+	// Embed the time zone database for schedule.timeZone on images without one
+	_ "time/tzdata"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -27,6 +30,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
@@ -37,6 +41,7 @@ import (
 
 	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
 	"github.com/CraightonH/boilerr/internal/controller"
+	"github.com/CraightonH/boilerr/internal/steamapi"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -58,12 +63,43 @@ func main() {
 	var webhookCertPath, webhookCertName, webhookCertKey string
 	var enableLeaderElection bool
 	var probeAddr string
+	var probeImage string
+	var serverInfoInterval, serverInfoTimeout time.Duration
+	var serverInfoWorkers int
+	var updateCheckInterval time.Duration
+	var steamAppInfoURL string
+	var wakeAddr, wakeURL, wakeProxyImage string
+	var backupImage string
+	var gameCacheStorageClass string
 	var secureMetrics bool
 	var enableHTTP2 bool
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.StringVar(&probeImage, "probe-image", "", "The operator image that provides the a2sprobe binary for "+
+		"A2S exec probes and the steamguard helper for Steam Guard logins. If empty, A2S health checks use "+
+		"the replies of the server info poller instead, and SteamServers can't use steamGuard.")
+	flag.DurationVar(&serverInfoInterval, "server-info-interval", controller.DefaultServerInfoInterval,
+		"How often to query starting and running game servers over A2S for status.serverInfo.")
+	flag.DurationVar(&serverInfoTimeout, "server-info-timeout", controller.DefaultServerInfoTimeout,
+		"The timeout for querying a single game server over A2S.")
+	flag.IntVar(&serverInfoWorkers, "server-info-workers", controller.DefaultServerInfoWorkers,
+		"The number of game servers queried concurrently over A2S.")
+	flag.DurationVar(&updateCheckInterval, "update-check-interval", controller.DefaultUpdateCheckInterval,
+		"How often to check for new Steam builds of games with an OnRestart or Automatic updatePolicy.")
+	flag.StringVar(&steamAppInfoURL, "steam-app-info-url", steamapi.DefaultBaseURL,
+		"The SteamCMD app info API used to look up the latest Steam builds.")
+	flag.StringVar(&wakeAddr, "wake-bind-address", "0", "The address the wake endpoint for wake proxies binds to. "+
+		"Use \"0\" to disable it.")
+	flag.StringVar(&wakeURL, "wake-url", "", "The base URL of the wake endpoint as reached from game server pods. "+
+		"wakeOnConnect has no effect if empty.")
+	flag.StringVar(&wakeProxyImage, "wake-proxy-image", "", "The operator image that provides the wakeproxy binary "+
+		"for wakeOnConnect.")
+	flag.StringVar(&backupImage, "backup-image", "", "The operator image that provides the backup binary "+
+		"for spec.backup. If empty, spec.backup has no effect.")
+	flag.StringVar(&gameCacheStorageClass, "game-cache-storage-class", "", "The StorageClass of the shared game "+
+		"cache volumes for spec.gameCache. It must support ReadOnlyMany. If empty, spec.gameCache is rejected.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -185,13 +221,70 @@ func main() {
 		os.Exit(1)
 	}
 
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create Kubernetes clientset")
+		os.Exit(1)
+	}
+
+	buildSource := steamapi.NewClient(steamAppInfoURL)
 	if err := (&controller.SteamServerReconciler{
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		ProbeImage:            probeImage,
+		WakeProxyImage:        wakeProxyImage,
+		WakeURL:               wakeURL,
+		BackupImage:           backupImage,
+		APIReader:             mgr.GetAPIReader(),
+		GameCacheStorageClass: gameCacheStorageClass,
+		Logs:                  &controller.PodLogReader{Clientset: clientset},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SteamServer")
+		os.Exit(1)
+	}
+	if err := (&controller.SteamServerRestoreReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
+		Image:  backupImage,
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "SteamServer")
+		setupLog.Error(err, "unable to create controller", "controller", "SteamServerRestore")
+		os.Exit(1)
+	}
+	if err := mgr.Add(&controller.ServerInfoPoller{
+		Client:   mgr.GetClient(),
+		Interval: serverInfoInterval,
+		Timeout:  serverInfoTimeout,
+		Workers:  serverInfoWorkers,
+		Recorder: mgr.GetEventRecorderFor("boilerr-idle-shutdown"),
+	}); err != nil {
+		setupLog.Error(err, "unable to set up server info poller")
 		os.Exit(1)
 	}
+	if err := mgr.Add(&controller.UpdateChecker{
+		Client:   mgr.GetClient(),
+		Source:   buildSource,
+		Interval: updateCheckInterval,
+	}); err != nil {
+		setupLog.Error(err, "unable to set up update checker")
+		os.Exit(1)
+	}
+	if gameCacheStorageClass != "" {
+		if err := mgr.Add(&controller.GameCachePruner{Client: mgr.GetClient()}); err != nil {
+			setupLog.Error(err, "unable to set up game cache pruner")
+			os.Exit(1)
+		}
+	}
+	if wakeAddr != "0" {
+		if err := mgr.Add(&controller.WakeServer{
+			Client:      mgr.GetClient(),
+			APIReader:   mgr.GetAPIReader(),
+			BindAddress: wakeAddr,
+			Recorder:    mgr.GetEventRecorderFor("boilerr-wake"),
+		}); err != nil {
+			setupLog.Error(err, "unable to set up wake server")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -0,0 +1,88 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command steamguard computes Steam Guard codes for authenticated SteamCMD logins.
+// It reads the shared secret of a Steam Guard mobile authenticator from STEAM_GUARD_SHARED_SECRET.
+//
+// Without arguments it prints the current code. Given a command, it runs the command with
+// "+set_steam_guard_code <code>" inserted before its arguments, so SteamCMD can log in without
+// a shell between the secret and its command line:
+//
+//	steamguard steamcmd +login "$(STEAM_USERNAME)" "$(STEAM_PASSWORD)" +app_update 896660 +quit
+//
+// It is shipped in the operator image and copied into game server pods with -install.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/CraightonH/boilerr/internal/selfinstall"
+	"github.com/CraightonH/boilerr/internal/steamcmd"
+)
+
+func main() {
+	var installPath string
+	flag.StringVar(&installPath, "install", "", "Copy this binary to the given path and exit.")
+	flag.Parse()
+
+	if installPath != "" {
+		if err := selfinstall.Install(installPath); err != nil {
+			fmt.Fprintf(os.Stderr, "install failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	code, err := steamcmd.GuardCode(os.Getenv(steamcmd.GuardSharedSecretEnvVar), time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", steamcmd.GuardSharedSecretEnvVar, err)
+		os.Exit(1)
+	}
+
+	if flag.NArg() == 0 {
+		fmt.Println(code)
+		return
+	}
+
+	if err := run(flag.Args(), code); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to run %s: %v\n", flag.Arg(0), err)
+		os.Exit(1)
+	}
+}
+
+// run replaces the process with the command, passing the Steam Guard code before its arguments.
+// The shared secret is removed from the environment of the command.
+func run(args []string, code string) error {
+	path, err := exec.LookPath(args[0])
+	if err != nil {
+		return err
+	}
+	argv := append([]string{args[0], steamcmd.GuardCodeCommand, code}, args[1:]...)
+
+	var env []string
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, steamcmd.GuardSharedSecretEnvVar+"=") {
+			env = append(env, kv)
+		}
+	}
+	return syscall.Exec(path, argv, env)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command wakeproxy stands in for a stopped game server.
+// It listens on the server's ports, answers A2S_INFO with a placeholder, and asks the
+// operator to start the server when a player connects.
+//
+// It is shipped in the operator image and deployed per SteamServer while the server is suspended.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/CraightonH/boilerr/internal/a2s"
+	"github.com/CraightonH/boilerr/internal/wakeproxy"
+)
+
+func main() {
+	var portList string
+	var name string
+	var maxPlayers int
+	var wakeURL string
+	flag.StringVar(&portList, "ports", "", "Comma-separated ports to listen on, such as 2456/UDP,27015/TCP.")
+	flag.StringVar(&name, "name", "Game server", "The server name advertised over A2S while stopped.")
+	flag.IntVar(&maxPlayers, "max-players", 0, "The max players advertised over A2S while stopped.")
+	flag.StringVar(&wakeURL, "wake-url", "", "The URL that receives a POST when a player connects.")
+	flag.Parse()
+
+	ports, err := wakeproxy.ParsePorts(portList)
+	if err != nil || len(ports) == 0 {
+		fmt.Fprintf(os.Stderr, "invalid -ports %q: %v\n", portList, err)
+		os.Exit(1)
+	}
+	if wakeURL == "" {
+		fmt.Fprintln(os.Stderr, "-wake-url is required")
+		os.Exit(1)
+	}
+
+	proxy := &wakeproxy.Proxy{
+		Info: a2s.Info{
+			Protocol:    17,
+			Name:        name,
+			Map:         "stopped",
+			MaxPlayers:  uint8(min(maxPlayers, 255)),
+			ServerType:  'd',
+			Environment: 'l',
+			Keywords:    "stopped",
+		},
+		WakeURL: wakeURL,
+		// Set from the server's wake Secret by the operator
+		Token: os.Getenv("WAKE_TOKEN"),
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, len(ports))
+	for _, port := range ports {
+		addr := fmt.Sprintf(":%d", port.Port)
+		switch port.Protocol {
+		case "TCP":
+			ln, err := net.Listen("tcp", addr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "listen %s/TCP: %v\n", addr, err)
+				os.Exit(1)
+			}
+			go func() { errs <- proxy.ServeTCP(ctx, ln) }()
+		default:
+			conn, err := net.ListenPacket("udp", addr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "listen %s/UDP: %v\n", addr, err)
+				os.Exit(1)
+			}
+			go func() { errs <- proxy.ServeUDP(ctx, conn) }()
+		}
+		fmt.Printf("listening on %d/%s\n", port.Port, port.Protocol)
+	}
+
+	select {
+	case <-ctx.Done():
+	case err := <-errs:
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "serve: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
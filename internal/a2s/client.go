@@ -0,0 +1,155 @@
+// Package a2s implements the Steam A2S server query protocol used by Source-engine style game servers.
+//
+// See https://developer.valvesoftware.com/wiki/Server_queries for the wire format.
+// Only single-packet responses are supported.
+package a2s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// DefaultTimeout is the default time to wait for a query response.
+const DefaultTimeout = 3 * time.Second
+
+// maxPacketSize is the maximum size of a single A2S response packet.
+const maxPacketSize = 1400
+
+const (
+	headerSimple = 0xFFFFFFFF
+	headerSplit  = 0xFFFFFFFE
+
+	requestInfo       = 0x54
+	requestPlayer     = 0x55
+	responseInfo      = 0x49
+	responsePlayer    = 0x44
+	responseChallenge = 0x41
+)
+
+// ErrSplitPacket is returned when the server replies with a multi-packet response.
+var ErrSplitPacket = errors.New("a2s: split packet responses are not supported")
+
+// Client queries game servers over the A2S protocol.
+type Client struct {
+	// Timeout bounds each query, including any challenge round trip.
+	// Defaults to DefaultTimeout if zero.
+	Timeout time.Duration
+}
+
+// NewClient creates a new Client with the given timeout.
+func NewClient(timeout time.Duration) *Client {
+	return &Client{Timeout: timeout}
+}
+
+// QueryInfo sends an A2S_INFO request to addr (host:port) and returns the parsed reply.
+func (c *Client) QueryInfo(ctx context.Context, addr string) (*Info, error) {
+	payload := append([]byte{0xFF, 0xFF, 0xFF, 0xFF, requestInfo}, []byte("Source Engine Query\x00")...)
+
+	resp, err := c.query(ctx, addr, payload, responseInfo)
+	if err != nil {
+		return nil, err
+	}
+	return parseInfo(resp)
+}
+
+// QueryPlayers sends an A2S_PLAYER request to addr (host:port) and returns the connected players.
+func (c *Client) QueryPlayers(ctx context.Context, addr string) ([]Player, error) {
+	// The server always challenges A2S_PLAYER; 0xFFFFFFFF asks for a challenge number.
+	payload := []byte{0xFF, 0xFF, 0xFF, 0xFF, requestPlayer, 0xFF, 0xFF, 0xFF, 0xFF}
+
+	resp, err := c.query(ctx, addr, payload, responsePlayer)
+	if err != nil {
+		return nil, err
+	}
+	return parsePlayers(resp)
+}
+
+// query sends a request and returns the response body after the expected header byte.
+// If the server answers with a challenge, the request is resent with the challenge appended.
+func (c *Client) query(ctx context.Context, addr string, payload []byte, expected byte) ([]byte, error) {
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("a2s: dial %s: %w", addr, err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	buf := make([]byte, maxPacketSize)
+	// A server may issue a challenge once; a second challenge means it ignored ours.
+	for attempt := 0; attempt < 2; attempt++ {
+		if _, err := conn.Write(payload); err != nil {
+			return nil, fmt.Errorf("a2s: write: %w", err)
+		}
+
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, fmt.Errorf("a2s: read: %w", err)
+		}
+
+		r := newReader(buf[:n])
+		header, err := r.uint32()
+		if err != nil {
+			return nil, err
+		}
+		switch header {
+		case headerSimple:
+		case headerSplit:
+			return nil, ErrSplitPacket
+		default:
+			return nil, fmt.Errorf("a2s: unexpected packet header 0x%08X", header)
+		}
+
+		kind, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		switch kind {
+		case expected:
+			return r.rest(), nil
+		case responseChallenge:
+			challenge, err := r.bytes(4)
+			if err != nil {
+				return nil, err
+			}
+			payload = withChallenge(payload, challenge)
+		default:
+			return nil, fmt.Errorf("a2s: unexpected response type 0x%02X", kind)
+		}
+	}
+
+	return nil, errors.New("a2s: server kept issuing challenges")
+}
+
+// withChallenge returns the request payload with the challenge number applied.
+// A2S_INFO appends the challenge; other requests carry it in their last 4 bytes.
+func withChallenge(payload, challenge []byte) []byte {
+	if payload[4] == requestInfo {
+		// Strip a previously appended challenge before appending the new one.
+		base := payload
+		if len(base) > infoRequestLen {
+			base = base[:infoRequestLen]
+		}
+		return append(append([]byte{}, base...), challenge...)
+	}
+	out := append([]byte{}, payload[:len(payload)-4]...)
+	return append(out, challenge...)
+}
+
+// infoRequestLen is the length of an A2S_INFO request without a challenge.
+const infoRequestLen = 5 + len("Source Engine Query\x00")
@@ -0,0 +1,222 @@
+package a2s
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+)
+
+// fakeServer is a local UDP responder that answers A2S queries.
+type fakeServer struct {
+	conn      *net.UDPConn
+	challenge []byte
+	respond   func(req []byte) []byte
+}
+
+// startFakeServer starts a responder. If challenge is set, requests without it get an S2C_CHALLENGE reply.
+func startFakeServer(t *testing.T, challenge []byte, respond func(req []byte) []byte) *fakeServer {
+	t.Helper()
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &fakeServer{conn: conn, challenge: challenge, respond: respond}
+	t.Cleanup(func() { _ = conn.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *fakeServer) addr() string {
+	return s.conn.LocalAddr().String()
+}
+
+func (s *fakeServer) serve() {
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, from, err := s.conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+		req := append([]byte{}, buf[:n]...)
+		if s.challenge != nil && !bytes.HasSuffix(req, s.challenge) {
+			reply := append([]byte{0xFF, 0xFF, 0xFF, 0xFF, responseChallenge}, s.challenge...)
+			_, _ = s.conn.WriteToUDP(reply, from)
+			continue
+		}
+		if reply := s.respond(req); reply != nil {
+			_, _ = s.conn.WriteToUDP(reply, from)
+		}
+	}
+}
+
+// infoPacket encodes an A2S_INFO reply.
+func infoPacket(name, mapName string, players, maxPlayers uint8, vac bool, version string, edf byte, extra []byte) []byte {
+	var b bytes.Buffer
+	b.Write([]byte{0xFF, 0xFF, 0xFF, 0xFF, responseInfo, 17})
+	for _, s := range []string{name, mapName, "valheim", "Valheim"} {
+		b.WriteString(s)
+		b.WriteByte(0)
+	}
+	_ = binary.Write(&b, binary.LittleEndian, uint16(0))
+	vacByte := byte(0)
+	if vac {
+		vacByte = 1
+	}
+	b.Write([]byte{players, maxPlayers, 0, 'd', 'l', 0, vacByte})
+	b.WriteString(version)
+	b.WriteByte(0)
+	if edf != 0 {
+		b.WriteByte(edf)
+		b.Write(extra)
+	}
+	return b.Bytes()
+}
+
+func TestClient_QueryInfo(t *testing.T) {
+	port := make([]byte, 2)
+	binary.LittleEndian.PutUint16(port, 2456)
+	extra := append(port, []byte("g=valheim\x00")...)
+
+	tests := []struct {
+		name      string
+		challenge []byte
+		reply     []byte
+		check     func(t *testing.T, info *Info)
+	}{
+		{
+			name:  "basic info reply",
+			reply: infoPacket("Vikings", "Midgard", 3, 10, true, "0.218.21", 0, nil),
+			check: func(t *testing.T, info *Info) {
+				if info.Name != "Vikings" {
+					t.Errorf("Name = %q, want %q", info.Name, "Vikings")
+				}
+				if info.Map != "Midgard" {
+					t.Errorf("Map = %q, want %q", info.Map, "Midgard")
+				}
+				if info.Players != 3 || info.MaxPlayers != 10 {
+					t.Errorf("Players = %d/%d, want 3/10", info.Players, info.MaxPlayers)
+				}
+				if !info.VAC {
+					t.Error("expected VAC to be true")
+				}
+				if info.Version != "0.218.21" {
+					t.Errorf("Version = %q, want %q", info.Version, "0.218.21")
+				}
+			},
+		},
+		{
+			name:      "challenge is echoed back",
+			challenge: []byte{0x11, 0x22, 0x33, 0x44},
+			reply:     infoPacket("Challenged", "map", 0, 8, false, "1.0", 0, nil),
+			check: func(t *testing.T, info *Info) {
+				if info.Name != "Challenged" {
+					t.Errorf("Name = %q, want %q", info.Name, "Challenged")
+				}
+			},
+		},
+		{
+			name:  "extra data fields parsed",
+			reply: infoPacket("Extra", "map", 1, 2, false, "1.0", edfPort|edfKeywords, extra),
+			check: func(t *testing.T, info *Info) {
+				if info.Port != 2456 {
+					t.Errorf("Port = %d, want 2456", info.Port)
+				}
+				if info.Keywords != "g=valheim" {
+					t.Errorf("Keywords = %q, want %q", info.Keywords, "g=valheim")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := startFakeServer(t, tt.challenge, func(req []byte) []byte {
+				if req[4] != requestInfo {
+					return nil
+				}
+				return tt.reply
+			})
+
+			info, err := NewClient(time.Second).QueryInfo(context.Background(), server.addr())
+			if err != nil {
+				t.Fatalf("QueryInfo() error = %v", err)
+			}
+			tt.check(t, info)
+		})
+	}
+}
+
+func TestClient_QueryInfoErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		reply []byte
+	}{
+		{name: "no reply times out", reply: nil},
+		{name: "split packet rejected", reply: []byte{0xFE, 0xFF, 0xFF, 0xFF, 0x01}},
+		{name: "unexpected response type", reply: []byte{0xFF, 0xFF, 0xFF, 0xFF, 0x6A}},
+		{name: "truncated info", reply: []byte{0xFF, 0xFF, 0xFF, 0xFF, responseInfo, 17, 'V'}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := startFakeServer(t, nil, func([]byte) []byte { return tt.reply })
+
+			if _, err := NewClient(200*time.Millisecond).QueryInfo(context.Background(), server.addr()); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+// playerPacket encodes an A2S_PLAYER reply.
+func playerPacket(players ...Player) []byte {
+	var b bytes.Buffer
+	b.Write([]byte{0xFF, 0xFF, 0xFF, 0xFF, responsePlayer, byte(len(players))})
+	for _, p := range players {
+		b.WriteByte(p.Index)
+		b.WriteString(p.Name)
+		b.WriteByte(0)
+		_ = binary.Write(&b, binary.LittleEndian, p.Score)
+		_ = binary.Write(&b, binary.LittleEndian, p.Duration)
+	}
+	return b.Bytes()
+}
+
+func TestClient_QueryPlayers(t *testing.T) {
+	want := []Player{
+		{Index: 0, Name: "Ragnar", Score: 12, Duration: 360.5},
+		{Index: 1, Name: "Lagertha", Score: -1, Duration: 42},
+	}
+	server := startFakeServer(t, []byte{0x0A, 0x0B, 0x0C, 0x0D}, func(req []byte) []byte {
+		if req[4] != requestPlayer {
+			return nil
+		}
+		return playerPacket(want...)
+	})
+
+	players, err := NewClient(time.Second).QueryPlayers(context.Background(), server.addr())
+	if err != nil {
+		t.Fatalf("QueryPlayers() error = %v", err)
+	}
+	if len(players) != len(want) {
+		t.Fatalf("got %d players, want %d", len(players), len(want))
+	}
+	for i := range want {
+		if players[i] != want[i] {
+			t.Errorf("player %d = %+v, want %+v", i, players[i], want[i])
+		}
+	}
+}
+
+func TestClient_QueryPlayersTruncated(t *testing.T) {
+	server := startFakeServer(t, nil, func([]byte) []byte {
+		return []byte{0xFF, 0xFF, 0xFF, 0xFF, responsePlayer, 2, 0, 'A', 0}
+	})
+
+	if _, err := NewClient(200*time.Millisecond).QueryPlayers(context.Background(), server.addr()); err == nil {
+		t.Error("expected error")
+	}
+}
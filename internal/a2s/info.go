@@ -0,0 +1,187 @@
+package a2s
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Extra data flags in an A2S_INFO response.
+const (
+	edfPort     = 0x80
+	edfSteamID  = 0x10
+	edfSourceTV = 0x40
+	edfKeywords = 0x20
+	edfGameID   = 0x01
+)
+
+// theShipAppID is the only game whose A2S_INFO reply carries extra fields before the version.
+const theShipAppID = 2400
+
+// errShortPacket is returned when a response ends before all expected fields were read.
+var errShortPacket = errors.New("a2s: packet too short")
+
+// Info is the parsed reply to an A2S_INFO query.
+type Info struct {
+	Protocol    byte
+	Name        string
+	Map         string
+	Folder      string
+	Game        string
+	AppID       uint16
+	Players     uint8
+	MaxPlayers  uint8
+	Bots        uint8
+	ServerType  byte
+	Environment byte
+	Visibility  byte
+	VAC         bool
+	Version     string
+	Port        uint16
+	SteamID     uint64
+	Keywords    string
+	GameID      uint64
+}
+
+// parseInfo parses an A2S_INFO response body (after the 'I' header byte).
+func parseInfo(data []byte) (*Info, error) {
+	r := newReader(data)
+	info := &Info{}
+	var err error
+
+	if info.Protocol, err = r.byte(); err != nil {
+		return nil, err
+	}
+	for _, dst := range []*string{&info.Name, &info.Map, &info.Folder, &info.Game} {
+		if *dst, err = r.string(); err != nil {
+			return nil, err
+		}
+	}
+	if info.AppID, err = r.uint16(); err != nil {
+		return nil, err
+	}
+	for _, dst := range []*byte{&info.Players, &info.MaxPlayers, &info.Bots, &info.ServerType, &info.Environment, &info.Visibility} {
+		if *dst, err = r.byte(); err != nil {
+			return nil, err
+		}
+	}
+	vac, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+	info.VAC = vac == 1
+
+	if info.AppID == theShipAppID {
+		// mode, witnesses, duration
+		if _, err := r.bytes(3); err != nil {
+			return nil, err
+		}
+	}
+
+	if info.Version, err = r.string(); err != nil {
+		return nil, err
+	}
+
+	// Extra data flag is optional
+	edf, err := r.byte()
+	if err != nil {
+		return info, nil
+	}
+	if edf&edfPort != 0 {
+		if info.Port, err = r.uint16(); err != nil {
+			return nil, err
+		}
+	}
+	if edf&edfSteamID != 0 {
+		if info.SteamID, err = r.uint64(); err != nil {
+			return nil, err
+		}
+	}
+	if edf&edfSourceTV != 0 {
+		if _, err := r.uint16(); err != nil {
+			return nil, err
+		}
+		if _, err := r.string(); err != nil {
+			return nil, err
+		}
+	}
+	if edf&edfKeywords != 0 {
+		if info.Keywords, err = r.string(); err != nil {
+			return nil, err
+		}
+	}
+	if edf&edfGameID != 0 {
+		if info.GameID, err = r.uint64(); err != nil {
+			return nil, err
+		}
+	}
+
+	return info, nil
+}
+
+// reader reads little-endian A2S fields from a packet.
+type reader struct {
+	data []byte
+	pos  int
+}
+
+func newReader(data []byte) *reader {
+	return &reader{data: data}
+}
+
+func (r *reader) bytes(n int) ([]byte, error) {
+	if r.pos+n > len(r.data) {
+		return nil, errShortPacket
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b, nil
+}
+
+func (r *reader) byte() (byte, error) {
+	b, err := r.bytes(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (r *reader) uint16() (uint16, error) {
+	b, err := r.bytes(2)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint16(b), nil
+}
+
+func (r *reader) uint32() (uint32, error) {
+	b, err := r.bytes(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+func (r *reader) uint64() (uint64, error) {
+	b, err := r.bytes(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+// string reads a null-terminated string.
+func (r *reader) string() (string, error) {
+	for i := r.pos; i < len(r.data); i++ {
+		if r.data[i] == 0 {
+			s := string(r.data[r.pos:i])
+			r.pos = i + 1
+			return s, nil
+		}
+	}
+	return "", errShortPacket
+}
+
+// rest returns the unread bytes.
+func (r *reader) rest() []byte {
+	return r.data[r.pos:]
+}
@@ -0,0 +1,48 @@
+package a2s
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Player is a single entry in the reply to an A2S_PLAYER query.
+type Player struct {
+	Index    byte
+	Name     string
+	Score    int32
+	Duration float32
+}
+
+// parsePlayers parses an A2S_PLAYER response body (after the 'D' header byte).
+func parsePlayers(data []byte) ([]Player, error) {
+	r := newReader(data)
+
+	count, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+
+	players := make([]Player, 0, count)
+	for i := 0; i < int(count); i++ {
+		var p Player
+		if p.Index, err = r.byte(); err != nil {
+			return nil, err
+		}
+		if p.Name, err = r.string(); err != nil {
+			return nil, err
+		}
+		score, err := r.uint32()
+		if err != nil {
+			return nil, err
+		}
+		p.Score = int32(score)
+		duration, err := r.bytes(4)
+		if err != nil {
+			return nil, err
+		}
+		p.Duration = math.Float32frombits(binary.LittleEndian.Uint32(duration))
+		players = append(players, p)
+	}
+
+	return players, nil
+}
@@ -0,0 +1,76 @@
+package a2s
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Request types that only query server information and never come from a joining player.
+const (
+	requestRules     = 0x56
+	requestChallenge = 0x57
+	requestPing      = 0x69
+)
+
+// IsQuery reports whether a packet is an A2S query rather than game traffic.
+func IsQuery(packet []byte) bool {
+	r := newReader(packet)
+	if header, err := r.uint32(); err != nil || header != headerSimple {
+		return false
+	}
+	kind, err := r.byte()
+	if err != nil {
+		return false
+	}
+	switch kind {
+	case requestInfo, requestPlayer, requestRules, requestChallenge, requestPing:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsInfoRequest reports whether a packet is an A2S_INFO query.
+func IsInfoRequest(packet []byte) bool {
+	return IsQuery(packet) && packet[4] == requestInfo
+}
+
+// MarshalInfo encodes an A2S_INFO response packet.
+// The port and keywords extra data fields are included when set.
+func MarshalInfo(info *Info) []byte {
+	var b bytes.Buffer
+	b.Write([]byte{0xFF, 0xFF, 0xFF, 0xFF, responseInfo, info.Protocol})
+	for _, s := range []string{info.Name, info.Map, info.Folder, info.Game} {
+		b.WriteString(s)
+		b.WriteByte(0)
+	}
+	_ = binary.Write(&b, binary.LittleEndian, info.AppID)
+
+	vac := byte(0)
+	if info.VAC {
+		vac = 1
+	}
+	b.Write([]byte{info.Players, info.MaxPlayers, info.Bots, info.ServerType, info.Environment, info.Visibility, vac})
+	b.WriteString(info.Version)
+	b.WriteByte(0)
+
+	var edf byte
+	if info.Port != 0 {
+		edf |= edfPort
+	}
+	if info.Keywords != "" {
+		edf |= edfKeywords
+	}
+	if edf == 0 {
+		return b.Bytes()
+	}
+	b.WriteByte(edf)
+	if info.Port != 0 {
+		_ = binary.Write(&b, binary.LittleEndian, info.Port)
+	}
+	if info.Keywords != "" {
+		b.WriteString(info.Keywords)
+		b.WriteByte(0)
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,67 @@
+package a2s
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestMarshalInfo(t *testing.T) {
+	tests := []struct {
+		name string
+		info Info
+	}{
+		{
+			name: "basic",
+			info: Info{Protocol: 17, Name: "My Server (starting)", Map: "Dedicated", Game: "Valheim", MaxPlayers: 10, ServerType: 'd', Environment: 'l', Version: "0.217.22"},
+		},
+		{
+			name: "extra data",
+			info: Info{Protocol: 17, Name: "My Server", Players: 2, MaxPlayers: 10, VAC: true, Port: 2456, Keywords: "starting"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packet := MarshalInfo(&tt.info)
+			if !bytes.HasPrefix(packet, []byte{0xFF, 0xFF, 0xFF, 0xFF, responseInfo}) {
+				t.Fatalf("unexpected header % X", packet[:5])
+			}
+
+			got, err := parseInfo(packet[5:])
+			if err != nil {
+				t.Fatalf("parseInfo() error = %v", err)
+			}
+			if !reflect.DeepEqual(*got, tt.info) {
+				t.Errorf("round trip = %+v, want %+v", *got, tt.info)
+			}
+		})
+	}
+}
+
+func TestIsQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		packet []byte
+		query  bool
+		info   bool
+	}{
+		{"info", append([]byte{0xFF, 0xFF, 0xFF, 0xFF, requestInfo}, []byte("Source Engine Query\x00")...), true, true},
+		{"player", []byte{0xFF, 0xFF, 0xFF, 0xFF, requestPlayer, 0xFF, 0xFF, 0xFF, 0xFF}, true, false},
+		{"rules", []byte{0xFF, 0xFF, 0xFF, 0xFF, requestRules, 0xFF, 0xFF, 0xFF, 0xFF}, true, false},
+		{"game traffic", []byte{0x01, 0x02, 0x03, 0x04, 0x05}, false, false},
+		{"connectionless game packet", []byte{0xFF, 0xFF, 0xFF, 0xFF, 'q'}, false, false},
+		{"short", []byte{0xFF, 0xFF}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsQuery(tt.packet); got != tt.query {
+				t.Errorf("IsQuery() = %v, want %v", got, tt.query)
+			}
+			if got := IsInfoRequest(tt.packet); got != tt.info {
+				t.Errorf("IsInfoRequest() = %v, want %v", got, tt.info)
+			}
+		})
+	}
+}
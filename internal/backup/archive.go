@@ -0,0 +1,97 @@
+// Package backup archives game server save data, stores the archives in a directory or an
+// S3-compatible bucket, and restores them. It runs in backup and restore Jobs from the operator image.
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WriteArchive writes a gzipped tar of paths to w. Paths are relative to root and are stored
+// relative to root in the archive. Paths may not leave root.
+func WriteArchive(w io.Writer, root string, paths []string) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	for _, p := range paths {
+		rel, err := cleanRelPath(p)
+		if err != nil {
+			return err
+		}
+		if err := addTree(tw, root, rel); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// addTree adds rel and everything under it to the archive.
+func addTree(tw *tar.Writer, root, rel string) error {
+	return filepath.WalkDir(filepath.Join(root, rel), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		var link string
+		switch {
+		case info.Mode().IsRegular(), info.IsDir():
+		case info.Mode()&fs.ModeSymlink != 0:
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		default:
+			// Sockets, devices and pipes are not save data
+			return nil
+		}
+
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(name)
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+}
+
+// cleanRelPath cleans a path relative to the archive root, rejecting paths that leave it.
+func cleanRelPath(p string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(strings.TrimPrefix(p, "/")))
+	if clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %q leaves the archive root", p)
+	}
+	return clean, nil
+}
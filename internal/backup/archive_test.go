@@ -0,0 +1,108 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// writeFiles creates files under root from a map of relative path to content.
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// readArchive returns the regular files in a gzipped tar by name.
+func readArchive(t *testing.T, r io.Reader) map[string]string {
+	t.Helper()
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	files := map[string]string{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[hdr.Name] = string(data)
+	}
+}
+
+func TestWriteArchive(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"worlds/dedicated.db":  "world",
+		"worlds/dedicated.fwl": "meta",
+		"adminlist.txt":        "admins",
+		"valheim_server.x86":   "binary",
+	})
+
+	var buf bytes.Buffer
+	if err := WriteArchive(&buf, root, []string{"worlds", "/adminlist.txt"}); err != nil {
+		t.Fatalf("WriteArchive() error = %v", err)
+	}
+
+	files := readArchive(t, &buf)
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	want := []string{"adminlist.txt", "worlds/dedicated.db", "worlds/dedicated.fwl"}
+	if len(names) != len(want) {
+		t.Fatalf("archive files = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("archive files = %v, want %v", names, want)
+		}
+	}
+	if files["worlds/dedicated.db"] != "world" {
+		t.Errorf("dedicated.db content = %q, want %q", files["worlds/dedicated.db"], "world")
+	}
+}
+
+func TestWriteArchive_Errors(t *testing.T) {
+	root := t.TempDir()
+
+	tests := []struct {
+		name  string
+		paths []string
+	}{
+		{name: "leaves root", paths: []string{"../etc"}},
+		{name: "missing path", paths: []string{"worlds"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := WriteArchive(io.Discard, root, tt.paths); err == nil {
+				t.Error("WriteArchive() expected error")
+			}
+		})
+	}
+}
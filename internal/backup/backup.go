@@ -0,0 +1,127 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// ArchiveSuffix is the file extension of backup archives.
+const ArchiveSuffix = ".tar.gz"
+
+// timestampFormat is used in archive names so that names sort by time.
+const timestampFormat = "20060102T150405Z"
+
+// Result describes a completed backup. Backup Jobs write it as JSON to their termination message,
+// where the controller reads it into status.backups.
+type Result struct {
+	// Name of the archive.
+	Name string `json:"name"`
+	// Location is the file path or s3:// URL of the archive.
+	Location string `json:"location"`
+	// Size of the archive in bytes.
+	Size int64 `json:"size"`
+}
+
+// Options configures Create.
+type Options struct {
+	// Root is the directory the paths are relative to, usually the mounted server volume.
+	Root string
+	// Paths to archive, relative to Root.
+	Paths []string
+	// Name of the server, used as the archive name prefix.
+	Name string
+	// Retention is the number of archives of the server to keep. Older archives are deleted. 0 keeps all.
+	Retention int
+	// TempDir holds the archive while it is written. Defaults to the system temp directory.
+	TempDir string
+}
+
+// ArchiveName returns the archive name of a server backup taken at t.
+func ArchiveName(server string, t time.Time) string {
+	return server + "-" + t.UTC().Format(timestampFormat) + ArchiveSuffix
+}
+
+// Create archives the configured paths into store and prunes archives beyond the retention count.
+func Create(ctx context.Context, store Store, opts Options, now time.Time) (*Result, error) {
+	if len(opts.Paths) == 0 {
+		return nil, fmt.Errorf("no paths to back up")
+	}
+
+	// Write to a temporary file first: object stores need the size up front
+	tmp, err := os.CreateTemp(opts.TempDir, "backup-*"+ArchiveSuffix)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if err := WriteArchive(tmp, opts.Root, opts.Paths); err != nil {
+		return nil, fmt.Errorf("writing archive: %w", err)
+	}
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	name := ArchiveName(opts.Name, now)
+	location, err := store.Put(ctx, name, tmp, size)
+	if err != nil {
+		return nil, fmt.Errorf("storing archive: %w", err)
+	}
+
+	if err := prune(ctx, store, opts.Name, opts.Retention); err != nil {
+		return nil, fmt.Errorf("pruning old archives: %w", err)
+	}
+
+	return &Result{Name: name, Location: location, Size: size}, nil
+}
+
+// prune deletes the oldest archives of a server so that at most retention remain.
+func prune(ctx context.Context, store Store, server string, retention int) error {
+	if retention <= 0 {
+		return nil
+	}
+	names, err := store.List(ctx, server+"-")
+	if err != nil {
+		return err
+	}
+
+	var archives []string
+	for _, name := range names {
+		if isArchiveOf(server, name) {
+			archives = append(archives, name)
+		}
+	}
+	// Names sort by timestamp, oldest first
+	for len(archives) > retention {
+		if err := store.Delete(ctx, archives[0]); err != nil {
+			return err
+		}
+		archives = archives[1:]
+	}
+	return nil
+}
+
+// isArchiveOf reports whether name is an archive of server, and not of a server whose name
+// starts with the same prefix (e.g. "valheim" and "valheim-test").
+func isArchiveOf(server, name string) bool {
+	stamp, ok := strings.CutPrefix(name, server+"-")
+	if !ok {
+		return false
+	}
+	stamp, ok = strings.CutSuffix(stamp, ArchiveSuffix)
+	if !ok {
+		return false
+	}
+	_, err := time.Parse(timestampFormat, stamp)
+	return err == nil
+}
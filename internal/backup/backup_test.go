@@ -0,0 +1,105 @@
+package backup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestArchiveName(t *testing.T) {
+	got := ArchiveName("valheim", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	if want := "valheim-20260102T030405Z.tar.gz"; got != want {
+		t.Errorf("ArchiveName() = %q, want %q", got, want)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"worlds/dedicated.db": "world"})
+	store := &DirStore{Dir: filepath.Join(t.TempDir(), "valheim")}
+	now := time.Date(2026, 1, 1, 4, 0, 0, 0, time.UTC)
+
+	result, err := Create(context.Background(), store, Options{
+		Root:  root,
+		Paths: []string{"worlds"},
+		Name:  "valheim",
+	}, now)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if result.Name != "valheim-20260101T040000Z.tar.gz" {
+		t.Errorf("Name = %q", result.Name)
+	}
+	if result.Location != filepath.Join(store.Dir, result.Name) {
+		t.Errorf("Location = %q", result.Location)
+	}
+	info, err := os.Stat(result.Location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != result.Size || result.Size == 0 {
+		t.Errorf("Size = %d, file size %d", result.Size, info.Size())
+	}
+
+	f, err := os.Open(result.Location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	if files := readArchive(t, f); files["worlds/dedicated.db"] != "world" {
+		t.Errorf("archive files = %v", files)
+	}
+}
+
+func TestCreate_Retention(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"worlds/dedicated.db": "world"})
+	dir := t.TempDir()
+	store := &DirStore{Dir: dir}
+	// Archives of another server and unrelated files are never pruned
+	writeFiles(t, dir, map[string]string{
+		"valheim-test-20250101T000000Z.tar.gz": "",
+		"notes.txt":                            "",
+	})
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		_, err := Create(context.Background(), store, Options{
+			Root:      root,
+			Paths:     []string{"worlds"},
+			Name:      "valheim",
+			Retention: 2,
+		}, start.Add(time.Duration(i)*time.Hour))
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	names, err := store.List(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"notes.txt",
+		"valheim-20260101T020000Z.tar.gz",
+		"valheim-20260101T030000Z.tar.gz",
+		"valheim-test-20250101T000000Z.tar.gz",
+	}
+	if len(names) != len(want) {
+		t.Fatalf("files = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("files = %v, want %v", names, want)
+		}
+	}
+}
+
+func TestCreate_NoPaths(t *testing.T) {
+	if _, err := Create(context.Background(), &DirStore{Dir: t.TempDir()}, Options{Name: "valheim"}, time.Now()); err == nil {
+		t.Error("Create() expected error without paths")
+	}
+}
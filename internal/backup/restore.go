@@ -0,0 +1,132 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// StagingDirName is the directory under the restore root that archives are extracted into
+// before they replace the live files.
+const StagingDirName = ".boilerr-restore"
+
+// RestoreResult describes a completed restore. Restore Jobs write it as JSON to their
+// termination message, where the controller reads it into the SteamServerRestore status.
+type RestoreResult struct {
+	// Files is the number of files restored.
+	Files int `json:"files"`
+	// Size is the number of bytes restored.
+	Size int64 `json:"size"`
+}
+
+// Restore extracts a gzipped tar archive into root. Each top-level file or directory in the
+// archive replaces the one in root; everything else in root is left alone. The archive is
+// extracted into a staging directory first, so a corrupt archive leaves root untouched.
+func Restore(r io.Reader, root string) (*RestoreResult, error) {
+	staging := filepath.Join(root, StagingDirName)
+	if err := os.RemoveAll(staging); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(staging, 0o755); err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(staging) }()
+
+	result, err := ExtractArchive(r, staging)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(staging)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		target := filepath.Join(root, e.Name())
+		if err := os.RemoveAll(target); err != nil {
+			return nil, err
+		}
+		if err := os.Rename(filepath.Join(staging, e.Name()), target); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// ExtractArchive extracts a gzipped tar archive into dir.
+// Entries that would be written outside dir are rejected.
+func ExtractArchive(r io.Reader, dir string) (*RestoreResult, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+
+	result := &RestoreResult{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return result, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading archive: %w", err)
+		}
+
+		rel, err := cleanRelPath(hdr.Name)
+		if err != nil || rel == "." {
+			return nil, fmt.Errorf("invalid archive entry %q", hdr.Name)
+		}
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, hdr.FileInfo().Mode().Perm()|0o700); err != nil {
+				return nil, err
+			}
+		case tar.TypeReg:
+			n, err := writeFile(path, tr, hdr.FileInfo().Mode().Perm())
+			if err != nil {
+				return nil, err
+			}
+			result.Files++
+			result.Size += n
+		case tar.TypeSymlink:
+			// Links may only point within the archive
+			if _, err := cleanRelPath(filepath.Join(filepath.Dir(rel), hdr.Linkname)); err != nil || filepath.IsAbs(hdr.Linkname) {
+				return nil, fmt.Errorf("invalid archive entry %q: link leaves the archive", hdr.Name)
+			}
+			if err := os.Symlink(hdr.Linkname, path); err != nil {
+				return nil, err
+			}
+		default:
+			// Other entry types are never written by WriteArchive
+			continue
+		}
+
+		// Keep files owned by the game server user, not by the restore Job
+		if os.Geteuid() == 0 {
+			if err := os.Lchown(path, hdr.Uid, hdr.Gid); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// writeFile writes r to a new file at path.
+func writeFile(path string, r io.Reader, perm os.FileMode) (int64, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return n, err
+}
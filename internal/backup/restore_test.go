@@ -0,0 +1,92 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRestore(t *testing.T) {
+	src := t.TempDir()
+	writeFiles(t, src, map[string]string{
+		"worlds/dedicated.db":  "good world",
+		"worlds/dedicated.fwl": "meta",
+	})
+	var archive bytes.Buffer
+	if err := WriteArchive(&archive, src, []string{"worlds"}); err != nil {
+		t.Fatal(err)
+	}
+
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"worlds/dedicated.db":     "corrupt world",
+		"worlds/dedicated.db.old": "stale",
+		"valheim_server.x86":      "binary",
+	})
+
+	result, err := Restore(&archive, root)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if result.Files != 2 || result.Size != int64(len("good world")+len("meta")) {
+		t.Errorf("result = %+v", result)
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, "worlds/dedicated.db"))
+	if err != nil || string(data) != "good world" {
+		t.Errorf("dedicated.db = %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "worlds/dedicated.db.old")); !os.IsNotExist(err) {
+		t.Error("expected restored directories to be replaced")
+	}
+	if _, err := os.Stat(filepath.Join(root, "valheim_server.x86")); err != nil {
+		t.Error("expected files outside the archive to be kept")
+	}
+	if _, err := os.Stat(filepath.Join(root, StagingDirName)); !os.IsNotExist(err) {
+		t.Error("expected the staging directory to be removed")
+	}
+}
+
+func TestRestore_CorruptArchive(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"worlds/dedicated.db": "world"})
+
+	if _, err := Restore(bytes.NewReader([]byte("not an archive")), root); err == nil {
+		t.Fatal("Restore() expected error")
+	}
+	data, err := os.ReadFile(filepath.Join(root, "worlds/dedicated.db"))
+	if err != nil || string(data) != "world" {
+		t.Errorf("expected files to be untouched, got %q, %v", data, err)
+	}
+}
+
+func TestExtractArchive_RejectsEscapes(t *testing.T) {
+	tests := []struct {
+		name string
+		hdr  tar.Header
+	}{
+		{name: "parent path", hdr: tar.Header{Name: "../evil", Typeflag: tar.TypeReg, Mode: 0o644}},
+		{name: "absolute link", hdr: tar.Header{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"}},
+		{name: "parent link", hdr: tar.Header{Name: "worlds/link", Typeflag: tar.TypeSymlink, Linkname: "../../etc"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			gz := gzip.NewWriter(&buf)
+			tw := tar.NewWriter(gz)
+			if err := tw.WriteHeader(&tt.hdr); err != nil {
+				t.Fatal(err)
+			}
+			_ = tw.Close()
+			_ = gz.Close()
+
+			if _, err := ExtractArchive(&buf, t.TempDir()); err == nil {
+				t.Error("ExtractArchive() expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Store holds backup archives by name.
+type Store interface {
+	// Put stores an archive of the given size and returns where it was stored.
+	Put(ctx context.Context, name string, r io.Reader, size int64) (string, error)
+	// List returns the names of the stored archives starting with prefix, sorted.
+	List(ctx context.Context, prefix string) ([]string, error)
+	// Delete removes an archive.
+	Delete(ctx context.Context, name string) error
+}
+
+// DirStore stores archives as files in a directory, such as a mounted backup PVC.
+type DirStore struct {
+	Dir string
+}
+
+// Put writes the archive to a temporary file and renames it into place,
+// so a failed backup never leaves a partial archive behind.
+func (s *DirStore) Put(_ context.Context, name string, r io.Reader, _ int64) (string, error) {
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(s.Dir, ".tmp-"+name)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		_ = tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(s.Dir, name)
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// List returns the archive files in the directory starting with prefix.
+func (s *DirStore) List(_ context.Context, prefix string) ([]string, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.Type().IsRegular() && strings.HasPrefix(e.Name(), prefix) {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Delete removes an archive file.
+func (s *DirStore) Delete(_ context.Context, name string) error {
+	if strings.ContainsRune(name, filepath.Separator) {
+		return fmt.Errorf("invalid archive name %q", name)
+	}
+	return os.Remove(filepath.Join(s.Dir, name))
+}
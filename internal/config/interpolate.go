@@ -4,6 +4,7 @@ package config
 import (
 	"bytes"
 	"fmt"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -39,10 +40,11 @@ func InterpolateArgs(args []string, config map[string]string) ([]string, error)
 }
 
 // InterpolateString replaces {{.Config.key}} in a string with actual values.
+// Keys without a value render as an empty string.
 func InterpolateString(s string, config map[string]string) (string, error) {
 	data := TemplateData{Config: config}
 
-	tmpl, err := template.New("str").Parse(s)
+	tmpl, err := template.New("str").Option("missingkey=zero").Parse(s)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse template: %w", err)
 	}
@@ -77,7 +79,7 @@ func ResolveConfigValues(
 	for key, cv := range config {
 		if cv.SecretKeyRef != nil {
 			// Create env var and reference it
-			envName := "CONFIG_" + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+			envName := SecretEnvName(key)
 			envVars = append(envVars, corev1.EnvVar{
 				Name: envName,
 				ValueFrom: &corev1.EnvVarSource{
@@ -94,6 +96,28 @@ func ResolveConfigValues(
 	return values, envVars
 }
 
+// SecretEnvName returns the name of the env var holding the secret value of a config key.
+func SecretEnvName(key string) string {
+	return "CONFIG_" + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+}
+
+// SecretReference returns the secret config key whose env var reference appears in s.
+// Kubernetes only expands $(VAR) references in env, command and args, so a secret value
+// rendered into a file would be written as the literal reference.
+func SecretReference(s string, config map[string]boilerrv1alpha1.ConfigValue) (string, bool) {
+	var keys []string
+	for key, cv := range config {
+		if cv.SecretKeyRef != nil && strings.Contains(s, "$("+SecretEnvName(key)+")") {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return "", false
+	}
+	sort.Strings(keys)
+	return keys[0], true
+}
+
 // ValidateConfig checks config against schema.
 func ValidateConfig(
 	config map[string]boilerrv1alpha1.ConfigValue,
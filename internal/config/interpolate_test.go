@@ -106,6 +106,12 @@ func TestInterpolateString(t *testing.T) {
 			config:   map[string]string{"greeting": "Hello", "name": "Player"},
 			expected: "Hello Player!",
 		},
+		{
+			name:     "missing key renders empty",
+			input:    "admins:{{.Config.admins}}",
+			config:   map[string]string{},
+			expected: "admins:",
+		},
 	}
 
 	for _, tt := range tests {
@@ -227,6 +233,36 @@ func TestResolveConfigValues(t *testing.T) {
 	}
 }
 
+func TestSecretReference(t *testing.T) {
+	config := map[string]boilerrv1alpha1.ConfigValue{
+		"serverName": {Value: "$(CONFIG_SERVERNAME)"},
+		"password": {SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "secrets"},
+			Key:                  "password",
+		}},
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		wantKey string
+		wantOK  bool
+	}{
+		{name: "secret value", input: "password=$(CONFIG_PASSWORD)", wantKey: "password", wantOK: true},
+		{name: "literal value", input: "name=$(CONFIG_SERVERNAME)"},
+		{name: "no reference", input: "name=Valheim"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, ok := SecretReference(tt.input, config)
+			if key != tt.wantKey || ok != tt.wantOK {
+				t.Errorf("SecretReference() = %q, %v, want %q, %v", key, ok, tt.wantKey, tt.wantOK)
+			}
+		})
+	}
+}
+
 func TestValidateConfig(t *testing.T) {
 	tests := []struct {
 		name    string
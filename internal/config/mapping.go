@@ -0,0 +1,136 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+)
+
+// ResolveEnvMappings returns env vars for config keys whose schema entry maps to "env".
+// Literal values (user-provided or schema defaults) are set directly; secret-backed values
+// reference the Secret via valueFrom.secretKeyRef. Keys with no value are skipped.
+// The result is sorted by config key for deterministic output.
+func ResolveEnvMappings(
+	config map[string]boilerrv1alpha1.ConfigValue,
+	schema map[string]boilerrv1alpha1.ConfigSchemaEntry,
+	values map[string]string,
+) []corev1.EnvVar {
+	var envVars []corev1.EnvVar
+
+	for _, key := range sortedMappedKeys(schema, boilerrv1alpha1.ConfigMappingEnv) {
+		name := schema[key].MapTo.Value
+		if name == "" {
+			continue
+		}
+
+		if cv, ok := config[key]; ok && cv.SecretKeyRef != nil {
+			envVars = append(envVars, corev1.EnvVar{
+				Name: name,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: cv.SecretKeyRef,
+				},
+			})
+			continue
+		}
+
+		value, ok := values[key]
+		if !ok {
+			continue
+		}
+		envVars = append(envVars, corev1.EnvVar{Name: name, Value: value})
+	}
+
+	return envVars
+}
+
+// ResolveArgMappings returns container args for config keys whose schema entry maps to "arg".
+// Keys are processed in sorted order so the result is deterministic. Supported forms:
+//   - Condition set: the flag alone is added when the config value equals Condition.
+//   - Condition empty: the flag is followed by the config value ("-flag value").
+//   - Array entries: the flag is emitted once per comma-separated element ("-flag a -flag b").
+//
+// Keys with an empty value are skipped.
+func ResolveArgMappings(
+	schema map[string]boilerrv1alpha1.ConfigSchemaEntry,
+	values map[string]string,
+) []string {
+	var args []string
+
+	for _, key := range sortedMappedKeys(schema, boilerrv1alpha1.ConfigMappingArg) {
+		entry := schema[key]
+		flag := entry.MapTo.Value
+		value := values[key]
+		if flag == "" || value == "" {
+			continue
+		}
+
+		switch {
+		case entry.Array:
+			for _, item := range SplitArrayValue(value) {
+				args = append(args, flag, item)
+			}
+		case entry.MapTo.Condition != "":
+			if value == entry.MapTo.Condition {
+				args = append(args, flag)
+			}
+		default:
+			args = append(args, flag, value)
+		}
+	}
+
+	return args
+}
+
+// SplitArrayValue splits a comma-separated array config value into trimmed, non-empty items.
+func SplitArrayValue(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ConfigFileMappings returns the config files declared by schema entries that map to "configFile".
+// Only keys with a value (user-provided or schema default) produce a file. The returned content is
+// the mapping template, still to be rendered with InterpolateString; without a template the file
+// contains the config value itself. The result is sorted by config key.
+func ConfigFileMappings(
+	schema map[string]boilerrv1alpha1.ConfigSchemaEntry,
+	values map[string]string,
+) []boilerrv1alpha1.ConfigFile {
+	var files []boilerrv1alpha1.ConfigFile
+
+	for _, key := range sortedMappedKeys(schema, boilerrv1alpha1.ConfigMappingConfigFile) {
+		mapping := schema[key].MapTo
+		if mapping.Path == "" || values[key] == "" {
+			continue
+		}
+
+		content := mapping.Template
+		if content == "" {
+			content = fmt.Sprintf("{{index .Config %q}}", key)
+		}
+		files = append(files, boilerrv1alpha1.ConfigFile{Path: mapping.Path, Content: content})
+	}
+
+	return files
+}
+
+// sortedMappedKeys returns the schema keys with the given mapping type, sorted by name.
+func sortedMappedKeys(schema map[string]boilerrv1alpha1.ConfigSchemaEntry, mappingType string) []string {
+	var keys []string
+	for key, entry := range schema {
+		if entry.MapTo != nil && entry.MapTo.Type == mappingType {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
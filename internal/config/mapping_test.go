@@ -0,0 +1,233 @@
+package config
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+)
+
+func TestResolveEnvMappings(t *testing.T) {
+	passwordRef := &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "game-secrets"},
+		Key:                  "password",
+	}
+
+	tests := []struct {
+		name     string
+		config   map[string]boilerrv1alpha1.ConfigValue
+		schema   map[string]boilerrv1alpha1.ConfigSchemaEntry
+		expected []corev1.EnvVar
+	}{
+		{
+			name:   "literal value set directly",
+			config: map[string]boilerrv1alpha1.ConfigValue{"maxPlayers": {Value: "20"}},
+			schema: map[string]boilerrv1alpha1.ConfigSchemaEntry{
+				"maxPlayers": {MapTo: &boilerrv1alpha1.ConfigMapping{Type: "env", Value: "MAX_PLAYERS"}},
+			},
+			expected: []corev1.EnvVar{{Name: "MAX_PLAYERS", Value: "20"}},
+		},
+		{
+			name:   "schema default used when not configured",
+			config: map[string]boilerrv1alpha1.ConfigValue{},
+			schema: map[string]boilerrv1alpha1.ConfigSchemaEntry{
+				"maxPlayers": {Default: "10", MapTo: &boilerrv1alpha1.ConfigMapping{Type: "env", Value: "MAX_PLAYERS"}},
+			},
+			expected: []corev1.EnvVar{{Name: "MAX_PLAYERS", Value: "10"}},
+		},
+		{
+			name:   "secret value uses secretKeyRef",
+			config: map[string]boilerrv1alpha1.ConfigValue{"password": {SecretKeyRef: passwordRef}},
+			schema: map[string]boilerrv1alpha1.ConfigSchemaEntry{
+				"password": {Secret: true, MapTo: &boilerrv1alpha1.ConfigMapping{Type: "env", Value: "SERVER_PASSWORD"}},
+			},
+			expected: []corev1.EnvVar{
+				{Name: "SERVER_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: passwordRef}},
+			},
+		},
+		{
+			name:   "unset key without default is skipped",
+			config: map[string]boilerrv1alpha1.ConfigValue{},
+			schema: map[string]boilerrv1alpha1.ConfigSchemaEntry{
+				"maxPlayers": {MapTo: &boilerrv1alpha1.ConfigMapping{Type: "env", Value: "MAX_PLAYERS"}},
+			},
+			expected: nil,
+		},
+		{
+			name:   "non-env mappings ignored",
+			config: map[string]boilerrv1alpha1.ConfigValue{"crossplay": {Value: "true"}, "serverName": {Value: "x"}},
+			schema: map[string]boilerrv1alpha1.ConfigSchemaEntry{
+				"crossplay":  {MapTo: &boilerrv1alpha1.ConfigMapping{Type: "arg", Value: "-crossplay", Condition: "true"}},
+				"serverName": {},
+			},
+			expected: nil,
+		},
+		{
+			name: "sorted by config key",
+			config: map[string]boilerrv1alpha1.ConfigValue{
+				"zeta":  {Value: "z"},
+				"alpha": {Value: "a"},
+			},
+			schema: map[string]boilerrv1alpha1.ConfigSchemaEntry{
+				"zeta":  {MapTo: &boilerrv1alpha1.ConfigMapping{Type: "env", Value: "ZETA"}},
+				"alpha": {MapTo: &boilerrv1alpha1.ConfigMapping{Type: "env", Value: "ALPHA"}},
+			},
+			expected: []corev1.EnvVar{{Name: "ALPHA", Value: "a"}, {Name: "ZETA", Value: "z"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, _ := ResolveConfigValues(tt.config, tt.schema)
+			got := ResolveEnvMappings(tt.config, tt.schema, values)
+
+			if len(got) != len(tt.expected) {
+				t.Fatalf("len(ResolveEnvMappings()) = %d, want %d: %v", len(got), len(tt.expected), got)
+			}
+			for i := range got {
+				if got[i].Name != tt.expected[i].Name {
+					t.Errorf("env[%d].Name = %q, want %q", i, got[i].Name, tt.expected[i].Name)
+				}
+				if got[i].Value != tt.expected[i].Value {
+					t.Errorf("env[%d].Value = %q, want %q", i, got[i].Value, tt.expected[i].Value)
+				}
+				if (got[i].ValueFrom == nil) != (tt.expected[i].ValueFrom == nil) {
+					t.Errorf("env[%d].ValueFrom = %v, want %v", i, got[i].ValueFrom, tt.expected[i].ValueFrom)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveArgMappings(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   map[string]boilerrv1alpha1.ConfigValue
+		schema   map[string]boilerrv1alpha1.ConfigSchemaEntry
+		expected []string
+	}{
+		{
+			name:   "flag added when condition matches",
+			config: map[string]boilerrv1alpha1.ConfigValue{"crossplay": {Value: "true"}},
+			schema: map[string]boilerrv1alpha1.ConfigSchemaEntry{
+				"crossplay": {MapTo: &boilerrv1alpha1.ConfigMapping{Type: "arg", Value: "-crossplay", Condition: "true"}},
+			},
+			expected: []string{"-crossplay"},
+		},
+		{
+			name:   "flag omitted when condition does not match",
+			config: map[string]boilerrv1alpha1.ConfigValue{"crossplay": {Value: "false"}},
+			schema: map[string]boilerrv1alpha1.ConfigSchemaEntry{
+				"crossplay": {MapTo: &boilerrv1alpha1.ConfigMapping{Type: "arg", Value: "-crossplay", Condition: "true"}},
+			},
+			expected: nil,
+		},
+		{
+			name:   "condition checked against schema default",
+			config: map[string]boilerrv1alpha1.ConfigValue{},
+			schema: map[string]boilerrv1alpha1.ConfigSchemaEntry{
+				"crossplay": {Default: "true", MapTo: &boilerrv1alpha1.ConfigMapping{Type: "arg", Value: "-crossplay", Condition: "true"}},
+			},
+			expected: []string{"-crossplay"},
+		},
+		{
+			name:   "value-carrying flag without condition",
+			config: map[string]boilerrv1alpha1.ConfigValue{"instanceId": {Value: "2"}},
+			schema: map[string]boilerrv1alpha1.ConfigSchemaEntry{
+				"instanceId": {MapTo: &boilerrv1alpha1.ConfigMapping{Type: "arg", Value: "-instanceid"}},
+			},
+			expected: []string{"-instanceid", "2"},
+		},
+		{
+			name:   "empty value skipped",
+			config: map[string]boilerrv1alpha1.ConfigValue{"instanceId": {Value: ""}},
+			schema: map[string]boilerrv1alpha1.ConfigSchemaEntry{
+				"instanceId": {MapTo: &boilerrv1alpha1.ConfigMapping{Type: "arg", Value: "-instanceid"}},
+			},
+			expected: nil,
+		},
+		{
+			name:   "array emits flag per element",
+			config: map[string]boilerrv1alpha1.ConfigValue{"admins": {Value: "111, 222,,333"}},
+			schema: map[string]boilerrv1alpha1.ConfigSchemaEntry{
+				"admins": {Array: true, MapTo: &boilerrv1alpha1.ConfigMapping{Type: "arg", Value: "-admin"}},
+			},
+			expected: []string{"-admin", "111", "-admin", "222", "-admin", "333"},
+		},
+		{
+			name: "deterministic order by config key",
+			config: map[string]boilerrv1alpha1.ConfigValue{
+				"public":    {Value: "1"},
+				"crossplay": {Value: "true"},
+			},
+			schema: map[string]boilerrv1alpha1.ConfigSchemaEntry{
+				"public":    {MapTo: &boilerrv1alpha1.ConfigMapping{Type: "arg", Value: "-public"}},
+				"crossplay": {MapTo: &boilerrv1alpha1.ConfigMapping{Type: "arg", Value: "-crossplay", Condition: "true"}},
+			},
+			expected: []string{"-crossplay", "-public", "1"},
+		},
+		{
+			name:   "secret value passed as env reference",
+			config: map[string]boilerrv1alpha1.ConfigValue{"token": {SecretKeyRef: &corev1.SecretKeySelector{Key: "token"}}},
+			schema: map[string]boilerrv1alpha1.ConfigSchemaEntry{
+				"token": {Secret: true, MapTo: &boilerrv1alpha1.ConfigMapping{Type: "arg", Value: "-token"}},
+			},
+			expected: []string{"-token", "$(CONFIG_TOKEN)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, _ := ResolveConfigValues(tt.config, tt.schema)
+			got := ResolveArgMappings(tt.schema, values)
+
+			if len(got) != len(tt.expected) {
+				t.Fatalf("ResolveArgMappings() = %v, want %v", got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("ResolveArgMappings()[%d] = %q, want %q", i, got[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestConfigFileMappings(t *testing.T) {
+	schema := map[string]boilerrv1alpha1.ConfigSchemaEntry{
+		"settings": {MapTo: &boilerrv1alpha1.ConfigMapping{
+			Type: "configFile", Path: "/data/server/settings.json", Template: `{"motd": "{{.Config.settings}}"}`,
+		}},
+		"motd":  {MapTo: &boilerrv1alpha1.ConfigMapping{Type: "configFile", Path: "/data/server/motd.txt"}},
+		"unset": {MapTo: &boilerrv1alpha1.ConfigMapping{Type: "configFile", Path: "/data/server/unset.txt"}},
+		"name":  {},
+	}
+	config := map[string]boilerrv1alpha1.ConfigValue{
+		"settings": {Value: "hello"},
+		"motd":     {Value: "Welcome!"},
+	}
+
+	values, _ := ResolveConfigValues(config, schema)
+	files := ConfigFileMappings(schema, values)
+	if len(files) != 2 {
+		t.Fatalf("len(ConfigFileMappings()) = %d, want 2: %v", len(files), files)
+	}
+
+	expected := []struct{ path, rendered string }{
+		{"/data/server/motd.txt", "Welcome!"},
+		{"/data/server/settings.json", `{"motd": "hello"}`},
+	}
+	for i, want := range expected {
+		if files[i].Path != want.path {
+			t.Errorf("files[%d].Path = %q, want %q", i, files[i].Path, want.path)
+		}
+		got, err := InterpolateString(files[i].Content, values)
+		if err != nil {
+			t.Fatalf("InterpolateString() error = %v", err)
+		}
+		if got != want.rendered {
+			t.Errorf("rendered files[%d] = %q, want %q", i, got, want.rendered)
+		}
+	}
+}
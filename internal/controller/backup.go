@@ -0,0 +1,224 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/backup"
+	"github.com/CraightonH/boilerr/internal/resources"
+)
+
+// reconcileBackup ensures the backup CronJob matches spec.backup and records finished backup Jobs
+// in status.backups. Without spec.backup the CronJob is deleted; past results are kept.
+func (r *SteamServerReconciler) reconcileBackup(ctx context.Context, server *boilerrv1alpha1.SteamServer) error {
+	logger := log.FromContext(ctx)
+
+	existing := &batchv1.CronJob{}
+	err := r.Get(ctx, client.ObjectKey{
+		Name:      resources.BackupCronJobName(server.Name),
+		Namespace: server.Namespace,
+	}, existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	found := err == nil
+
+	// Backups run the backup binary from the operator image
+	if server.Spec.Backup == nil || r.BackupImage == "" {
+		if !found {
+			return nil
+		}
+		logger.Info("Deleting backup CronJob", "name", existing.Name)
+		return client.IgnoreNotFound(r.Delete(ctx, existing))
+	}
+
+	desired, err := resources.NewBackupCronJobBuilder(server).WithImage(r.BackupImage).Build()
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		if err := controllerutil.SetControllerReference(server, desired, r.Scheme); err != nil {
+			return err
+		}
+		logger.Info("Creating backup CronJob", "name", desired.Name)
+		if err := r.Create(ctx, desired); err != nil {
+			return err
+		}
+	} else if existing.Annotations[resources.SpecHashAnnotation] != desired.Annotations[resources.SpecHashAnnotation] {
+		existing.Spec = desired.Spec
+		existing.Labels = desired.Labels
+		if existing.Annotations == nil {
+			existing.Annotations = map[string]string{}
+		}
+		existing.Annotations[resources.SpecHashAnnotation] = desired.Annotations[resources.SpecHashAnnotation]
+		logger.Info("Updating backup CronJob", "name", existing.Name)
+		if err := r.Update(ctx, existing); err != nil {
+			return err
+		}
+	}
+
+	return r.recordBackups(ctx, server)
+}
+
+// recordBackups adds finished backup Jobs to status.backups.
+func (r *SteamServerReconciler) recordBackups(ctx context.Context, server *boilerrv1alpha1.SteamServer) error {
+	jobs := &batchv1.JobList{}
+	if err := r.List(ctx, jobs,
+		client.InNamespace(server.Namespace),
+		client.MatchingLabels{
+			"app.kubernetes.io/name":     "backup",
+			"app.kubernetes.io/instance": server.Name,
+		},
+	); err != nil {
+		return err
+	}
+
+	records := server.Status.Backups
+	recorded := make(map[string]bool, len(records))
+	for _, b := range records {
+		recorded[b.Job] = true
+	}
+
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if recorded[job.Name] || !jobFinished(job) {
+			continue
+		}
+		pods := &corev1.PodList{}
+		if err := r.List(ctx, pods,
+			client.InNamespace(job.Namespace),
+			client.MatchingLabels{batchv1.JobNameLabel: job.Name},
+		); err != nil {
+			return err
+		}
+		records = append(records, backupRecord(job, pods.Items))
+	}
+
+	retention := int(resources.DefaultBackupRetention)
+	if server.Spec.Backup.Retention > 0 {
+		retention = int(server.Spec.Backup.Retention)
+	}
+	records = newestBackups(records, retention)
+
+	if equality.Semantic.DeepEqual(records, server.Status.Backups) {
+		return nil
+	}
+	server.Status.Backups = records
+	return r.Status().Update(ctx, server)
+}
+
+// jobFinished returns whether a Job has completed or failed.
+func jobFinished(job *batchv1.Job) bool {
+	for _, c := range job.Status.Conditions {
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// backupRecord builds the status.backups entry of a finished backup Job.
+// The backup container reports its result, or its error, in its termination message.
+func backupRecord(job *batchv1.Job, pods []corev1.Pod) boilerrv1alpha1.BackupStatus {
+	record := boilerrv1alpha1.BackupStatus{
+		Job:    job.Name,
+		Result: boilerrv1alpha1.BackupFailed,
+	}
+
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			record.Result = boilerrv1alpha1.BackupSucceeded
+			record.Time = c.LastTransitionTime
+		case batchv1.JobFailed:
+			record.Time = c.LastTransitionTime
+			record.Message = c.Message
+		}
+	}
+	if job.Status.CompletionTime != nil {
+		record.Time = *job.Status.CompletionTime
+	}
+
+	for _, pod := range pods {
+		for _, cs := range pod.Status.ContainerStatuses {
+			term := cs.State.Terminated
+			if cs.Name != resources.BackupContainerName || term == nil || term.Message == "" {
+				continue
+			}
+			if record.Result == boilerrv1alpha1.BackupSucceeded && term.ExitCode == 0 {
+				var result backup.Result
+				if err := json.Unmarshal([]byte(term.Message), &result); err == nil {
+					record.Location = result.Location
+					record.SizeBytes = result.Size
+				}
+			}
+			if record.Result == boilerrv1alpha1.BackupFailed && term.ExitCode != 0 {
+				record.Message = term.Message
+			}
+		}
+	}
+	return record
+}
+
+// newestBackups sorts backups newest first and keeps at most limit entries.
+func newestBackups(backups []boilerrv1alpha1.BackupStatus, limit int) []boilerrv1alpha1.BackupStatus {
+	sorted := append([]boilerrv1alpha1.BackupStatus(nil), backups...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[j].Time.Before(&sorted[i].Time)
+	})
+	if len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+	if len(sorted) == 0 {
+		return nil
+	}
+	return sorted
+}
+
+// findSteamServerForBackupJob maps a backup Job to the SteamServer it backs up.
+// Jobs are owned by the backup CronJob, so they are matched by label.
+func (r *SteamServerReconciler) findSteamServerForBackupJob(ctx context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	if labels["app.kubernetes.io/name"] != "backup" || labels["app.kubernetes.io/managed-by"] != "boilerr" {
+		return nil
+	}
+	name := labels["app.kubernetes.io/instance"]
+	if name == "" {
+		return nil
+	}
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{Name: name, Namespace: obj.GetNamespace()},
+	}}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/resources"
+)
+
+var _ = Describe("Backup Helper Functions", func() {
+	finished := metav1.NewTime(time.Date(2026, 1, 1, 6, 0, 0, 0, time.UTC))
+
+	newJob := func(condition batchv1.JobConditionType) *batchv1.Job {
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "valheim-backup-29000000", Namespace: "default"},
+			Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{{
+					Type:               condition,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: finished,
+					Message:            "Job has reached the specified backoff limit",
+				}},
+			},
+		}
+		if condition == batchv1.JobComplete {
+			job.Status.CompletionTime = &finished
+		}
+		return job
+	}
+
+	newPod := func(exitCode int32, message string) corev1.Pod {
+		return corev1.Pod{
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: resources.BackupContainerName,
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode, Message: message},
+					},
+				}},
+			},
+		}
+	}
+
+	Context("backupRecord", func() {
+		It("Should record the archive from the termination message", func() {
+			pods := []corev1.Pod{newPod(0, `{"name":"valheim-20260101T060000Z.tar.gz",`+
+				`"location":"s3://saves/valheim-20260101T060000Z.tar.gz","size":1048576}`)}
+
+			record := backupRecord(newJob(batchv1.JobComplete), pods)
+			Expect(record).To(Equal(boilerrv1alpha1.BackupStatus{
+				Job:       "valheim-backup-29000000",
+				Time:      finished,
+				Result:    boilerrv1alpha1.BackupSucceeded,
+				Location:  "s3://saves/valheim-20260101T060000Z.tar.gz",
+				SizeBytes: 1048576,
+			}))
+		})
+
+		It("Should record the error of a failed backup", func() {
+			pods := []corev1.Pod{newPod(1, "storing archive: PUT /saves: 403 Forbidden")}
+
+			record := backupRecord(newJob(batchv1.JobFailed), pods)
+			Expect(record.Result).To(Equal(boilerrv1alpha1.BackupFailed))
+			Expect(record.Time).To(Equal(finished))
+			Expect(record.Message).To(Equal("storing archive: PUT /saves: 403 Forbidden"))
+		})
+
+		It("Should fall back to the Job condition message", func() {
+			record := backupRecord(newJob(batchv1.JobFailed), nil)
+			Expect(record.Message).To(Equal("Job has reached the specified backoff limit"))
+		})
+	})
+
+	Context("jobFinished", func() {
+		It("Should only consider completed or failed Jobs finished", func() {
+			Expect(jobFinished(newJob(batchv1.JobComplete))).To(BeTrue())
+			Expect(jobFinished(newJob(batchv1.JobFailed))).To(BeTrue())
+			Expect(jobFinished(&batchv1.Job{})).To(BeFalse())
+		})
+	})
+
+	Context("newestBackups", func() {
+		It("Should sort newest first and apply the limit", func() {
+			at := func(hour int) metav1.Time {
+				return metav1.NewTime(time.Date(2026, 1, 1, hour, 0, 0, 0, time.UTC))
+			}
+			backups := []boilerrv1alpha1.BackupStatus{
+				{Job: "a", Time: at(0)},
+				{Job: "c", Time: at(12)},
+				{Job: "b", Time: at(6)},
+			}
+
+			Expect(newestBackups(backups, 2)).To(Equal([]boilerrv1alpha1.BackupStatus{
+				{Job: "c", Time: at(12)},
+				{Job: "b", Time: at(6)},
+			}))
+			Expect(newestBackups(nil, 2)).To(BeNil())
+		})
+	})
+
+	Context("findSteamServerForBackupJob", func() {
+		It("Should map backup Jobs to their server", func() {
+			r := &SteamServerReconciler{}
+			server := &boilerrv1alpha1.SteamServer{ObjectMeta: metav1.ObjectMeta{Name: "valheim"}}
+			job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "games",
+				Labels:    resources.BackupLabels(server),
+			}}
+
+			requests := r.findSteamServerForBackupJob(context.Background(), job)
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].Name).To(Equal("valheim"))
+			Expect(requests[0].Namespace).To(Equal("games"))
+
+			Expect(r.findSteamServerForBackupJob(context.Background(), &batchv1.Job{})).To(BeEmpty())
+		})
+	})
+})
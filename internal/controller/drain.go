@@ -0,0 +1,201 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/resources"
+)
+
+// DefaultDrainMaxWait is used when updateStrategy.drain omits maxWait.
+const DefaultDrainMaxWait = time.Hour
+
+// drainPollInterval is how often a held change rechecks the player count.
+// RCON player counts don't change status, so they don't trigger a reconcile by themselves.
+const drainPollInterval = time.Minute
+
+// Reasons a held change is applied.
+const (
+	drainReasonForced        = "Forced"
+	drainReasonNotRunning    = "NotRunning"
+	drainReasonNoPlayers     = "NoPlayers"
+	drainReasonNoPlayerCount = "NoPlayerCount"
+	drainReasonMaxWait       = "MaxWaitExpired"
+	drainReasonWaitPlayers   = "WaitingForPlayers"
+	drainReasonUnknown       = "PlayerCountUnavailable"
+)
+
+// playerCounter returns the number of connected players, or nil if it is unknown.
+// hasSource reports whether the server has a source for the player count at all.
+type playerCounter func() (players *int32, hasSource bool)
+
+// drainDecision decides whether a change that restarts the pod must wait for players to leave.
+// Returns whether to hold the change and when the hold expires, or the reason it may be applied now.
+// The wait starts when the PendingUpdate condition was first set. A server without a source for
+// its player count is never held; one whose count is unavailable is held until maxWait.
+func drainDecision(server *boilerrv1alpha1.SteamServer, count playerCounter, now time.Time) (hold bool, reason string, deadline time.Time) {
+	if server.Spec.UpdateStrategy == nil || server.Spec.UpdateStrategy.Drain == nil {
+		return false, "", time.Time{}
+	}
+	if server.Annotations[boilerrv1alpha1.ForceUpdateAnnotation] == "true" {
+		return false, drainReasonForced, time.Time{}
+	}
+	if server.Status.State != boilerrv1alpha1.ServerStateRunning {
+		return false, drainReasonNotRunning, time.Time{}
+	}
+	players, hasSource := count()
+	if players != nil && *players == 0 {
+		return false, drainReasonNoPlayers, time.Time{}
+	}
+	if players == nil && !hasSource {
+		return false, drainReasonNoPlayerCount, time.Time{}
+	}
+
+	maxWait := DefaultDrainMaxWait
+	if mw := server.Spec.UpdateStrategy.Drain.MaxWait; mw != nil {
+		maxWait = mw.Duration
+	}
+	since := now
+	if cond := meta.FindStatusCondition(server.Status.Conditions, boilerrv1alpha1.ConditionPendingUpdate); cond != nil &&
+		cond.Status == metav1.ConditionTrue {
+		since = cond.LastTransitionTime.Time
+	}
+
+	deadline = since.Add(maxWait)
+	if !now.Before(deadline) {
+		return false, drainReasonMaxWait, time.Time{}
+	}
+	return true, "", deadline
+}
+
+// drainPlayerCount returns a playerCounter reading the A2S player count in status.serverInfo,
+// or with updateStrategy.drain.rcon, the RCON player count when there is no server info.
+// The count is read once, on the first call.
+func (r *SteamServerReconciler) drainPlayerCount(ctx context.Context, server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) playerCounter {
+	return sync.OnceValues(func() (*int32, bool) {
+		if info := server.Status.ServerInfo; info != nil {
+			return &info.Players, true
+		}
+
+		_, hasA2S := resources.A2SQueryPort(server, gameDef)
+		spec := server.Spec.UpdateStrategy.Drain.RCON
+		if spec == nil {
+			return nil, hasA2S
+		}
+
+		output, err := r.runRCON(ctx, server, gameDef, "updateStrategy.drain.rcon", spec.Port, spec.PasswordSecretRef, []string{spec.Command})
+		if err == nil {
+			var players int32
+			if players, err = countPlayers(strings.Join(output, "\n"), spec.Pattern); err == nil {
+				return &players, true
+			}
+		}
+		log.FromContext(ctx).Error(err, "Failed to read the player count over RCON")
+		return nil, true
+	})
+}
+
+// countPlayers reads the player count from RCON output. With a capture group in pattern, the group
+// of the first match is the count; without one, each matching line counts as one player.
+func countPlayers(output, pattern string) (int32, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return 0, fmt.Errorf("updateStrategy.drain.rcon.pattern: %w", err)
+	}
+
+	if re.NumSubexp() == 0 {
+		var players int32
+		for _, line := range strings.Split(output, "\n") {
+			if re.MatchString(line) {
+				players++
+			}
+		}
+		return players, nil
+	}
+
+	match := re.FindStringSubmatch(output)
+	if match == nil {
+		return 0, fmt.Errorf("RCON output does not match updateStrategy.drain.rcon.pattern %q", pattern)
+	}
+	players, err := strconv.ParseInt(match[1], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("player count %q is not a number", match[1])
+	}
+	return int32(players), nil
+}
+
+// setPendingUpdate records a held change in the PendingUpdate condition.
+func (r *SteamServerReconciler) setPendingUpdate(ctx context.Context, server *boilerrv1alpha1.SteamServer, players *int32, deadline time.Time) error {
+	reason := drainReasonWaitPlayers
+	message := fmt.Sprintf("Waiting for %d players to leave before restarting, at the latest by %s",
+		ptr.Deref(players, 0), deadline.UTC().Format(time.RFC3339))
+	if players == nil {
+		reason = drainReasonUnknown
+		message = fmt.Sprintf("Player count is unavailable from A2S and RCON; holding the restart until %s",
+			deadline.UTC().Format(time.RFC3339))
+	}
+
+	changed := meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               boilerrv1alpha1.ConditionPendingUpdate,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: server.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+	if !changed {
+		return nil
+	}
+	return r.Status().Update(ctx, server)
+}
+
+// clearPendingUpdate marks a held change as applied and removes the force annotation.
+// Does nothing if no change was held.
+func (r *SteamServerReconciler) clearPendingUpdate(ctx context.Context, server *boilerrv1alpha1.SteamServer, reason string) error {
+	if _, ok := server.Annotations[boilerrv1alpha1.ForceUpdateAnnotation]; ok {
+		patch := client.MergeFrom(server.DeepCopy())
+		delete(server.Annotations, boilerrv1alpha1.ForceUpdateAnnotation)
+		if err := r.Patch(ctx, server, patch); err != nil {
+			return err
+		}
+	}
+
+	if !meta.IsStatusConditionTrue(server.Status.Conditions, boilerrv1alpha1.ConditionPendingUpdate) {
+		return nil
+	}
+	meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               boilerrv1alpha1.ConditionPendingUpdate,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: server.Generation,
+		Reason:             reason,
+		Message:            fmt.Sprintf("Applied held change (%s)", reason),
+	})
+	return r.Status().Update(ctx, server)
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+)
+
+var _ = Describe("Drain Helper Functions", func() {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newServer := func() *boilerrv1alpha1.SteamServer {
+		return &boilerrv1alpha1.SteamServer{
+			Spec: boilerrv1alpha1.SteamServerSpec{
+				UpdateStrategy: &boilerrv1alpha1.UpdateStrategy{
+					Drain: &boilerrv1alpha1.DrainStrategy{
+						MaxWait: &metav1.Duration{Duration: 30 * time.Minute},
+					},
+				},
+			},
+			Status: boilerrv1alpha1.SteamServerStatus{State: boilerrv1alpha1.ServerStateRunning},
+		}
+	}
+	players := func(n int32) playerCounter {
+		return func() (*int32, bool) { return &n, true }
+	}
+
+	It("Should apply immediately without a drain strategy", func() {
+		server := newServer()
+		server.Spec.UpdateStrategy = nil
+
+		hold, _, _ := drainDecision(server, players(3), now)
+		Expect(hold).To(BeFalse())
+	})
+
+	It("Should hold while players are connected", func() {
+		hold, _, deadline := drainDecision(newServer(), players(3), now)
+		Expect(hold).To(BeTrue())
+		Expect(deadline).To(Equal(now.Add(30 * time.Minute)))
+	})
+
+	It("Should apply once the server is empty", func() {
+		hold, reason, _ := drainDecision(newServer(), players(0), now)
+		Expect(hold).To(BeFalse())
+		Expect(reason).To(Equal(drainReasonNoPlayers))
+	})
+
+	It("Should apply when the server is not running", func() {
+		server := newServer()
+		server.Status.State = boilerrv1alpha1.ServerStateStarting
+
+		hold, reason, _ := drainDecision(server, players(3), now)
+		Expect(hold).To(BeFalse())
+		Expect(reason).To(Equal(drainReasonNotRunning))
+	})
+
+	It("Should apply when forced", func() {
+		server := newServer()
+		server.Annotations = map[string]string{boilerrv1alpha1.ForceUpdateAnnotation: "true"}
+
+		hold, reason, _ := drainDecision(server, players(3), now)
+		Expect(hold).To(BeFalse())
+		Expect(reason).To(Equal(drainReasonForced))
+	})
+
+	It("Should apply without a source for the player count", func() {
+		hold, reason, _ := drainDecision(newServer(), func() (*int32, bool) { return nil, false }, now)
+		Expect(hold).To(BeFalse())
+		Expect(reason).To(Equal(drainReasonNoPlayerCount))
+	})
+
+	It("Should hold until maxWait while the player count is unavailable", func() {
+		hold, _, deadline := drainDecision(newServer(), func() (*int32, bool) { return nil, true }, now)
+		Expect(hold).To(BeTrue())
+		Expect(deadline).To(Equal(now.Add(30 * time.Minute)))
+	})
+
+	It("Should count players in RCON output", func() {
+		count, err := countPlayers("There are 3 of a max of 20 players online: a, b, c", `There are ([0-9]+) of`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(int32(3)))
+
+		count, err = countPlayers("0. Alice, 76561198000000001\n1. Bob, 76561198000000002\n", `^[0-9]+\. `)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(int32(2)))
+
+		count, err = countPlayers("No Players Connected\n", `^[0-9]+\. `)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(BeZero())
+
+		_, err = countPlayers("Unknown command", `There are ([0-9]+) of`)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Should measure maxWait from when the change started waiting", func() {
+		server := newServer()
+		server.Status.Conditions = []metav1.Condition{{
+			Type:               boilerrv1alpha1.ConditionPendingUpdate,
+			Status:             metav1.ConditionTrue,
+			Reason:             drainReasonWaitPlayers,
+			LastTransitionTime: metav1.NewTime(now.Add(-20 * time.Minute)),
+		}}
+
+		hold, _, deadline := drainDecision(server, players(3), now)
+		Expect(hold).To(BeTrue())
+		Expect(deadline).To(Equal(now.Add(10 * time.Minute)))
+
+		hold, reason, _ := drainDecision(server, players(3), now.Add(10*time.Minute))
+		Expect(hold).To(BeFalse())
+		Expect(reason).To(Equal(drainReasonMaxWait))
+	})
+})
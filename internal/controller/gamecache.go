@@ -0,0 +1,264 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/resources"
+)
+
+// gameCachePollInterval is how often a server waiting for its game cache re-checks the populate Job.
+const gameCachePollInterval = 10 * time.Second
+
+// gameCacheGracePeriod protects a new game cache from pruning, until the server that
+// created it shows up in the cached list of SteamServers.
+const gameCacheGracePeriod = 10 * time.Minute
+
+// Reasons for the GameCacheReady condition.
+const (
+	gameCacheReasonPopulated       = "Populated"
+	gameCacheReasonPopulating      = "Populating"
+	gameCacheReasonFailed          = "PopulateFailed"
+	gameCacheReasonWaitingForBuild = "WaitingForBuild"
+)
+
+// selectGameCache returns the build and game cache a gameCache server installs, given the build
+// targetBuild chose and the pod template of the existing StatefulSet. Until the game cache of the
+// build is populated, the server keeps its current build and game cache, so a new server gets none
+// and the caller holds it. The returned duration is when to re-check a game cache being populated.
+func (r *SteamServerReconciler) selectGameCache(ctx context.Context, server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition, target string, current *corev1.PodTemplateSpec) (string, string, time.Duration, error) {
+	build := gameCacheBuild(server, target)
+
+	populated, err := r.reconcileGameCache(ctx, server, gameDef, build)
+	if err != nil {
+		return "", "", 0, err
+	}
+	if populated {
+		return build, resources.GameCacheName(resources.AppID(server, gameDef), build), 0, nil
+	}
+	return current.Annotations[resources.TargetBuildAnnotation], resources.GameCacheClaim(current), gameCachePollInterval, nil
+}
+
+// gameCacheBuild returns the build whose game cache a server installs from: the target build,
+// otherwise the installed build, otherwise the latest build the update checker recorded.
+// Returns an empty string until one of them is known.
+func gameCacheBuild(server *boilerrv1alpha1.SteamServer, target string) string {
+	switch {
+	case target != "":
+		return target
+	case server.Status.AppBuildId != "":
+		return server.Status.AppBuildId
+	default:
+		return server.Status.LatestBuildId
+	}
+}
+
+// reconcileGameCache ensures the game cache volume of a build exists and its populate Job has run.
+// Returns whether the game cache is populated.
+func (r *SteamServerReconciler) reconcileGameCache(ctx context.Context, server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition, buildID string) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	if r.GameCacheStorageClass == "" {
+		return false, fmt.Errorf("gameCache requires the operator to run with --game-cache-storage-class")
+	}
+	if buildID == "" {
+		return false, r.setGameCacheStatus(ctx, server, "", metav1.ConditionFalse, gameCacheReasonWaitingForBuild,
+			"Waiting for the update checker to look up the latest Steam build")
+	}
+
+	builder := resources.NewGameCacheBuilder(server, gameDef, buildID).WithStorageClass(r.GameCacheStorageClass)
+	name := builder.Name()
+	populating := fmt.Sprintf("Installing build %s into game cache %s", buildID, name)
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: server.Namespace}, pvc)
+	if apierrors.IsNotFound(err) {
+		// Record the game cache first, so it is never pruned as unused
+		if err := r.setGameCacheStatus(ctx, server, name, metav1.ConditionFalse, gameCacheReasonPopulating, populating); err != nil {
+			return false, err
+		}
+		pvc = builder.BuildPVC()
+		logger.Info("Creating game cache", "name", name)
+		if err := r.Create(ctx, pvc); err != nil && !apierrors.IsAlreadyExists(err) {
+			return false, err
+		}
+	} else if err != nil {
+		return false, err
+	}
+
+	job := &batchv1.Job{}
+	err = r.Get(ctx, client.ObjectKey{Name: name, Namespace: server.Namespace}, job)
+	if apierrors.IsNotFound(err) {
+		// The Job is owned by the game cache, so pruning the cache removes it
+		job = builder.BuildJob()
+		if err := controllerutil.SetControllerReference(pvc, job, r.Scheme); err != nil {
+			return false, err
+		}
+		logger.Info("Populating game cache", "name", name, "build", buildID)
+		if err := r.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+			return false, err
+		}
+		return false, r.setGameCacheStatus(ctx, server, name, metav1.ConditionFalse, gameCacheReasonPopulating, populating)
+	} else if err != nil {
+		return false, err
+	}
+
+	var pods []corev1.Pod
+	if jobFinished(job) {
+		podList := &corev1.PodList{}
+		if err := r.List(ctx, podList,
+			client.InNamespace(job.Namespace),
+			client.MatchingLabels{batchv1.JobNameLabel: job.Name},
+		); err != nil {
+			return false, err
+		}
+		pods = podList.Items
+	}
+
+	populated, jobErr := gameCacheJobResult(job, pods)
+	switch {
+	case jobErr != nil:
+		return false, r.setGameCacheStatus(ctx, server, name, metav1.ConditionFalse, gameCacheReasonFailed,
+			fmt.Sprintf("%s; delete PVC %s to retry", jobErr.Error(), name))
+	case !populated:
+		return false, r.setGameCacheStatus(ctx, server, name, metav1.ConditionFalse, gameCacheReasonPopulating, populating)
+	}
+	return true, r.setGameCacheStatus(ctx, server, name, metav1.ConditionTrue, gameCacheReasonPopulated,
+		fmt.Sprintf("Game cache %s holds build %s", name, buildID))
+}
+
+// gameCacheJobResult returns whether a game cache populate Job succeeded, or an error with
+// the reason it failed. The reason comes from the termination message of the SteamCMD container.
+func gameCacheJobResult(job *batchv1.Job, pods []corev1.Pod) (bool, error) {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return true, nil
+		case batchv1.JobFailed:
+			message := c.Message
+			for _, pod := range pods {
+				for _, cs := range pod.Status.ContainerStatuses {
+					if cs.Name == resources.InitContainerName && cs.State.Terminated != nil && cs.State.Terminated.Message != "" {
+						message = cs.State.Terminated.Message
+					}
+				}
+			}
+			return false, fmt.Errorf("populating game cache failed: %s", message)
+		}
+	}
+	return false, nil
+}
+
+// setGameCacheStatus records the game cache in status.gameCache and its state in the GameCacheReady condition.
+func (r *SteamServerReconciler) setGameCacheStatus(ctx context.Context, server *boilerrv1alpha1.SteamServer, name string, status metav1.ConditionStatus, reason, message string) error {
+	changed := server.Status.GameCache != name
+	server.Status.GameCache = name
+	changed = meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               boilerrv1alpha1.ConditionGameCacheReady,
+		Status:             status,
+		ObservedGeneration: server.Generation,
+		Reason:             reason,
+		Message:            message,
+	}) || changed
+	if !changed {
+		return nil
+	}
+	return r.Status().Update(ctx, server)
+}
+
+// clearGameCacheStatus removes status.gameCache and the GameCacheReady condition once gameCache is unset.
+func (r *SteamServerReconciler) clearGameCacheStatus(ctx context.Context, server *boilerrv1alpha1.SteamServer) error {
+	changed := server.Status.GameCache != ""
+	server.Status.GameCache = ""
+	changed = meta.RemoveStatusCondition(&server.Status.Conditions, boilerrv1alpha1.ConditionGameCacheReady) || changed
+	if !changed {
+		return nil
+	}
+	return r.Status().Update(ctx, server)
+}
+
+// pruneGameCaches deletes the game caches in a namespace that no SteamServer uses: none records it
+// in status.gameCache and no StatefulSet mounts it. The caches of a SteamServer being deleted no
+// longer count as used.
+func pruneGameCaches(ctx context.Context, c client.Client, namespace string, deleting *boilerrv1alpha1.SteamServer, now time.Time) error {
+	caches := &corev1.PersistentVolumeClaimList{}
+	if err := c.List(ctx, caches,
+		client.InNamespace(namespace),
+		client.MatchingLabels(resources.GameCacheLabels()),
+	); err != nil || len(caches.Items) == 0 {
+		return err
+	}
+
+	servers := &boilerrv1alpha1.SteamServerList{}
+	if err := c.List(ctx, servers, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+	inUse := map[string]bool{}
+	for i := range servers.Items {
+		server := &servers.Items[i]
+		if deleting != nil && server.UID == deleting.UID {
+			continue
+		}
+		inUse[server.Status.GameCache] = true
+
+		sts := &appsv1.StatefulSet{}
+		err := c.Get(ctx, client.ObjectKey{Name: server.Name, Namespace: namespace}, sts)
+		if err == nil {
+			inUse[resources.GameCacheClaim(&sts.Spec.Template)] = true
+		} else if !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	for _, pvc := range unusedGameCaches(caches.Items, inUse, now) {
+		log.FromContext(ctx).Info("Deleting unused game cache", "name", pvc.Name, "namespace", namespace)
+		if err := c.Delete(ctx, pvc, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unusedGameCaches returns the game caches not in use, except those created within gameCacheGracePeriod.
+func unusedGameCaches(caches []corev1.PersistentVolumeClaim, inUse map[string]bool, now time.Time) []*corev1.PersistentVolumeClaim {
+	var unused []*corev1.PersistentVolumeClaim
+	for i := range caches {
+		pvc := &caches[i]
+		if inUse[pvc.Name] || !pvc.DeletionTimestamp.IsZero() || now.Sub(pvc.CreationTimestamp.Time) < gameCacheGracePeriod {
+			continue
+		}
+		unused = append(unused, pvc)
+	}
+	return unused
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/CraightonH/boilerr/internal/resources"
+)
+
+// DefaultGameCachePruneInterval is the default time between game cache garbage collections.
+const DefaultGameCachePruneInterval = 10 * time.Minute
+
+// GameCachePruner periodically deletes the game caches no SteamServer uses.
+// Pruning runs outside of Reconcile, since it reads every SteamServer in a namespace.
+type GameCachePruner struct {
+	client.Client
+
+	// Interval between prunes. Defaults to DefaultGameCachePruneInterval.
+	Interval time.Duration
+}
+
+// +kubebuilder:rbac:groups=boilerr.dev,resources=steamservers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;delete
+
+// Start prunes unused game caches every Interval until ctx is cancelled.
+func (p *GameCachePruner) Start(ctx context.Context) error {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = DefaultGameCachePruneInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.pruneAll(ctx); err != nil {
+			log.FromContext(ctx).WithName("gamecache").Error(err, "Failed to prune game caches")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection ensures only the leader deletes game caches.
+func (p *GameCachePruner) NeedLeaderElection() bool {
+	return true
+}
+
+// pruneAll prunes the unused game caches of every namespace that has game caches.
+func (p *GameCachePruner) pruneAll(ctx context.Context) error {
+	caches := &corev1.PersistentVolumeClaimList{}
+	if err := p.List(ctx, caches, client.MatchingLabels(resources.GameCacheLabels())); err != nil {
+		return err
+	}
+
+	namespaces := map[string]bool{}
+	for _, pvc := range caches.Items {
+		if namespaces[pvc.Namespace] {
+			continue
+		}
+		namespaces[pvc.Namespace] = true
+		if err := pruneGameCaches(ctx, p.Client, pvc.Namespace, nil, time.Now()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/resources"
+)
+
+var _ = Describe("Game Cache Helper Functions", func() {
+	Context("gameCacheJobResult", func() {
+		newJob := func(condition batchv1.JobConditionType) *batchv1.Job {
+			job := &batchv1.Job{}
+			if condition != "" {
+				job.Status.Conditions = []batchv1.JobCondition{{
+					Type:    condition,
+					Status:  corev1.ConditionTrue,
+					Message: "Job has reached the specified backoff limit",
+				}}
+			}
+			return job
+		}
+
+		It("should report a running Job as not populated", func() {
+			populated, err := gameCacheJobResult(newJob(""), nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(populated).To(BeFalse())
+		})
+
+		It("should report a completed Job as populated", func() {
+			populated, err := gameCacheJobResult(newJob(batchv1.JobComplete), nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(populated).To(BeTrue())
+		})
+
+		It("should return the SteamCMD termination message of a failed Job", func() {
+			pods := []corev1.Pod{{
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name: resources.InitContainerName,
+						State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 1,
+							Message:  "SteamCMD installed build 15700000 instead of 15632571",
+						}},
+					}},
+				},
+			}}
+			_, err := gameCacheJobResult(newJob(batchv1.JobFailed), pods)
+			Expect(err).To(MatchError(ContainSubstring("installed build 15700000 instead of 15632571")))
+		})
+
+		It("should fall back to the Job condition message", func() {
+			_, err := gameCacheJobResult(newJob(batchv1.JobFailed), nil)
+			Expect(err).To(MatchError(ContainSubstring("backoff limit")))
+		})
+	})
+
+	Context("gameCacheBuild", func() {
+		It("should prefer the target, then the installed, then the latest build", func() {
+			server := &boilerrv1alpha1.SteamServer{}
+			Expect(gameCacheBuild(server, "")).To(BeEmpty())
+
+			server.Status.LatestBuildId = "300"
+			Expect(gameCacheBuild(server, "")).To(Equal("300"))
+
+			server.Status.AppBuildId = "200"
+			Expect(gameCacheBuild(server, "")).To(Equal("200"))
+			Expect(gameCacheBuild(server, "100")).To(Equal("100"))
+		})
+	})
+
+	Context("unusedGameCaches", func() {
+		now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		newCache := func(name string, age time.Duration) corev1.PersistentVolumeClaim {
+			return corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			}}
+		}
+		names := func(pvcs []*corev1.PersistentVolumeClaim) []string {
+			var out []string
+			for _, pvc := range pvcs {
+				out = append(out, pvc.Name)
+			}
+			return out
+		}
+
+		It("should return caches no server uses", func() {
+			caches := []corev1.PersistentVolumeClaim{
+				newCache("gamecache-896660-1", time.Hour),
+				newCache("gamecache-896660-2", time.Hour),
+				newCache("gamecache-896660-3", time.Hour),
+			}
+			inUse := map[string]bool{"gamecache-896660-2": true, "": true}
+			Expect(names(unusedGameCaches(caches, inUse, now))).To(Equal([]string{"gamecache-896660-1", "gamecache-896660-3"}))
+		})
+
+		It("should keep caches created within the grace period", func() {
+			caches := []corev1.PersistentVolumeClaim{newCache("gamecache-896660-1", time.Minute)}
+			Expect(unusedGameCaches(caches, map[string]bool{}, now)).To(BeEmpty())
+		})
+
+		It("should skip caches already being deleted", func() {
+			cache := newCache("gamecache-896660-1", time.Hour)
+			deleted := metav1.NewTime(now)
+			cache.DeletionTimestamp = &deleted
+			Expect(unusedGameCaches([]corev1.PersistentVolumeClaim{cache}, map[string]bool{}, now)).To(BeEmpty())
+		})
+	})
+})
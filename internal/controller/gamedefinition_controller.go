@@ -19,13 +19,18 @@ package controller
 import (
 	"context"
 	"fmt"
+	"path"
+	"strings"
+	"text/template"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/resources"
 )
 
 // GameDefinitionReconciler reconciles a GameDefinition object.
@@ -96,12 +101,66 @@ func (r *GameDefinitionReconciler) validate(gd *boilerrv1alpha1.GameDefinition)
 		}
 	}
 
+	// Validate health check references a defined port
+	if hc := gd.Spec.HealthCheck; hc != nil && hc.TCPSocket != nil {
+		if err := validatePortRef(hc.TCPSocket.Port, gd.Spec.Ports); err != nil {
+			return fmt.Errorf("healthCheck.tcpSocket: %w", err)
+		}
+	}
+	if hc := gd.Spec.HealthCheck; hc != nil && hc.A2S != nil {
+		if err := validatePortRef(hc.A2S.Port, gd.Spec.Ports); err != nil {
+			return fmt.Errorf("healthCheck.a2s: %w", err)
+		}
+	}
+
+	// Validate installDir can hold the server volume
+	installDir := path.Clean(gd.Spec.InstallDir)
+	if gd.Spec.InstallDir != "" {
+		if !path.IsAbs(installDir) || installDir == "/" {
+			return fmt.Errorf("installDir must be an absolute path below /")
+		}
+		if pathWithin(installDir, resources.GameCacheMountPath) {
+			return fmt.Errorf("installDir must not be within %s, where the game cache is mounted", resources.GameCacheMountPath)
+		}
+	}
+
+	// Validate saveDirs are absolute paths inside the container
+	for i, dir := range gd.Spec.SaveDirs {
+		if !path.IsAbs(dir) || path.Clean(dir) == "/" {
+			return fmt.Errorf("saveDirs[%d] must be an absolute path below /", i)
+		}
+		// A save directory mounted over the install directory would hide the game files
+		if gd.Spec.InstallDir != "" && pathWithin(installDir, path.Clean(dir)) {
+			return fmt.Errorf("saveDirs[%d] must not contain installDir %s", i, installDir)
+		}
+	}
+
+	// Validate the Workshop mod directory is on the server volume
+	if ws := gd.Spec.Workshop; ws != nil {
+		modDir := ws.ModDir
+		if !path.IsAbs(modDir) {
+			modDir = path.Join(resources.InstallDir(gd), modDir)
+		}
+		if !pathWithin(path.Clean(modDir), path.Clean(resources.InstallDir(gd))) {
+			return fmt.Errorf("workshop.modDir must be within installDir")
+		}
+		if ws.ModList != nil {
+			if _, err := template.New("modList").Parse(ws.ModList.Template); err != nil {
+				return fmt.Errorf("workshop.modList.template: %w", err)
+			}
+		}
+	}
+
 	// Validate configSchema entries
 	for key, entry := range gd.Spec.ConfigSchema {
 		if entry.MapTo != nil {
 			switch entry.MapTo.Type {
-			case "arg", "env", "configFile":
+			case boilerrv1alpha1.ConfigMappingConfigFile:
 				// valid
+			case boilerrv1alpha1.ConfigMappingArg, boilerrv1alpha1.ConfigMappingEnv:
+				if entry.MapTo.Value == "" {
+					return fmt.Errorf("configSchema[%s].mapTo.value is required for type '%s'", key, entry.MapTo.Type)
+				}
 			default:
 				return fmt.Errorf("configSchema[%s].mapTo.type must be 'arg', 'env', or 'configFile'", key)
 			}
@@ -111,6 +170,27 @@ func (r *GameDefinitionReconciler) validate(gd *boilerrv1alpha1.GameDefinition)
 	return nil
 }
 
+// pathWithin reports whether the clean absolute path p is dir or below it.
+func pathWithin(p, dir string) bool {
+	return p == dir || strings.HasPrefix(p, strings.TrimSuffix(dir, "/")+"/")
+}
+
+// validatePortRef checks that a named port matches one of the defined ports.
+func validatePortRef(port intstr.IntOrString, ports []boilerrv1alpha1.ServerPort) error {
+	if port.Type == intstr.Int {
+		if port.IntVal <= 0 || port.IntVal > 65535 {
+			return fmt.Errorf("port must be between 1 and 65535")
+		}
+		return nil
+	}
+	for _, p := range ports {
+		if p.Name == port.StrVal {
+			return nil
+		}
+	}
+	return fmt.Errorf("port %q does not match any port name", port.StrVal)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *GameDefinitionReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
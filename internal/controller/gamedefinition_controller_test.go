@@ -0,0 +1,67 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+)
+
+var _ = Describe("GameDefinition Helper Functions", func() {
+	r := &GameDefinitionReconciler{}
+
+	newGameDef := func(installDir string, saveDirs ...string) *boilerrv1alpha1.GameDefinition {
+		return &boilerrv1alpha1.GameDefinition{
+			Spec: boilerrv1alpha1.GameDefinitionSpec{
+				AppId:      896660,
+				InstallDir: installDir,
+				Command:    "./valheim_server.x86_64",
+				Ports:      []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+				SaveDirs:   saveDirs,
+			},
+		}
+	}
+
+	It("Should accept save directories beside or inside installDir", func() {
+		Expect(r.validate(newGameDef("/data/server", "/data/saves", "/data/server/worlds"))).To(Succeed())
+		Expect(r.validate(newGameDef("", "/data/saves"))).To(Succeed())
+	})
+
+	It("Should reject an installDir the server volume cannot be mounted at", func() {
+		Expect(r.validate(newGameDef("data/server"))).To(MatchError(ContainSubstring("installDir must be an absolute path")))
+		Expect(r.validate(newGameDef("/"))).To(MatchError(ContainSubstring("installDir must be an absolute path")))
+		Expect(r.validate(newGameDef("/gamecache/server"))).To(MatchError(ContainSubstring("game cache")))
+	})
+
+	It("Should reject a save directory hiding installDir", func() {
+		Expect(r.validate(newGameDef("/data/server", "/data"))).To(MatchError(ContainSubstring("saveDirs[0] must not contain installDir")))
+		Expect(r.validate(newGameDef("/data/server/", "/data/server"))).To(MatchError(ContainSubstring("saveDirs[0] must not contain installDir")))
+	})
+
+	It("Should reject a Workshop mod directory outside installDir", func() {
+		gd := newGameDef("/data/server")
+		gd.Spec.Workshop = &boilerrv1alpha1.WorkshopSpec{AppId: 892970, ModDir: "BepInEx/plugins"}
+		Expect(r.validate(gd)).To(Succeed())
+
+		gd.Spec.Workshop.ModDir = "../mods"
+		Expect(r.validate(gd)).To(MatchError("workshop.modDir must be within installDir"))
+		gd.Spec.Workshop.ModDir = "/mods"
+		Expect(r.validate(gd)).To(MatchError("workshop.modDir must be within installDir"))
+	})
+})
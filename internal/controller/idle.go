@@ -0,0 +1,54 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/resources"
+)
+
+// updateIdleSince starts or resets the idle timer in status from the latest server info.
+// The timer only runs while the server is Running with idleShutdown set and reports zero players.
+// Returns whether the status changed.
+func updateIdleSince(server *boilerrv1alpha1.SteamServer, info *boilerrv1alpha1.ServerInfoStatus, now metav1.Time) bool {
+	idle := server.Spec.IdleShutdown != nil &&
+		server.Status.State == boilerrv1alpha1.ServerStateRunning &&
+		info != nil && info.Players == 0
+
+	switch {
+	case idle && server.Status.IdleSince == nil:
+		server.Status.IdleSince = &now
+		return true
+	case !idle && server.Status.IdleSince != nil:
+		server.Status.IdleSince = nil
+		return true
+	default:
+		return false
+	}
+}
+
+// idleExpired returns whether the server has been idle for longer than idleShutdown.after.
+func idleExpired(server *boilerrv1alpha1.SteamServer, now time.Time) bool {
+	if server.Spec.IdleShutdown == nil || resources.Suspended(server) || server.Status.IdleSince == nil {
+		return false
+	}
+	return !now.Before(server.Status.IdleSince.Add(server.Spec.IdleShutdown.After.Duration))
+}
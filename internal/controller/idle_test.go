@@ -0,0 +1,105 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+)
+
+var _ = Describe("Idle Shutdown Helper Functions", func() {
+	now := metav1.NewTime(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	newServer := func() *boilerrv1alpha1.SteamServer {
+		return &boilerrv1alpha1.SteamServer{
+			Spec: boilerrv1alpha1.SteamServerSpec{
+				IdleShutdown: &boilerrv1alpha1.IdleShutdown{
+					After: metav1.Duration{Duration: 30 * time.Minute},
+				},
+			},
+			Status: boilerrv1alpha1.SteamServerStatus{
+				State: boilerrv1alpha1.ServerStateRunning,
+			},
+		}
+	}
+
+	Context("updateIdleSince", func() {
+		It("Should start the timer when the server is empty", func() {
+			server := newServer()
+
+			Expect(updateIdleSince(server, &boilerrv1alpha1.ServerInfoStatus{Players: 0}, now)).To(BeTrue())
+			Expect(server.Status.IdleSince).To(Equal(&now))
+
+			later := metav1.NewTime(now.Add(time.Minute))
+			Expect(updateIdleSince(server, &boilerrv1alpha1.ServerInfoStatus{Players: 0}, later)).To(BeFalse())
+			Expect(server.Status.IdleSince).To(Equal(&now))
+		})
+
+		It("Should reset the timer when a player connects", func() {
+			server := newServer()
+			server.Status.IdleSince = &now
+
+			Expect(updateIdleSince(server, &boilerrv1alpha1.ServerInfoStatus{Players: 1}, now)).To(BeTrue())
+			Expect(server.Status.IdleSince).To(BeNil())
+		})
+
+		It("Should not track servers without idleShutdown", func() {
+			server := newServer()
+			server.Spec.IdleShutdown = nil
+
+			Expect(updateIdleSince(server, &boilerrv1alpha1.ServerInfoStatus{Players: 0}, now)).To(BeFalse())
+			Expect(server.Status.IdleSince).To(BeNil())
+		})
+
+		It("Should not track servers without server info", func() {
+			Expect(updateIdleSince(newServer(), nil, now)).To(BeFalse())
+		})
+	})
+
+	Context("idleExpired", func() {
+		It("Should expire after idleShutdown.after", func() {
+			server := newServer()
+			server.Status.IdleSince = &now
+
+			Expect(idleExpired(server, now.Add(29*time.Minute))).To(BeFalse())
+			Expect(idleExpired(server, now.Add(30*time.Minute))).To(BeTrue())
+		})
+
+		It("Should not expire a suspended server", func() {
+			server := newServer()
+			server.Spec.Suspended = true
+			server.Status.IdleSince = &now
+
+			Expect(idleExpired(server, now.Add(time.Hour))).To(BeFalse())
+		})
+
+		It("Should expire a suspended server that was woken", func() {
+			server := newServer()
+			server.Spec.Suspended = true
+			server.Status.WokenAt = &now
+			server.Status.IdleSince = &now
+
+			Expect(idleExpired(server, now.Add(time.Hour))).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,188 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/resources"
+	"github.com/CraightonH/boilerr/internal/steamcmd"
+)
+
+// installLogLines is how many lines of SteamCMD output are read for the progress of a running install.
+const installLogLines int64 = 20
+
+// LogReader reads the output of containers.
+type LogReader interface {
+	// TailLogs returns the last lines of output of a container.
+	TailLogs(ctx context.Context, namespace, pod, container string, lines int64) (string, error)
+}
+
+// PodLogReader reads container output from the pod log API.
+type PodLogReader struct {
+	Clientset kubernetes.Interface
+}
+
+// TailLogs returns the last lines of output of a container.
+func (p *PodLogReader) TailLogs(ctx context.Context, namespace, pod, container string, lines int64) (string, error) {
+	data, err := p.Clientset.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{
+		Container: container,
+		TailLines: &lines,
+	}).DoRaw(ctx)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// determineInstall returns the progress of the running SteamCMD install of the server pod, or the
+// outcome of the last one. Returns false if there is nothing new to report, so status.install is kept:
+// the pod is gone, installs from a game cache, or has not printed any progress yet.
+func (r *SteamServerReconciler) determineInstall(ctx context.Context, server *boilerrv1alpha1.SteamServer) (*boilerrv1alpha1.InstallStatus, bool) {
+	pod := &corev1.Pod{}
+	if err := r.Get(ctx, client.ObjectKey{
+		Name:      fmt.Sprintf("%s-0", server.Name),
+		Namespace: server.Namespace,
+	}, pod); err != nil {
+		return nil, false
+	}
+
+	for _, cs := range pod.Status.InitContainerStatuses {
+		if cs.Name != resources.InitContainerName {
+			continue
+		}
+		switch {
+		case cs.State.Terminated != nil:
+			return installResult(cs.State.Terminated, server.Status.Install), true
+		case cs.State.Running != nil:
+			if r.Logs == nil {
+				return nil, false
+			}
+			output, err := r.Logs.TailLogs(ctx, pod.Namespace, pod.Name, cs.Name, installLogLines)
+			if err != nil {
+				return nil, false
+			}
+			return installProgress(output, server.Status.Install)
+		case cs.LastTerminationState.Terminated != nil:
+			// Waiting to restart after a failed install
+			return installResult(cs.LastTerminationState.Terminated, server.Status.Install), true
+		}
+	}
+	return nil, false
+}
+
+// installProgress returns the progress of a running install from the recent SteamCMD output.
+// The attempt is kept from the last status if its line is no longer in the output.
+// Returns false if the output has no progress yet.
+func installProgress(output string, last *boilerrv1alpha1.InstallStatus) (*boilerrv1alpha1.InstallStatus, bool) {
+	result := steamcmd.ParseOutput(output)
+	if result.UpdateState == "" {
+		return nil, false
+	}
+	attempt := result.Attempt
+	if attempt == 0 && last != nil {
+		attempt = last.Attempt
+	}
+	return &boilerrv1alpha1.InstallStatus{
+		Attempt:         attempt,
+		UpdateState:     result.UpdateState,
+		Progress:        result.Progress,
+		BytesDownloaded: result.BytesDownloaded,
+		BytesTotal:      result.BytesTotal,
+	}, true
+}
+
+// installResult returns the outcome of a finished install. A SteamCMD container that failed every
+// attempt reports the end of the last one's output as its termination message, which holds the error.
+// The attempt and download size of a successful install are kept from the last progress reported.
+func installResult(terminated *corev1.ContainerStateTerminated, last *boilerrv1alpha1.InstallStatus) *boilerrv1alpha1.InstallStatus {
+	if terminated.ExitCode == 0 {
+		install := &boilerrv1alpha1.InstallStatus{Progress: 100}
+		if last != nil {
+			install.Attempt = last.Attempt
+			install.BytesDownloaded = last.BytesTotal
+			install.BytesTotal = last.BytesTotal
+		}
+		return install
+	}
+
+	result := steamcmd.ParseOutput(terminated.Message)
+	install := &boilerrv1alpha1.InstallStatus{
+		Attempt:         result.Attempt,
+		UpdateState:     result.UpdateState,
+		Progress:        result.Progress,
+		BytesDownloaded: result.BytesDownloaded,
+		BytesTotal:      result.BytesTotal,
+		Reason:          result.Reason,
+		Message:         result.Error,
+	}
+	if install.Reason == "" {
+		install.Reason = steamcmd.ErrorReason
+		install.Message = fmt.Sprintf("SteamCMD exited with code %d", terminated.ExitCode)
+	}
+	return install
+}
+
+// installMessage returns the status message for the install progress or failure, or "" for the state message.
+func installMessage(state boilerrv1alpha1.ServerState, install *boilerrv1alpha1.InstallStatus) string {
+	if install == nil {
+		return ""
+	}
+	switch {
+	case state == boilerrv1alpha1.ServerStateError && install.Reason != "":
+		return fmt.Sprintf("SteamCMD install failed: %s", install.Message)
+	case state == boilerrv1alpha1.ServerStateInstalling && install.UpdateState != "":
+		return fmt.Sprintf("SteamCMD is downloading game files: %s %d%%", install.UpdateState, install.Progress)
+	}
+	return ""
+}
+
+// setInstallFailedCondition sets the InstallFailed condition from the outcome of an install:
+// true once SteamCMD failed every attempt, false once an install succeeds.
+// A running install leaves the condition of the last one.
+func setInstallFailedCondition(server *boilerrv1alpha1.SteamServer, install *boilerrv1alpha1.InstallStatus) {
+	condition := metav1.Condition{
+		Type:               boilerrv1alpha1.ConditionInstallFailed,
+		ObservedGeneration: server.Generation,
+	}
+	switch {
+	case install == nil:
+		return
+	case install.Reason != "":
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = install.Reason
+		condition.Message = fmt.Sprintf("SteamCMD failed after %d attempts: %s", max(install.Attempt, 1), install.Message)
+	case install.Progress == 100 && install.UpdateState == "":
+		if meta.FindStatusCondition(server.Status.Conditions, boilerrv1alpha1.ConditionInstallFailed) == nil {
+			return
+		}
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Installed"
+		condition.Message = "SteamCMD installed the app"
+	default:
+		return
+	}
+	meta.SetStatusCondition(&server.Status.Conditions, condition)
+}
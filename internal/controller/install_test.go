@@ -0,0 +1,110 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/resources"
+)
+
+var _ = Describe("Install Helper Functions", func() {
+	It("Should report the progress of a running install", func() {
+		install, ok := installProgress("SteamCMD attempt 2 of 4\n"+
+			" Update state (0x61) downloading, progress: 45.23 (452300000 / 1000000000)\n", nil)
+		Expect(ok).To(BeTrue())
+		Expect(install).To(Equal(&boilerrv1alpha1.InstallStatus{
+			Attempt: 2, UpdateState: "downloading", Progress: 45, BytesDownloaded: 452300000, BytesTotal: 1000000000,
+		}))
+
+		// The attempt line scrolled out of the recent output
+		install, ok = installProgress(" Update state (0x61) downloading, progress: 50.00 (500 / 1000)\n", install)
+		Expect(ok).To(BeTrue())
+		Expect(install.Attempt).To(Equal(int32(2)))
+
+		_, ok = installProgress("Logging in user 'anonymous' to Steam Public...OK\n", nil)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("Should report the SteamCMD error of a failed install", func() {
+		install := installResult(&corev1.ContainerStateTerminated{
+			ExitCode: 8,
+			Message:  "ERROR! Failed to install app '1829350' (No subscription)\n",
+		}, nil)
+		Expect(install.Reason).To(Equal("NoSubscription"))
+		Expect(install.Message).To(Equal("Failed to install app '1829350' (No subscription)"))
+		Expect(installMessage(boilerrv1alpha1.ServerStateError, install)).
+			To(Equal("SteamCMD install failed: Failed to install app '1829350' (No subscription)"))
+
+		install = installResult(&corev1.ContainerStateTerminated{ExitCode: 137}, nil)
+		Expect(install.Reason).To(Equal("SteamCMDError"))
+		Expect(install.Message).To(Equal("SteamCMD exited with code 137"))
+	})
+
+	It("Should keep the download size of a successful install", func() {
+		last := &boilerrv1alpha1.InstallStatus{UpdateState: "downloading", Progress: 99, BytesDownloaded: 990, BytesTotal: 1000}
+		install := installResult(&corev1.ContainerStateTerminated{ExitCode: 0}, last)
+		Expect(install).To(Equal(&boilerrv1alpha1.InstallStatus{Progress: 100, BytesDownloaded: 1000, BytesTotal: 1000}))
+		Expect(installMessage(boilerrv1alpha1.ServerStateRunning, install)).To(BeEmpty())
+	})
+
+	It("Should set InstallFailed once every attempt failed, and clear it after an install", func() {
+		server := &boilerrv1alpha1.SteamServer{}
+		setInstallFailedCondition(server, installResult(&corev1.ContainerStateTerminated{ExitCode: 0}, nil))
+		Expect(server.Status.Conditions).To(BeEmpty())
+
+		setInstallFailedCondition(server, installResult(&corev1.ContainerStateTerminated{
+			ExitCode: 8,
+			Message:  "SteamCMD attempt 4 of 4\nERROR! Failed to install app '896660' (Disk write failure)\n",
+		}, nil))
+		condition := meta.FindStatusCondition(server.Status.Conditions, boilerrv1alpha1.ConditionInstallFailed)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("DiskWriteFailure"))
+		Expect(condition.Message).To(HavePrefix("SteamCMD failed after 4 attempts"))
+
+		// A running install keeps the condition of the last one
+		setInstallFailedCondition(server, &boilerrv1alpha1.InstallStatus{Attempt: 1, UpdateState: "downloading", Progress: 10})
+		Expect(meta.IsStatusConditionTrue(server.Status.Conditions, boilerrv1alpha1.ConditionInstallFailed)).To(BeTrue())
+
+		setInstallFailedCondition(server, installResult(&corev1.ContainerStateTerminated{ExitCode: 0}, nil))
+		Expect(meta.IsStatusConditionFalse(server.Status.Conditions, boilerrv1alpha1.ConditionInstallFailed)).To(BeTrue())
+	})
+
+	It("Should report a pending pod with a failed install as an error", func() {
+		pod := &corev1.Pod{}
+		pod.Status.InitContainerStatuses = []corev1.ContainerStatus{{
+			Name:                 resources.InitContainerName,
+			State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 8}},
+		}}
+		state, ok := installState(pod)
+		Expect(ok).To(BeTrue())
+		Expect(state).To(Equal(boilerrv1alpha1.ServerStateError))
+
+		pod.Status.InitContainerStatuses[0].State = corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+		state, ok = installState(pod)
+		Expect(ok).To(BeTrue())
+		Expect(state).To(Equal(boilerrv1alpha1.ServerStateInstalling))
+	})
+})
@@ -0,0 +1,285 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/rcon"
+	"github.com/CraightonH/boilerr/internal/resources"
+	"github.com/CraightonH/boilerr/internal/schedule"
+)
+
+// RCONRunner runs RCON commands against a game server.
+type RCONRunner interface {
+	Run(ctx context.Context, addr, password string, commands []string) ([]string, error)
+}
+
+// serverSchedule is a parsed spec.schedule.
+type serverSchedule struct {
+	restarts []*schedule.Schedule
+	window   *schedule.Schedule
+	duration time.Duration
+	location *time.Location
+}
+
+// parseSchedule parses the cron expressions and time zone of spec.schedule.
+// Returns nil if the server has no schedule.
+func parseSchedule(spec *boilerrv1alpha1.ScheduleSpec) (*serverSchedule, error) {
+	if spec == nil {
+		return nil, nil
+	}
+
+	s := &serverSchedule{location: time.UTC}
+	if spec.TimeZone != "" {
+		loc, err := time.LoadLocation(spec.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("schedule.timeZone: %w", err)
+		}
+		s.location = loc
+	}
+
+	for i, expr := range spec.Restarts {
+		parsed, err := schedule.Parse(expr)
+		if err != nil {
+			return nil, fmt.Errorf("schedule.restarts[%d]: %w", i, err)
+		}
+		s.restarts = append(s.restarts, parsed)
+	}
+
+	if mw := spec.MaintenanceWindow; mw != nil {
+		parsed, err := schedule.Parse(mw.Start)
+		if err != nil {
+			return nil, fmt.Errorf("schedule.maintenanceWindow.start: %w", err)
+		}
+		if mw.Duration.Duration <= 0 {
+			return nil, fmt.Errorf("schedule.maintenanceWindow.duration must be positive")
+		}
+		s.window = parsed
+		s.duration = mw.Duration.Duration
+	}
+	return s, nil
+}
+
+// nextRestart returns the earliest scheduled restart after t, or the zero time if there is none.
+func (s *serverSchedule) nextRestart(t time.Time) time.Time {
+	var next time.Time
+	for _, r := range s.restarts {
+		if n := r.Next(t.In(s.location)); !n.IsZero() && (next.IsZero() || n.Before(next)) {
+			next = n
+		}
+	}
+	return next
+}
+
+// isRestart reports whether t is one of the scheduled restart times.
+func (s *serverSchedule) isRestart(t time.Time) bool {
+	for _, r := range s.restarts {
+		if r.Next(t.In(s.location).Add(-time.Minute)).Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// maintenanceWindow reports whether the maintenance window is open at now, and if not, when it next opens.
+// Without a maintenance window it is always open.
+func (s *serverSchedule) maintenanceWindow(now time.Time) (bool, time.Time) {
+	if s == nil || s.window == nil {
+		return true, time.Time{}
+	}
+	now = now.In(s.location)
+	// The window is open if it started within the last duration
+	if start := s.window.Next(now.Add(-s.duration)); !start.IsZero() && !start.After(now) {
+		return true, time.Time{}
+	}
+	return false, s.window.Next(now)
+}
+
+// planRestart decides whether a scheduled restart is due at now and when the next one is.
+// A restart is due once now reaches status.nextScheduledRestart. If the stored time is missing
+// or no longer matches the schedule, the next restart is computed from now without restarting.
+func planRestart(server *boilerrv1alpha1.SteamServer, sched *serverSchedule, now time.Time) (bool, time.Time) {
+	if sched == nil || len(sched.restarts) == 0 {
+		return false, time.Time{}
+	}
+
+	stored := server.Status.NextScheduledRestart
+	if stored == nil || !sched.isRestart(stored.Time) {
+		return false, sched.nextRestart(now)
+	}
+	if now.Before(stored.Time) {
+		return false, stored.Time
+	}
+	return true, sched.nextRestart(now)
+}
+
+// reconcileSchedule performs due scheduled restarts and records them in status.
+// A restart sets status.lastScheduledRestart, which the pod template records so the pod restarts.
+// The schedule.preRestart commands run when reconcileStatefulSet applies it, after any drain hold.
+// Returns when the next restart, or the opening of the maintenance window for a pending update, is due.
+func (r *SteamServerReconciler) reconcileSchedule(ctx context.Context, server *boilerrv1alpha1.SteamServer) (time.Duration, error) {
+	logger := log.FromContext(ctx)
+
+	sched, err := parseSchedule(server.Spec.Schedule)
+	if err != nil {
+		return 0, err
+	}
+
+	now := r.now()
+	due, next := planRestart(server, sched, now)
+	changed := false
+
+	// Restarting a stopped server would start it, so just move on to the next restart
+	if due && !resources.ScaledDown(server) {
+		logger.Info("Performing scheduled restart")
+		restarted := metav1.NewTime(now.Truncate(time.Second))
+		server.Status.LastScheduledRestart = &restarted
+		changed = true
+	}
+
+	var nextTime *metav1.Time
+	if !next.IsZero() {
+		nextTime = &metav1.Time{Time: next}
+	}
+	if !timesEqual(server.Status.NextScheduledRestart, nextTime) {
+		server.Status.NextScheduledRestart = nextTime
+		changed = true
+	}
+
+	if changed {
+		if err := r.Status().Update(ctx, server); err != nil {
+			return 0, err
+		}
+	}
+
+	var requeue time.Duration
+	if !next.IsZero() {
+		requeue = next.Sub(now)
+	}
+	// An update waiting for the maintenance window is applied when the window opens
+	if open, opens := sched.maintenanceWindow(now); !open && targetBuild(server, "", true) != "" && !opens.IsZero() {
+		requeue = shortestRequeue(requeue, opens.Sub(now))
+	}
+	return requeue, nil
+}
+
+// scheduledRestartPending reports whether applying the desired pod template performs a scheduled restart.
+func scheduledRestartPending(existing, desired *corev1.PodTemplateSpec) bool {
+	restarted := desired.Annotations[resources.RestartedAtAnnotation]
+	return restarted != "" && restarted != existing.Annotations[resources.RestartedAtAnnotation]
+}
+
+// runPreRestart sends the schedule.preRestart RCON commands to the server pod.
+// Does nothing unless the server is running with schedule.preRestart set.
+func (r *SteamServerReconciler) runPreRestart(ctx context.Context, server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) error {
+	if server.Status.State != boilerrv1alpha1.ServerStateRunning || server.Spec.Schedule == nil || server.Spec.Schedule.PreRestart == nil {
+		return nil
+	}
+	pre := server.Spec.Schedule.PreRestart
+	_, err := r.runRCON(ctx, server, gameDef, "schedule.preRestart", pre.Port, pre.PasswordSecretRef, pre.Commands)
+	return err
+}
+
+// runRCON runs RCON commands against the server pod, with the password from the referenced Secret.
+// field names the spec field configuring the commands in errors.
+func (r *SteamServerReconciler) runRCON(
+	ctx context.Context,
+	server *boilerrv1alpha1.SteamServer,
+	gameDef *boilerrv1alpha1.GameDefinition,
+	field string,
+	rconPort intstr.IntOrString,
+	passwordRef corev1.SecretKeySelector,
+	commands []string,
+) ([]string, error) {
+	port, ok := resources.ResolvePort(server, gameDef, rconPort)
+	if !ok {
+		return nil, fmt.Errorf("%s.port %q does not match any port", field, rconPort.String())
+	}
+
+	reader := r.APIReader
+	if reader == nil {
+		reader = r.Client
+	}
+	secret := &corev1.Secret{}
+	if err := reader.Get(ctx, client.ObjectKey{Name: passwordRef.Name, Namespace: server.Namespace}, secret); err != nil {
+		return nil, err
+	}
+	password, ok := secret.Data[passwordRef.Key]
+	if !ok {
+		return nil, fmt.Errorf("secret %s has no key %q", passwordRef.Name, passwordRef.Key)
+	}
+
+	pod := &corev1.Pod{}
+	if err := r.Get(ctx, client.ObjectKey{
+		Name:      fmt.Sprintf("%s-0", server.Name),
+		Namespace: server.Namespace,
+	}, pod); err != nil {
+		return nil, err
+	}
+	if pod.Status.PodIP == "" {
+		return nil, fmt.Errorf("pod %s has no IP", pod.Name)
+	}
+
+	runner := r.RCON
+	if runner == nil {
+		runner = rcon.NewClient(rcon.DefaultTimeout)
+	}
+	addr := net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(port)))
+	return runner.Run(ctx, addr, string(password), commands)
+}
+
+// maintenanceWindowOpen reports whether automatic updates may be applied now.
+// An invalid schedule keeps the window closed; reconcileSchedule reports the error.
+func maintenanceWindowOpen(server *boilerrv1alpha1.SteamServer, now time.Time) bool {
+	sched, err := parseSchedule(server.Spec.Schedule)
+	if err != nil {
+		return false
+	}
+	open, _ := sched.maintenanceWindow(now)
+	return open
+}
+
+// timesEqual compares optional times.
+func timesEqual(a, b *metav1.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(b)
+}
+
+// shortestRequeue returns the shortest positive duration, or 0 if there is none.
+func shortestRequeue(durations ...time.Duration) time.Duration {
+	var shortest time.Duration
+	for _, d := range durations {
+		if d > 0 && (shortest == 0 || d < shortest) {
+			shortest = d
+		}
+	}
+	return shortest
+}
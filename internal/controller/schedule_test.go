@@ -0,0 +1,180 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/resources"
+)
+
+var _ = Describe("Schedule Helper Functions", func() {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newServer := func(spec *boilerrv1alpha1.ScheduleSpec) *boilerrv1alpha1.SteamServer {
+		return &boilerrv1alpha1.SteamServer{
+			Spec: boilerrv1alpha1.SteamServerSpec{Schedule: spec},
+		}
+	}
+
+	Context("parseSchedule", func() {
+		It("Should return nil without a schedule", func() {
+			sched, err := parseSchedule(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sched).To(BeNil())
+		})
+
+		It("Should reject invalid expressions", func() {
+			_, err := parseSchedule(&boilerrv1alpha1.ScheduleSpec{Restarts: []string{"0 4 * *"}})
+			Expect(err).To(MatchError(ContainSubstring("schedule.restarts[0]")))
+		})
+
+		It("Should reject unknown time zones", func() {
+			_, err := parseSchedule(&boilerrv1alpha1.ScheduleSpec{TimeZone: "Mars/Olympus"})
+			Expect(err).To(MatchError(ContainSubstring("schedule.timeZone")))
+		})
+
+		It("Should require a positive maintenance window duration", func() {
+			_, err := parseSchedule(&boilerrv1alpha1.ScheduleSpec{
+				MaintenanceWindow: &boilerrv1alpha1.MaintenanceWindow{Start: "0 3 * * *"},
+			})
+			Expect(err).To(MatchError(ContainSubstring("duration")))
+		})
+	})
+
+	Context("planRestart", func() {
+		spec := &boilerrv1alpha1.ScheduleSpec{Restarts: []string{"0 4 * * *", "0 16 * * *"}}
+
+		It("Should schedule the next restart without restarting", func() {
+			server := newServer(spec)
+			sched, _ := parseSchedule(spec)
+
+			due, next := planRestart(server, sched, now)
+			Expect(due).To(BeFalse())
+			Expect(next).To(Equal(time.Date(2026, 1, 1, 16, 0, 0, 0, time.UTC)))
+		})
+
+		It("Should restart once the scheduled time passes", func() {
+			server := newServer(spec)
+			server.Status.NextScheduledRestart = &metav1.Time{Time: time.Date(2026, 1, 1, 16, 0, 0, 0, time.UTC)}
+			sched, _ := parseSchedule(spec)
+
+			due, next := planRestart(server, sched, now)
+			Expect(due).To(BeFalse())
+			Expect(next).To(Equal(server.Status.NextScheduledRestart.Time))
+
+			due, next = planRestart(server, sched, time.Date(2026, 1, 1, 16, 0, 30, 0, time.UTC))
+			Expect(due).To(BeTrue())
+			Expect(next).To(Equal(time.Date(2026, 1, 2, 4, 0, 0, 0, time.UTC)))
+		})
+
+		It("Should not restart for a time no longer in the schedule", func() {
+			server := newServer(spec)
+			server.Status.NextScheduledRestart = &metav1.Time{Time: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)}
+			sched, _ := parseSchedule(spec)
+
+			due, next := planRestart(server, sched, now)
+			Expect(due).To(BeFalse())
+			Expect(next).To(Equal(time.Date(2026, 1, 1, 16, 0, 0, 0, time.UTC)))
+		})
+
+		It("Should use the schedule time zone", func() {
+			tzSpec := &boilerrv1alpha1.ScheduleSpec{Restarts: []string{"0 4 * * *"}, TimeZone: "America/Denver"}
+			sched, err := parseSchedule(tzSpec)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, next := planRestart(newServer(tzSpec), sched, now)
+			Expect(next.UTC()).To(Equal(time.Date(2026, 1, 2, 11, 0, 0, 0, time.UTC)))
+		})
+	})
+
+	Context("maintenanceWindow", func() {
+		spec := &boilerrv1alpha1.ScheduleSpec{
+			MaintenanceWindow: &boilerrv1alpha1.MaintenanceWindow{
+				Start:    "0 3 * * *",
+				Duration: metav1.Duration{Duration: 2 * time.Hour},
+			},
+		}
+
+		It("Should always be open without a window", func() {
+			Expect(maintenanceWindowOpen(newServer(nil), now)).To(BeTrue())
+		})
+
+		It("Should be open during the window", func() {
+			server := newServer(spec)
+			Expect(maintenanceWindowOpen(server, time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC))).To(BeTrue())
+			Expect(maintenanceWindowOpen(server, time.Date(2026, 1, 1, 4, 59, 0, 0, time.UTC))).To(BeTrue())
+			Expect(maintenanceWindowOpen(server, time.Date(2026, 1, 1, 5, 0, 0, 0, time.UTC))).To(BeFalse())
+		})
+
+		It("Should report when the window opens", func() {
+			sched, _ := parseSchedule(spec)
+			open, opens := sched.maintenanceWindow(now)
+			Expect(open).To(BeFalse())
+			Expect(opens).To(Equal(time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)))
+		})
+
+		It("Should stay closed when the schedule is invalid", func() {
+			server := newServer(&boilerrv1alpha1.ScheduleSpec{
+				MaintenanceWindow: &boilerrv1alpha1.MaintenanceWindow{Start: "bad"},
+			})
+			Expect(maintenanceWindowOpen(server, now)).To(BeFalse())
+		})
+	})
+
+	Context("scheduledRestartPending", func() {
+		template := func(restarted string) *corev1.PodTemplateSpec {
+			t := &corev1.PodTemplateSpec{}
+			if restarted != "" {
+				t.Annotations = map[string]string{resources.RestartedAtAnnotation: restarted}
+			}
+			return t
+		}
+
+		It("Should detect a new scheduled restart", func() {
+			Expect(scheduledRestartPending(template(""), template("2026-01-01T04:00:00Z"))).To(BeTrue())
+			Expect(scheduledRestartPending(template("2026-01-01T04:00:00Z"), template("2026-01-01T16:00:00Z"))).To(BeTrue())
+		})
+
+		It("Should ignore template changes without a new restart", func() {
+			Expect(scheduledRestartPending(template("2026-01-01T04:00:00Z"), template("2026-01-01T04:00:00Z"))).To(BeFalse())
+			Expect(scheduledRestartPending(template(""), template(""))).To(BeFalse())
+		})
+	})
+
+	Context("shortestRequeue", func() {
+		It("Should ignore zero durations", func() {
+			Expect(shortestRequeue(0, time.Minute, 0, time.Hour)).To(Equal(time.Minute))
+			Expect(shortestRequeue(0, 0)).To(BeZero())
+		})
+	})
+
+	Context("now", func() {
+		It("Should use the injected clock", func() {
+			r := &SteamServerReconciler{Clock: clocktesting.NewFakePassiveClock(now)}
+			Expect(r.now()).To(Equal(now))
+		})
+	})
+})
@@ -0,0 +1,293 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/a2s"
+	"github.com/CraightonH/boilerr/internal/resources"
+)
+
+const (
+	// DefaultServerInfoInterval is the default time between server info polls.
+	DefaultServerInfoInterval = 30 * time.Second
+	// DefaultServerInfoWorkers is the default number of concurrent server info queries.
+	DefaultServerInfoWorkers = 10
+	// DefaultServerInfoTimeout is the default timeout for querying a single server.
+	DefaultServerInfoTimeout = 5 * time.Second
+)
+
+// A2SQuerier queries game servers over the Steam A2S protocol.
+type A2SQuerier interface {
+	QueryInfo(ctx context.Context, addr string) (*a2s.Info, error)
+	QueryPlayers(ctx context.Context, addr string) ([]a2s.Player, error)
+}
+
+// ServerInfoPoller periodically queries Starting and Running SteamServers over A2S and records the results in status.serverInfo.
+// Queries run on a bounded worker pool outside of Reconcile, so a slow or silent server never blocks reconciliation.
+type ServerInfoPoller struct {
+	client.Client
+
+	// A2S queries game servers. Defaults to an a2s.Client.
+	A2S A2SQuerier
+
+	// Interval between polls. Defaults to DefaultServerInfoInterval.
+	Interval time.Duration
+
+	// Workers is the number of servers queried concurrently. Defaults to DefaultServerInfoWorkers.
+	Workers int
+
+	// Timeout bounds the queries to a single server. Defaults to DefaultServerInfoTimeout.
+	Timeout time.Duration
+
+	// Recorder emits an Event when idleShutdown suspends a server. Optional.
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=boilerr.dev,resources=steamservers,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=boilerr.dev,resources=steamservers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// Start polls all SteamServers every Interval until ctx is cancelled.
+func (p *ServerInfoPoller) Start(ctx context.Context) error {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = DefaultServerInfoInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		p.pollAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection ensures only the leader writes server info.
+func (p *ServerInfoPoller) NeedLeaderElection() bool {
+	return true
+}
+
+// pollAll queries every SteamServer on the worker pool and waits for all queries to finish.
+func (p *ServerInfoPoller) pollAll(ctx context.Context) {
+	logger := log.FromContext(ctx).WithName("serverinfo")
+
+	var serverList boilerrv1alpha1.SteamServerList
+	if err := p.List(ctx, &serverList); err != nil {
+		logger.Error(err, "Failed to list SteamServers")
+		return
+	}
+
+	workers := p.Workers
+	if workers <= 0 {
+		workers = DefaultServerInfoWorkers
+	}
+
+	jobs := make(chan *boilerrv1alpha1.SteamServer)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for server := range jobs {
+				if err := p.poll(ctx, server); err != nil {
+					logger.V(1).Info("Failed to update server info",
+						"steamserver", client.ObjectKeyFromObject(server), "error", err.Error())
+				}
+			}
+		}()
+	}
+
+	for i := range serverList.Items {
+		select {
+		case jobs <- &serverList.Items[i]:
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// poll queries a single SteamServer and patches its status if the server info or idle timer changed.
+// Starting servers are queried too, since a reply is what marks a server with a controller-run
+// A2S health check Running. Servers that are neither, or don't reply, have their server info cleared.
+// Servers idle for longer than idleShutdown.after are suspended.
+func (p *ServerInfoPoller) poll(ctx context.Context, server *boilerrv1alpha1.SteamServer) error {
+	var info *boilerrv1alpha1.ServerInfoStatus
+	var queryErr error
+	if state := server.Status.State; state == boilerrv1alpha1.ServerStateRunning || state == boilerrv1alpha1.ServerStateStarting {
+		info, queryErr = p.query(ctx, server)
+	}
+
+	now := metav1.Now()
+	patch := client.MergeFrom(server.DeepCopy())
+	changed := updateIdleSince(server, info, now)
+	if !serverInfoEqual(server.Status.ServerInfo, info) {
+		if info != nil {
+			info.LastUpdated = &now
+		}
+		server.Status.ServerInfo = info
+		changed = true
+	}
+	if changed {
+		if err := p.Status().Patch(ctx, server, patch); err != nil {
+			return err
+		}
+	}
+
+	if idleExpired(server, now.Time) {
+		return p.suspendIdle(ctx, server)
+	}
+	return queryErr
+}
+
+// suspendIdle suspends a server that has been idle for longer than idleShutdown.after.
+// A server woken by wakeOnConnect is still suspended in its spec, so only its wake is cleared.
+func (p *ServerInfoPoller) suspendIdle(ctx context.Context, server *boilerrv1alpha1.SteamServer) error {
+	logger := log.FromContext(ctx).WithName("serverinfo")
+
+	patch := client.MergeFrom(server.DeepCopy())
+	if server.Spec.Suspended {
+		server.Status.WokenAt = nil
+		if err := p.Status().Patch(ctx, server, patch); err != nil {
+			return err
+		}
+	} else {
+		server.Spec.Suspended = true
+		if err := p.Patch(ctx, server, patch); err != nil {
+			return err
+		}
+	}
+
+	after := server.Spec.IdleShutdown.After.Duration
+	logger.Info("Suspended idle SteamServer", "steamserver", client.ObjectKeyFromObject(server), "idleFor", after)
+	if p.Recorder != nil {
+		p.Recorder.Eventf(server, corev1.EventTypeNormal, "IdleShutdown",
+			"Suspended after %s with no players connected", after)
+	}
+	return nil
+}
+
+// query sends A2S_INFO and A2S_PLAYER to the server pod.
+// Returns nil without error if the server has no A2S query port.
+func (p *ServerInfoPoller) query(ctx context.Context, server *boilerrv1alpha1.SteamServer) (*boilerrv1alpha1.ServerInfoStatus, error) {
+	var gameDef *boilerrv1alpha1.GameDefinition
+	if server.Spec.GameDefinition != "" {
+		gameDef = &boilerrv1alpha1.GameDefinition{}
+		if err := p.Get(ctx, client.ObjectKey{Name: server.Spec.GameDefinition}, gameDef); err != nil {
+			return nil, err
+		}
+	}
+
+	port, ok := resources.A2SQueryPort(server, gameDef)
+	if !ok {
+		return nil, nil
+	}
+
+	pod := &corev1.Pod{}
+	if err := p.Get(ctx, client.ObjectKey{
+		Name:      fmt.Sprintf("%s-0", server.Name),
+		Namespace: server.Namespace,
+	}, pod); err != nil {
+		return nil, err
+	}
+	if pod.Status.PodIP == "" {
+		return nil, fmt.Errorf("pod %s has no IP", pod.Name)
+	}
+
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = DefaultServerInfoTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	querier := p.A2S
+	if querier == nil {
+		querier = a2s.NewClient(timeout)
+	}
+
+	addr := net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(port)))
+	info, err := querier.QueryInfo(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Not every game answers A2S_PLAYER, so player names are best effort
+	players, err := querier.QueryPlayers(ctx, addr)
+	if err != nil {
+		players = nil
+	}
+
+	return buildServerInfoStatus(info, players), nil
+}
+
+// buildServerInfoStatus converts A2S replies to a ServerInfoStatus.
+// Players without a name are still connecting and are left out of PlayerNames.
+func buildServerInfoStatus(info *a2s.Info, players []a2s.Player) *boilerrv1alpha1.ServerInfoStatus {
+	status := &boilerrv1alpha1.ServerInfoStatus{
+		Name:       info.Name,
+		Map:        info.Map,
+		Players:    int32(info.Players),
+		MaxPlayers: int32(info.MaxPlayers),
+		VAC:        info.VAC,
+		Version:    info.Version,
+	}
+	for _, player := range players {
+		if player.Name != "" {
+			status.PlayerNames = append(status.PlayerNames, player.Name)
+		}
+	}
+	return status
+}
+
+// serverInfoEqual compares two ServerInfoStatus values, ignoring LastUpdated.
+func serverInfoEqual(a, b *boilerrv1alpha1.ServerInfoStatus) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Name != b.Name || a.Map != b.Map || a.Players != b.Players || a.MaxPlayers != b.MaxPlayers ||
+		a.VAC != b.VAC || a.Version != b.Version || len(a.PlayerNames) != len(b.PlayerNames) {
+		return false
+	}
+	for i := range a.PlayerNames {
+		if a.PlayerNames[i] != b.PlayerNames[i] {
+			return false
+		}
+	}
+	return true
+}
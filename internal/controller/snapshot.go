@@ -0,0 +1,273 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/resources"
+)
+
+// snapshotPollInterval is how often a VolumeSnapshot or a volume restore is re-checked while in progress.
+// VolumeSnapshots are an optional CRD, so they are polled rather than watched.
+const snapshotPollInterval = 5 * time.Second
+
+// Reasons for the PreUpdateSnapshot and RestoringSnapshot conditions.
+const (
+	snapshotReasonCreating  = "Creating"
+	snapshotReasonReady     = "Ready"
+	snapshotReasonStopping  = "Stopping"
+	snapshotReasonRestored  = "Restored"
+	snapshotReasonCancelled = "Cancelled"
+)
+
+// preUpdateSnapshot ensures a ready VolumeSnapshot of the server volume exists before the install
+// identified by installHash is applied, and prunes old snapshots once it is.
+// Returns whether the update may be applied.
+func (r *SteamServerReconciler) preUpdateSnapshot(ctx context.Context, server *boilerrv1alpha1.SteamServer, installHash string) (bool, error) {
+	if server.Spec.Snapshots == nil {
+		return true, nil
+	}
+
+	snapshots, err := r.listSnapshots(ctx, server)
+	if err != nil {
+		return false, err
+	}
+
+	// Only the newest snapshot can belong to this update; an older one predates later changes to the volume
+	var snapshot *unstructured.Unstructured
+	if n := len(snapshots); n > 0 && snapshots[n-1].GetAnnotations()[resources.InstallHashAnnotation] == installHash {
+		snapshot = &snapshots[n-1]
+	}
+
+	if snapshot == nil {
+		// Not owned by the server, so its rollback points outlive it; they are found by label
+		snapshot = resources.NewSnapshotBuilder(server, installHash, r.now()).Build()
+		log.FromContext(ctx).Info("Creating VolumeSnapshot before update", "name", snapshot.GetName())
+		if err := r.Create(ctx, snapshot); err != nil {
+			return false, err
+		}
+		return false, r.setSnapshotCondition(ctx, server, metav1.ConditionFalse, snapshotReasonCreating,
+			fmt.Sprintf("Waiting for VolumeSnapshot %s to be ready before updating", snapshot.GetName()))
+	}
+
+	ready, message := snapshotReady(snapshot)
+	if message != "" {
+		return false, fmt.Errorf("VolumeSnapshot %s failed: %s", snapshot.GetName(), message)
+	}
+	if !ready {
+		return false, r.setSnapshotCondition(ctx, server, metav1.ConditionFalse, snapshotReasonCreating,
+			fmt.Sprintf("Waiting for VolumeSnapshot %s to be ready before updating", snapshot.GetName()))
+	}
+
+	if err := r.pruneSnapshots(ctx, server, snapshots); err != nil {
+		return false, err
+	}
+	return true, r.setSnapshotCondition(ctx, server, metav1.ConditionTrue, snapshotReasonReady,
+		fmt.Sprintf("VolumeSnapshot %s is ready", snapshot.GetName()))
+}
+
+// listSnapshots returns the pre-update VolumeSnapshots of a server, oldest first.
+func (r *SteamServerReconciler) listSnapshots(ctx context.Context, server *boilerrv1alpha1.SteamServer) ([]unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(resources.VolumeSnapshotGVK.GroupVersion().WithKind(resources.VolumeSnapshotKind + "List"))
+	if err := r.List(ctx, list,
+		client.InNamespace(server.Namespace),
+		client.MatchingLabels(resources.SnapshotLabels(server)),
+	); err != nil {
+		return nil, snapshotAPIError(err)
+	}
+
+	snapshots := list.Items
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].GetCreationTimestamp().Time.Before(snapshots[j].GetCreationTimestamp().Time)
+	})
+	return snapshots, nil
+}
+
+// pruneSnapshots deletes the oldest pre-update snapshots beyond the retention count.
+// Snapshots named in a restoreFromSnapshot field are never deleted.
+func (r *SteamServerReconciler) pruneSnapshots(ctx context.Context, server *boilerrv1alpha1.SteamServer, snapshots []unstructured.Unstructured) error {
+	var keep []string
+	if server.Spec.Storage != nil {
+		keep = append(keep, server.Spec.Storage.RestoreFromSnapshot)
+	}
+	if server.Spec.SaveStorage != nil {
+		keep = append(keep, server.Spec.SaveStorage.RestoreFromSnapshot)
+	}
+
+	for _, snapshot := range snapshotsToPrune(snapshots, resources.SnapshotRetention(server), keep...) {
+		log.FromContext(ctx).Info("Pruning VolumeSnapshot", "name", snapshot.GetName())
+		if err := r.Delete(ctx, &snapshot); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshotsToPrune returns the snapshots beyond the newest retention, given snapshots oldest first.
+// keep names snapshots that are never pruned.
+func snapshotsToPrune(snapshots []unstructured.Unstructured, retention int, keep ...string) []unstructured.Unstructured {
+	if len(snapshots) <= retention {
+		return nil
+	}
+	var prune []unstructured.Unstructured
+	for _, snapshot := range snapshots[:len(snapshots)-retention] {
+		if !slices.Contains(keep, snapshot.GetName()) {
+			prune = append(prune, snapshot)
+		}
+	}
+	return prune
+}
+
+// snapshotReady returns whether a VolumeSnapshot is ready to use, or the error it failed with.
+func snapshotReady(snapshot *unstructured.Unstructured) (bool, string) {
+	if message, _, _ := unstructured.NestedString(snapshot.Object, "status", "error", "message"); message != "" {
+		return false, message
+	}
+	ready, _, _ := unstructured.NestedBool(snapshot.Object, "status", "readyToUse")
+	return ready, ""
+}
+
+// setSnapshotCondition records the state of the pre-update snapshot in the PreUpdateSnapshot condition.
+func (r *SteamServerReconciler) setSnapshotCondition(ctx context.Context, server *boilerrv1alpha1.SteamServer, status metav1.ConditionStatus, reason, message string) error {
+	changed := meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               boilerrv1alpha1.ConditionPreUpdateSnapshot,
+		Status:             status,
+		ObservedGeneration: server.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+	if !changed {
+		return nil
+	}
+	return r.Status().Update(ctx, server)
+}
+
+// snapshotRestorePending returns whether the desired PVC is restored from a VolumeSnapshot
+// the existing PVC was not created from.
+func snapshotRestorePending(existing, desired *corev1.PersistentVolumeClaim) bool {
+	source := desired.Spec.DataSource
+	if source == nil || source.Kind != resources.VolumeSnapshotKind {
+		return false
+	}
+	current := existing.Spec.DataSource
+	return current == nil || current.Kind != source.Kind || current.Name != source.Name
+}
+
+// restoreSnapshot recreates a server PVC from the VolumeSnapshot in its restoreFromSnapshot field.
+// It stops the server through the RestoringSnapshot condition, deletes the PVC once the pod is gone,
+// and leaves recreating the PVC from the snapshot to reconcilePVC.
+// Returns when to re-check the restore.
+func (r *SteamServerReconciler) restoreSnapshot(ctx context.Context, server *boilerrv1alpha1.SteamServer, pvc *corev1.PersistentVolumeClaim, name string) (time.Duration, error) {
+	logger := log.FromContext(ctx)
+
+	// Never delete the volume for a snapshot that can't be restored
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(resources.VolumeSnapshotGVK)
+	if err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: server.Namespace}, snapshot); err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, fmt.Errorf("VolumeSnapshot %q not found", name)
+		}
+		return 0, snapshotAPIError(err)
+	}
+	if ready, message := snapshotReady(snapshot); !ready {
+		if message == "" {
+			message = "not ready to use"
+		}
+		return 0, fmt.Errorf("VolumeSnapshot %q cannot be restored: %s", name, message)
+	}
+
+	if !meta.IsStatusConditionTrue(server.Status.Conditions, boilerrv1alpha1.ConditionRestoringSnapshot) {
+		logger.Info("Stopping server to restore VolumeSnapshot", "snapshot", name, "pvc", pvc.Name)
+		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               boilerrv1alpha1.ConditionRestoringSnapshot,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: server.Generation,
+			Reason:             snapshotReasonStopping,
+			Message:            fmt.Sprintf("Stopping the server to restore VolumeSnapshot %s into %s", name, pvc.Name),
+		})
+		return snapshotPollInterval, r.Status().Update(ctx, server)
+	}
+
+	pod := &corev1.Pod{}
+	err := r.Get(ctx, client.ObjectKey{Name: fmt.Sprintf("%s-0", server.Name), Namespace: server.Namespace}, pod)
+	if err == nil {
+		return snapshotPollInterval, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return 0, err
+	}
+
+	if pvc.DeletionTimestamp.IsZero() {
+		logger.Info("Deleting PVC to restore VolumeSnapshot", "name", pvc.Name, "snapshot", name)
+		if err := r.Delete(ctx, pvc); client.IgnoreNotFound(err) != nil {
+			return 0, err
+		}
+	}
+	return snapshotPollInterval, nil
+}
+
+// finishSnapshotRestore clears the RestoringSnapshot condition once the PVCs are recreated or the restore
+// was cancelled by clearing restoreFromSnapshot, which starts the server.
+func (r *SteamServerReconciler) finishSnapshotRestore(ctx context.Context, server *boilerrv1alpha1.SteamServer, pvcs []*corev1.PersistentVolumeClaim) error {
+	if !meta.IsStatusConditionTrue(server.Status.Conditions, boilerrv1alpha1.ConditionRestoringSnapshot) {
+		return nil
+	}
+
+	var restored []string
+	for _, pvc := range pvcs {
+		if source := pvc.Spec.DataSource; source != nil && source.Kind == resources.VolumeSnapshotKind {
+			restored = append(restored, fmt.Sprintf("%s from VolumeSnapshot %s", pvc.Name, source.Name))
+		}
+	}
+	reason, message := snapshotReasonCancelled, "restoreFromSnapshot was cleared"
+	if len(restored) > 0 {
+		reason, message = snapshotReasonRestored, "Restored "+strings.Join(restored, ", ")
+	}
+
+	meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               boilerrv1alpha1.ConditionRestoringSnapshot,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: server.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+	return r.Status().Update(ctx, server)
+}
+
+// snapshotAPIError explains a missing VolumeSnapshot API.
+func snapshotAPIError(err error) error {
+	if meta.IsNoMatchError(err) {
+		return fmt.Errorf("the VolumeSnapshot API is not available, install the CSI snapshot controller: %w", err)
+	}
+	return err
+}
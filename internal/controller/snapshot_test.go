@@ -0,0 +1,121 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/CraightonH/boilerr/internal/resources"
+)
+
+var _ = Describe("Snapshot Helper Functions", func() {
+	newSnapshot := func(name string, status map[string]any) unstructured.Unstructured {
+		snapshot := unstructured.Unstructured{Object: map[string]any{}}
+		snapshot.SetGroupVersionKind(resources.VolumeSnapshotGVK)
+		snapshot.SetName(name)
+		if status != nil {
+			snapshot.Object["status"] = status
+		}
+		return snapshot
+	}
+
+	names := func(snapshots []unstructured.Unstructured) []string {
+		var out []string
+		for _, s := range snapshots {
+			out = append(out, s.GetName())
+		}
+		return out
+	}
+
+	Context("snapshotsToPrune", func() {
+		snapshots := []unstructured.Unstructured{
+			newSnapshot("valheim-1", nil),
+			newSnapshot("valheim-2", nil),
+			newSnapshot("valheim-3", nil),
+			newSnapshot("valheim-4", nil),
+		}
+
+		It("should prune the oldest snapshots beyond the retention", func() {
+			Expect(names(snapshotsToPrune(snapshots, 2))).To(Equal([]string{"valheim-1", "valheim-2"}))
+		})
+
+		It("should prune nothing within the retention", func() {
+			Expect(snapshotsToPrune(snapshots, 4)).To(BeEmpty())
+		})
+
+		It("should keep the snapshots being restored", func() {
+			Expect(names(snapshotsToPrune(snapshots, 2, "valheim-1"))).To(Equal([]string{"valheim-2"}))
+			Expect(snapshotsToPrune(snapshots, 2, "valheim-1", "valheim-2")).To(BeEmpty())
+		})
+	})
+
+	Context("snapshotReady", func() {
+		It("should report a snapshot ready to use", func() {
+			snapshot := newSnapshot("valheim-1", map[string]any{"readyToUse": true})
+			ready, message := snapshotReady(&snapshot)
+			Expect(ready).To(BeTrue())
+			Expect(message).To(BeEmpty())
+		})
+
+		It("should report a snapshot without status as not ready", func() {
+			snapshot := newSnapshot("valheim-1", nil)
+			ready, _ := snapshotReady(&snapshot)
+			Expect(ready).To(BeFalse())
+		})
+
+		It("should return the snapshot error", func() {
+			snapshot := newSnapshot("valheim-1", map[string]any{
+				"readyToUse": false,
+				"error":      map[string]any{"message": "driver does not support snapshots"},
+			})
+			ready, message := snapshotReady(&snapshot)
+			Expect(ready).To(BeFalse())
+			Expect(message).To(Equal("driver does not support snapshots"))
+		})
+	})
+
+	Context("snapshotRestorePending", func() {
+		pvcFrom := func(name string) *corev1.PersistentVolumeClaim {
+			pvc := &corev1.PersistentVolumeClaim{}
+			if name != "" {
+				pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{Kind: resources.VolumeSnapshotKind, Name: name}
+			}
+			return pvc
+		}
+		desired := pvcFrom("valheim-2")
+
+		It("should restore a volume not created from a snapshot", func() {
+			Expect(snapshotRestorePending(pvcFrom(""), desired)).To(BeTrue())
+		})
+
+		It("should restore a volume created from another snapshot", func() {
+			Expect(snapshotRestorePending(pvcFrom("valheim-1"), desired)).To(BeTrue())
+		})
+
+		It("should not restore a volume already created from the snapshot", func() {
+			Expect(snapshotRestorePending(pvcFrom("valheim-2"), desired)).To(BeFalse())
+		})
+
+		It("should not restore without restoreFromSnapshot", func() {
+			Expect(snapshotRestorePending(pvcFrom(""), pvcFrom(""))).To(BeFalse())
+		})
+	})
+})
@@ -18,14 +18,21 @@ package controller
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -36,6 +43,7 @@ import (
 	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
 	"github.com/CraightonH/boilerr/internal/config"
 	"github.com/CraightonH/boilerr/internal/resources"
+	"github.com/CraightonH/boilerr/internal/steamcmd"
 )
 
 const (
@@ -47,6 +55,40 @@ const (
 type SteamServerReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// ProbeImage is the operator image providing the a2sprobe binary for A2S exec probes and the
+	// steamguard helper for Steam Guard logins. If empty, A2S health checks use the server info
+	// polled by the ServerInfoPoller instead, and steamGuard is rejected.
+	ProbeImage string
+
+	// Logs reads the SteamCMD output of running installs for status.install progress.
+	// If nil, only the outcome of finished installs is reported.
+	Logs LogReader
+
+	// WakeProxyImage is the operator image providing the wakeproxy binary for wakeOnConnect.
+	WakeProxyImage string
+
+	// WakeURL is the base URL of the operator's wake endpoint, reachable from game server namespaces.
+	// If it or WakeProxyImage is empty, wakeOnConnect has no effect.
+	WakeURL string
+
+	// APIReader reads objects the manager does not cache, such as RCON password Secrets.
+	// Defaults to the Client.
+	APIReader client.Reader
+
+	// RCON runs schedule.preRestart commands. Defaults to an rcon.Client.
+	RCON RCONRunner
+
+	// BackupImage is the operator image providing the backup binary for spec.backup.
+	// If empty, spec.backup has no effect.
+	BackupImage string
+
+	// GameCacheStorageClass is the StorageClass of the game cache volumes for spec.gameCache.
+	// It must support ReadOnlyMany. If empty, spec.gameCache is rejected.
+	GameCacheStorageClass string
+
+	// Clock provides the current time for schedules and drain deadlines. Defaults to the real clock.
+	Clock clock.PassiveClock
 }
 
 // +kubebuilder:rbac:groups=boilerr.dev,resources=steamservers,verbs=get;list;watch;create;update;patch;delete
@@ -54,10 +96,17 @@ type SteamServerReconciler struct {
 // +kubebuilder:rbac:groups=boilerr.dev,resources=steamservers/finalizers,verbs=update
 // +kubebuilder:rbac:groups=boilerr.dev,resources=gamedefinitions,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;create
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
 
 // Reconcile is the main reconciliation loop for SteamServer resources.
 func (r *SteamServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -111,25 +160,66 @@ func (r *SteamServerReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 	}
 
+	// Validate SteamCMD options
+	if err := r.validateSteamCMD(server); err != nil {
+		return r.setErrorStatus(ctx, server, "SteamCMD", err)
+	}
+
+	// Validate Workshop mods against the GameDefinition
+	if err := validateMods(server, gameDef); err != nil {
+		return r.setErrorStatus(ctx, server, "Mods", err)
+	}
+
 	// 7. Reconcile child resources
 	if err := r.reconcileConfigMap(ctx, server, gameDef); err != nil {
 		return r.setErrorStatus(ctx, server, "ConfigMap", err)
 	}
 
-	if err := r.reconcilePVC(ctx, server, gameDef); err != nil {
+	restoreRequeue, err := r.reconcilePVC(ctx, server, gameDef)
+	if err != nil {
 		return r.setErrorStatus(ctx, server, "PVC", err)
 	}
 
-	if err := r.reconcileStatefulSet(ctx, server, gameDef); err != nil {
+	scheduleRequeue, err := r.reconcileSchedule(ctx, server)
+	if err != nil {
+		return r.setErrorStatus(ctx, server, "Schedule", err)
+	}
+
+	drainRequeue, err := r.reconcileStatefulSet(ctx, server, gameDef)
+	if err != nil {
 		return r.setErrorStatus(ctx, server, "StatefulSet", err)
 	}
 
-	if err := r.reconcileService(ctx, server, gameDef); err != nil {
+	wakeProxy, err := r.reconcileWakeProxy(ctx, server, gameDef)
+	if err != nil {
+		return r.setErrorStatus(ctx, server, "WakeProxy", err)
+	}
+
+	if err := r.reconcileService(ctx, server, gameDef, wakeProxy); err != nil {
 		return r.setErrorStatus(ctx, server, "Service", err)
 	}
 
+	if err := r.reconcileBackup(ctx, server); err != nil {
+		return r.setErrorStatus(ctx, server, "Backup", err)
+	}
+
 	// 8. Update status based on actual state
-	return r.updateStatus(ctx, server)
+	result, err := r.updateStatus(ctx, server, gameDef)
+	if err != nil {
+		return result, err
+	}
+
+	// Re-check a held change when its drain wait expires, and come back for the next scheduled restart
+	result.RequeueAfter = shortestRequeue(result.RequeueAfter, drainRequeue, scheduleRequeue, restoreRequeue)
+	return result, nil
+}
+
+// now returns the current time from the reconciler clock.
+func (r *SteamServerReconciler) now() time.Time {
+	if r.Clock == nil {
+		return time.Now()
+	}
+	return r.Clock.Now()
 }
 
 // fetchGameDefinition fetches the GameDefinition referenced by the SteamServer.
@@ -152,6 +242,28 @@ func (r *SteamServerReconciler) fetchGameDefinition(ctx context.Context, server
 	return &gameDef, nil
 }
 
+// validateSteamCMD checks that the SteamCMD options of a SteamServer are consistent.
+func (r *SteamServerReconciler) validateSteamCMD(server *boilerrv1alpha1.SteamServer) error {
+	if server.Spec.BetaPasswordSecretRef != nil && server.Spec.Beta == "" {
+		return fmt.Errorf("betaPasswordSecretRef requires beta")
+	}
+	anonymous := server.Spec.Anonymous == nil || *server.Spec.Anonymous
+	if !anonymous && server.Spec.SteamCredentialsSecret == "" {
+		return fmt.Errorf("anonymous false requires steamCredentialsSecret")
+	}
+	if server.Spec.SteamGuard != nil {
+		switch {
+		case anonymous:
+			return fmt.Errorf("steamGuard requires anonymous false")
+		case server.Spec.GameCache:
+			return fmt.Errorf("steamGuard can't be combined with gameCache")
+		case r.ProbeImage == "":
+			return fmt.Errorf("steamGuard requires the operator to run with --probe-image")
+		}
+	}
+	return nil
+}
+
 // handleDeletion handles the deletion of a SteamServer resource.
 func (r *SteamServerReconciler) handleDeletion(ctx context.Context, server *boilerrv1alpha1.SteamServer) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
@@ -159,8 +271,20 @@ func (r *SteamServerReconciler) handleDeletion(ctx context.Context, server *boil
 	if controllerutil.ContainsFinalizer(server, FinalizerName) {
 		logger.Info("Running finalizer cleanup for SteamServer")
 
-		// Perform cleanup if needed
-		// Child resources with owner references will be garbage collected automatically
+		// Child resources with owner references will be garbage collected automatically,
+		// so release a retained save volume first
+		if err := r.retainSaveVolume(ctx, server); err != nil {
+			logger.Error(err, "Failed to retain save volume")
+			return ctrl.Result{}, err
+		}
+
+		// Delete the game caches only this server used
+		if r.GameCacheStorageClass != "" {
+			if err := pruneGameCaches(ctx, r.Client, server.Namespace, server, r.now()); err != nil {
+				logger.Error(err, "Failed to prune game caches")
+				return ctrl.Result{}, err
+			}
+		}
 
 		// Remove finalizer
 		controllerutil.RemoveFinalizer(server, FinalizerName)
@@ -173,30 +297,54 @@ func (r *SteamServerReconciler) handleDeletion(ctx context.Context, server *boil
 	return ctrl.Result{}, nil
 }
 
+// retainSaveVolume removes the SteamServer's owner reference from its save PVC unless
+// saveStorage.reclaimPolicy is Delete, so the save data outlives the SteamServer.
+func (r *SteamServerReconciler) retainSaveVolume(ctx context.Context, server *boilerrv1alpha1.SteamServer) error {
+	if server.Spec.SaveStorage == nil || server.Spec.SaveStorage.ReclaimPolicy == boilerrv1alpha1.SaveReclaimDelete {
+		return nil
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.Get(ctx, client.ObjectKey{Name: resources.SavePVCName(server.Name), Namespace: server.Namespace}, pvc); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(pvc, server) {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Retaining save volume", "name", pvc.Name)
+	if err := controllerutil.RemoveControllerReference(server, pvc, r.Scheme); err != nil {
+		return err
+	}
+	return r.Update(ctx, pvc)
+}
+
 // reconcileConfigMap ensures the ConfigMap exists if config files are specified.
-func (r *SteamServerReconciler) reconcileConfigMap(ctx context.Context, server *boilerrv1alpha1.SteamServer, _ *boilerrv1alpha1.GameDefinition) error {
+// Files come from GameDefinition.ConfigFiles, configSchema "configFile" mappings and SteamServer.ConfigFiles.
+func (r *SteamServerReconciler) reconcileConfigMap(ctx context.Context, server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) error {
 	logger := log.FromContext(ctx)
 
+	cmBuilder := resources.NewConfigMapBuilder(server, gameDef)
+	desiredCM, err := cmBuilder.Build()
+	if err != nil {
+		return err
+	}
+
 	// Skip if no config files
-	if len(server.Spec.ConfigFiles) == 0 {
+	if desiredCM == nil {
 		return nil
 	}
 
 	configMap := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      resources.ConfigMapName(server.Name),
-			Namespace: server.Namespace,
+			Name:      desiredCM.Name,
+			Namespace: desiredCM.Namespace,
 		},
 	}
 
 	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
 		configMap.Labels = commonLabels(server.Name, server.Spec.GameDefinition)
-		configMap.Data = make(map[string]string)
-
-		for i, cf := range server.Spec.ConfigFiles {
-			key := fmt.Sprintf("config-%d", i)
-			configMap.Data[key] = cf.Content
-		}
+		configMap.Data = desiredCM.Data
 
 		return controllerutil.SetControllerReference(server, configMap, r.Scheme)
 	})
@@ -212,17 +360,53 @@ func (r *SteamServerReconciler) reconcileConfigMap(ctx context.Context, server *
 	return nil
 }
 
-// reconcilePVC ensures the PVC exists for the SteamServer.
-func (r *SteamServerReconciler) reconcilePVC(ctx context.Context, server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) error {
-	logger := log.FromContext(ctx)
+// reconcilePVC ensures the server PVC, and the save PVC with saveStorage, exist for the SteamServer
+// and expands them when their size grows. When a restoreFromSnapshot field names a new snapshot,
+// its PVC is recreated from it; the returned duration is when the restore should be re-checked.
+func (r *SteamServerReconciler) reconcilePVC(ctx context.Context, server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) (time.Duration, error) {
+	if server.Spec.SaveStorage != nil && (gameDef == nil || len(gameDef.Spec.SaveDirs) == 0) {
+		return 0, fmt.Errorf("saveStorage requires GameDefinition %q to declare saveDirs", server.Spec.GameDefinition)
+	}
 
 	pvcBuilder := resources.NewPVCBuilder(server, gameDef)
-	desiredPVC := pvcBuilder.Build()
+	var desired, existing []*corev1.PersistentVolumeClaim
+	var requeue time.Duration
+	restoring := false
+	for _, desiredPVC := range []*corev1.PersistentVolumeClaim{pvcBuilder.Build(), pvcBuilder.BuildSaves()} {
+		if desiredPVC == nil {
+			// No storage configured
+			continue
+		}
+		desired = append(desired, desiredPVC)
 
-	if desiredPVC == nil {
-		// No storage configured
-		return nil
+		pvc, restoreRequeue, err := r.reconcileVolume(ctx, server, desiredPVC)
+		if err != nil {
+			return 0, err
+		}
+		if pvc == nil {
+			restoring = true
+			requeue = shortestRequeue(requeue, restoreRequeue)
+			continue
+		}
+		existing = append(existing, pvc)
+	}
+
+	if restoring {
+		return requeue, nil
 	}
+	if err := r.finishSnapshotRestore(ctx, server, desired); err != nil {
+		return 0, err
+	}
+	if setStorageConditions(&server.Status.Conditions, existing, server.Generation) {
+		return 0, r.Status().Update(ctx, server)
+	}
+	return 0, nil
+}
+
+// reconcileVolume ensures a server PVC exists and is up to date, and returns it.
+// Returns a nil PVC while it is being recreated from a VolumeSnapshot, with when to re-check.
+func (r *SteamServerReconciler) reconcileVolume(ctx context.Context, server *boilerrv1alpha1.SteamServer, desiredPVC *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, time.Duration, error) {
+	logger := log.FromContext(ctx)
 
 	existingPVC := &corev1.PersistentVolumeClaim{}
 	err := r.Get(ctx, client.ObjectKey{
@@ -233,63 +417,180 @@ func (r *SteamServerReconciler) reconcilePVC(ctx context.Context, server *boiler
 	if apierrors.IsNotFound(err) {
 		// Create new PVC
 		if err := controllerutil.SetControllerReference(server, desiredPVC, r.Scheme); err != nil {
-			return err
+			return nil, 0, err
 		}
 
 		logger.Info("Creating PVC", "name", desiredPVC.Name)
-		return r.Create(ctx, desiredPVC)
+		return desiredPVC, 0, r.Create(ctx, desiredPVC)
 	} else if err != nil {
-		return err
+		return nil, 0, err
+	}
+
+	if snapshotRestorePending(existingPVC, desiredPVC) {
+		requeue, err := r.restoreSnapshot(ctx, server, existingPVC, desiredPVC.Spec.DataSource.Name)
+		return nil, requeue, err
 	}
 
-	// PVC exists - PVCs are immutable for most fields, so we only update labels
+	// Adopt a save volume retained from a deleted SteamServer of the same name
+	if metav1.GetControllerOf(existingPVC) == nil {
+		if err := controllerutil.SetControllerReference(server, existingPVC, r.Scheme); err != nil {
+			return nil, 0, err
+		}
+		logger.Info("Adopting PVC", "name", existingPVC.Name)
+		if err := r.Update(ctx, existingPVC); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if err := r.resizeVolume(ctx, existingPVC, desiredPVC); err != nil {
+		return nil, 0, err
+	}
+
+	// Apart from their size, PVCs are immutable for most fields, so we only update labels
 	if !hasLabels(existingPVC.Labels, desiredPVC.Labels) {
 		existingPVC.Labels = desiredPVC.Labels
 		logger.Info("Updating PVC labels", "name", existingPVC.Name)
-		return r.Update(ctx, existingPVC)
+		return existingPVC, 0, r.Update(ctx, existingPVC)
 	}
 
-	return nil
+	return existingPVC, 0, nil
 }
 
 // reconcileStatefulSet ensures the StatefulSet exists and is up to date.
-func (r *SteamServerReconciler) reconcileStatefulSet(ctx context.Context, server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) error {
+// With updateStrategy.drain, changes that restart the pod are held until players leave, while
+// replica changes are still applied; the returned duration is when a held change should be re-checked.
+func (r *SteamServerReconciler) reconcileStatefulSet(ctx context.Context, server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) (time.Duration, error) {
 	logger := log.FromContext(ctx)
 
-	stsBuilder := resources.NewStatefulSetBuilder(server, gameDef)
+	existingSTS := &appsv1.StatefulSet{}
+	err := r.Get(ctx, client.ObjectKey{
+		Name:      server.Name,
+		Namespace: server.Namespace,
+	}, existingSTS)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return 0, err
+	}
+
+	currentBuild := existingSTS.Spec.Template.Annotations[resources.TargetBuildAnnotation]
+	build := targetBuild(server, currentBuild, maintenanceWindowOpen(server, r.now()))
+
+	// Install from the game cache of the build once it is populated
+	var gameCache string
+	var cacheRequeue time.Duration
+	if server.Spec.GameCache {
+		var cacheErr error
+		build, gameCache, cacheRequeue, cacheErr = r.selectGameCache(ctx, server, gameDef, build, &existingSTS.Spec.Template)
+		if cacheErr != nil {
+			return 0, cacheErr
+		}
+		if gameCache == "" && apierrors.IsNotFound(err) {
+			logger.Info("Holding StatefulSet creation until the game cache is populated")
+			return cacheRequeue, nil
+		}
+	} else if err := r.clearGameCacheStatus(ctx, server); err != nil {
+		return 0, err
+	}
+
+	stsBuilder := resources.NewStatefulSetBuilder(server, gameDef).
+		WithProbeImage(r.ProbeImage).
+		WithTargetBuild(build).
+		WithGameCache(gameCache)
 	desiredSTS := stsBuilder.Build()
+	desiredHash := resources.PodTemplateHash(&desiredSTS.Spec.Template)
+	desiredSTS.Annotations = map[string]string{resources.PodTemplateHashAnnotation: desiredHash}
 
 	// Set owner reference before create/update
 	if err := controllerutil.SetControllerReference(server, desiredSTS, r.Scheme); err != nil {
-		return err
+		return 0, err
 	}
 
-	existingSTS := &appsv1.StatefulSet{}
-	err := r.Get(ctx, client.ObjectKey{
-		Name:      desiredSTS.Name,
-		Namespace: desiredSTS.Namespace,
-	}, existingSTS)
-
 	if apierrors.IsNotFound(err) {
 		logger.Info("Creating StatefulSet", "name", desiredSTS.Name)
-		return r.Create(ctx, desiredSTS)
+		return cacheRequeue, r.Create(ctx, desiredSTS)
 	} else if err != nil {
-		return err
+		return 0, err
+	}
+
+	// A changed pod template restarts the pod, so hold it while players are connected
+	if appliedHash, ok := existingSTS.Annotations[resources.PodTemplateHashAnnotation]; ok && appliedHash != desiredHash {
+		now := r.now()
+		count := r.drainPlayerCount(ctx, server, gameDef)
+		hold, reason, deadline := drainDecision(server, count, now)
+		if hold {
+			logger.Info("Holding StatefulSet update until players leave", "deadline", deadline)
+			players, _ := count()
+			if err := r.setPendingUpdate(ctx, server, players, deadline); err != nil {
+				return 0, err
+			}
+			// Scaling down to suspend the server or restore a backup doesn't wait for the held template
+			if !ptr.Equal(existingSTS.Spec.Replicas, desiredSTS.Spec.Replicas) {
+				logger.Info("Scaling StatefulSet with its update held", "replicas", ptr.Deref(desiredSTS.Spec.Replicas, 1))
+				existingSTS.Spec.Replicas = desiredSTS.Spec.Replicas
+				if err := r.Update(ctx, existingSTS); err != nil {
+					return 0, err
+				}
+			}
+			return shortestRequeue(deadline.Sub(now), drainPollInterval, cacheRequeue), nil
+		}
+		if err := r.clearPendingUpdate(ctx, server, reason); err != nil {
+			return 0, err
+		}
+	}
+
+	// A change to what SteamCMD installs waits for a snapshot of the volume to roll back to
+	if desiredInstall := resources.InstallHash(&desiredSTS.Spec.Template); desiredInstall != resources.InstallHash(&existingSTS.Spec.Template) {
+		ready, err := r.preUpdateSnapshot(ctx, server, desiredInstall)
+		if err != nil {
+			return 0, err
+		}
+		if !ready {
+			logger.Info("Holding StatefulSet update until the pre-update snapshot is ready")
+			return shortestRequeue(snapshotPollInterval, cacheRequeue), nil
+		}
+	}
+
+	if target := desiredSTS.Spec.Template.Annotations[resources.TargetBuildAnnotation]; target != currentBuild {
+		logger.Info("Restarting to install new Steam build", "installed", server.Status.AppBuildId, "target", target)
+	}
+
+	// Warn players only once the scheduled restart is no longer held
+	if scheduledRestartPending(&existingSTS.Spec.Template, &desiredSTS.Spec.Template) {
+		if err := r.runPreRestart(ctx, server, gameDef); err != nil {
+			logger.Error(err, "Pre-restart RCON commands failed, restarting anyway")
+		}
 	}
 
 	// Update the StatefulSet spec
 	existingSTS.Spec = desiredSTS.Spec
 	existingSTS.Labels = desiredSTS.Labels
+	if existingSTS.Annotations == nil {
+		existingSTS.Annotations = map[string]string{}
+	}
+	existingSTS.Annotations[resources.PodTemplateHashAnnotation] = desiredHash
 
 	logger.Info("Updating StatefulSet", "name", existingSTS.Name)
-	return r.Update(ctx, existingSTS)
+	return cacheRequeue, r.Update(ctx, existingSTS)
+}
+
+// targetBuild returns the Steam build the pod template should target, given the current target.
+// Under the Automatic update policy, a published build newer than the installed one becomes the target,
+// which restarts the pod so SteamCMD installs it. Otherwise, or while the maintenance window is closed,
+// the current target is kept.
+func targetBuild(server *boilerrv1alpha1.SteamServer, current string, windowOpen bool) string {
+	status := server.Status
+	if windowOpen && server.Spec.UpdatePolicy == boilerrv1alpha1.UpdatePolicyAutomatic &&
+		status.AppBuildId != "" && status.LatestBuildId != "" && status.LatestBuildId != status.AppBuildId {
+		return status.LatestBuildId
+	}
+	return current
 }
 
 // reconcileService ensures the Service exists and is up to date.
-func (r *SteamServerReconciler) reconcileService(ctx context.Context, server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) error {
+// While wakeProxy is set, the Service routes to the wake proxy instead of the game server pod.
+func (r *SteamServerReconciler) reconcileService(ctx context.Context, server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition, wakeProxy bool) error {
 	logger := log.FromContext(ctx)
 
-	svcBuilder := resources.NewServiceBuilder(server, gameDef)
+	svcBuilder := resources.NewServiceBuilder(server, gameDef).WithWakeProxy(wakeProxy)
 	desiredSVC := svcBuilder.Build()
 
 	existingSVC := &corev1.Service{}
@@ -320,8 +621,88 @@ func (r *SteamServerReconciler) reconcileService(ctx context.Context, server *bo
 	return r.Update(ctx, existingSVC)
 }
 
+// reconcileWakeProxy creates the wake proxy Deployment while the server sleeps, and deletes it otherwise.
+// Returns whether the wake proxy should receive the server's traffic.
+func (r *SteamServerReconciler) reconcileWakeProxy(ctx context.Context, server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	builder := resources.NewWakeProxyBuilder(server, gameDef).
+		WithImage(r.WakeProxyImage).
+		WithWakeURL(WakeURL(r.WakeURL, server))
+	desired := builder.Build()
+
+	existing := &appsv1.Deployment{}
+	err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, err
+	}
+	found := err == nil
+
+	if !r.wakeProxyActive(server) {
+		if !found {
+			return false, nil
+		}
+		logger.Info("Deleting wake proxy", "name", existing.Name)
+		return false, client.IgnoreNotFound(r.Delete(ctx, existing))
+	}
+
+	if err := r.ensureWakeToken(ctx, server, builder); err != nil {
+		return false, err
+	}
+
+	if !found {
+		if err := controllerutil.SetControllerReference(server, desired, r.Scheme); err != nil {
+			return false, err
+		}
+		logger.Info("Creating wake proxy", "name", desired.Name)
+		return true, r.Create(ctx, desired)
+	}
+
+	existing.Spec = desired.Spec
+	existing.Labels = desired.Labels
+	return true, r.Update(ctx, existing)
+}
+
+// ensureWakeToken creates the Secret holding the wake proxy's token if it doesn't exist.
+// The Secret is kept for the life of the server, so the token only changes if it is deleted.
+func (r *SteamServerReconciler) ensureWakeToken(ctx context.Context, server *boilerrv1alpha1.SteamServer, builder *resources.WakeProxyBuilder) error {
+	reader := r.APIReader
+	if reader == nil {
+		reader = r.Client
+	}
+	key := client.ObjectKey{Namespace: server.Namespace, Name: resources.WakeTokenSecretName(server.Name)}
+	err := reader.Get(ctx, key, &corev1.Secret{})
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return err
+	}
+	secret := builder.BuildTokenSecret(hex.EncodeToString(token))
+	if err := controllerutil.SetControllerReference(server, secret, r.Scheme); err != nil {
+		return err
+	}
+	log.FromContext(ctx).Info("Creating wake token Secret", "name", secret.Name)
+	if err := r.Create(ctx, secret); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// wakeProxyActive returns whether the wake proxy should stand in for the server.
+// It keeps answering after a wake request until the woken server is Running.
+func (r *SteamServerReconciler) wakeProxyActive(server *boilerrv1alpha1.SteamServer) bool {
+	if !server.Spec.WakeOnConnect || r.WakeProxyImage == "" || r.WakeURL == "" {
+		return false
+	}
+	return resources.Suspended(server) ||
+		meta.IsStatusConditionTrue(server.Status.Conditions, boilerrv1alpha1.ConditionWakeRequested)
+}
+
 // updateStatus updates the SteamServer status based on the actual cluster state.
-func (r *SteamServerReconciler) updateStatus(ctx context.Context, server *boilerrv1alpha1.SteamServer) (ctrl.Result, error) {
+func (r *SteamServerReconciler) updateStatus(ctx context.Context, server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
 	// Get the StatefulSet to determine server state
@@ -340,21 +721,81 @@ func (r *SteamServerReconciler) updateStatus(ctx context.Context, server *boiler
 
 	// Determine state
 	newState := r.determineState(ctx, server, sts, stsErr)
+	// Without an A2S exec probe, a server is only Running once the poller got an A2S reply
+	if r.controllerA2SCheck(server, gameDef) && newState == boilerrv1alpha1.ServerStateRunning && server.Status.ServerInfo == nil {
+		newState = boilerrv1alpha1.ServerStateStarting
+	}
 	newAddress := r.determineAddress(svc, svcErr)
 	newPorts := r.determinePorts(svc, svcErr)
+	buildID, installedAt := r.determineBuild(ctx, server)
+	mods, modsReported := r.determineMods(ctx, server)
+	if len(resources.EnabledMods(server)) == 0 {
+		mods, modsReported = nil, true
+	}
+	install, installReported := r.determineInstall(ctx, server)
 	now := metav1.Now()
 
+	// A build ID is only reported by a completed install, so keep the last known one otherwise
+	buildChanged := buildID != "" && (buildID != server.Status.AppBuildId ||
+		meta.FindStatusCondition(server.Status.Conditions, boilerrv1alpha1.ConditionInstalled) == nil)
+	modsChanged := modsReported && !equality.Semantic.DeepEqual(mods, server.Status.Mods)
+	installedMods := server.Status.Mods
+	if modsChanged {
+		installedMods = mods
+	}
+	pinsChanged := setModVersionCondition(server, installedMods)
+	installChanged := installReported && !equality.Semantic.DeepEqual(install, server.Status.Install)
+
+	// A woken server hands its ports back from the wake proxy once it is Running
+	wakeDone := newState == boilerrv1alpha1.ServerStateRunning &&
+		meta.IsStatusConditionTrue(server.Status.Conditions, boilerrv1alpha1.ConditionWakeRequested)
+	// A wake only overrides spec.suspended, so it ends once that is cleared
+	wakeCleared := server.Status.WokenAt != nil && !server.Spec.Suspended
+
 	// Check if status needs update
-	statusChanged := server.Status.State != newState ||
+	oldState := server.Status.State
+	statusChanged := oldState != newState ||
 		server.Status.Address != newAddress ||
-		!portsEqual(server.Status.Ports, newPorts)
+		!portsEqual(server.Status.Ports, newPorts) ||
+		buildChanged || modsChanged || pinsChanged || installChanged || wakeDone || wakeCleared
 
 	if statusChanged {
 		server.Status.State = newState
 		server.Status.Address = newAddress
 		server.Status.Ports = newPorts
 		server.Status.LastUpdated = &now
+		if installChanged {
+			server.Status.Install = install
+			setInstallFailedCondition(server, install)
+		}
 		server.Status.Message = r.stateMessage(newState)
+		if message := installMessage(newState, server.Status.Install); message != "" {
+			server.Status.Message = message
+		}
+		if newState == boilerrv1alpha1.ServerStateStopped && oldState != boilerrv1alpha1.ServerStateStopped {
+			server.Status.LastStoppedAt = &now
+		}
+		if wakeCleared {
+			server.Status.WokenAt = nil
+		}
+		if wakeDone {
+			meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+				Type:               boilerrv1alpha1.ConditionWakeRequested,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: server.Generation,
+				Reason:             "ServerRunning",
+				Message:            "Server started after a player connected",
+			})
+		}
+		if modsChanged {
+			server.Status.Mods = mods
+		}
+		if buildChanged {
+			setInstalledCondition(server, buildID, installedAt)
+			if server.Status.LatestBuildId != "" {
+				setUpdateStatus(server, server.Status.LatestBuildId)
+			}
+		}
 
 		logger.Info("Updating SteamServer status",
 			"state", newState,
@@ -368,13 +809,23 @@ func (r *SteamServerReconciler) updateStatus(ctx context.Context, server *boiler
 	}
 
 	// Requeue if not yet running to check for state changes
-	if newState != boilerrv1alpha1.ServerStateRunning {
+	if newState != boilerrv1alpha1.ServerStateRunning && newState != boilerrv1alpha1.ServerStateStopped {
 		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 	}
 
 	return ctrl.Result{}, nil
 }
 
+// controllerA2SCheck returns whether the A2S health check of a server uses status.serverInfo.
+// That is the case when the GameDefinition uses an A2S health check but no probe image is configured.
+func (r *SteamServerReconciler) controllerA2SCheck(server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) bool {
+	if r.ProbeImage != "" {
+		return false
+	}
+	_, ok := resources.A2SHealthCheckPort(server, gameDef)
+	return ok
+}
+
 // determineState determines the current state of the server based on the StatefulSet.
 func (r *SteamServerReconciler) determineState(ctx context.Context, server *boilerrv1alpha1.SteamServer, sts *appsv1.StatefulSet, stsErr error) boilerrv1alpha1.ServerState {
 	if stsErr != nil {
@@ -384,6 +835,11 @@ func (r *SteamServerReconciler) determineState(ctx context.Context, server *boil
 		return boilerrv1alpha1.ServerStateError
 	}
 
+	// A suspended server is stopped once its pod is gone
+	if resources.ScaledDown(server) && sts.Status.Replicas == 0 {
+		return boilerrv1alpha1.ServerStateStopped
+	}
+
 	// Check StatefulSet replica status
 	if sts.Status.ReadyReplicas == 0 && sts.Status.Replicas == 0 {
 		return boilerrv1alpha1.ServerStatePending
@@ -407,18 +863,14 @@ func (r *SteamServerReconciler) determineState(ctx context.Context, server *boil
 	// Check pod phase
 	switch pod.Status.Phase {
 	case corev1.PodPending:
+		// Init containers run while the pod is pending
+		if state, ok := installState(pod); ok {
+			return state
+		}
 		return boilerrv1alpha1.ServerStatePending
 	case corev1.PodRunning:
-		// Check init containers
-		for _, cs := range pod.Status.InitContainerStatuses {
-			if cs.Name == resources.InitContainerName {
-				if cs.State.Running != nil {
-					return boilerrv1alpha1.ServerStateInstalling
-				}
-				if cs.State.Terminated != nil && cs.State.Terminated.ExitCode != 0 {
-					return boilerrv1alpha1.ServerStateError
-				}
-			}
+		if state, ok := installState(pod); ok {
+			return state
 		}
 		// Check main container
 		for _, cs := range pod.Status.ContainerStatuses {
@@ -439,6 +891,70 @@ func (r *SteamServerReconciler) determineState(ctx context.Context, server *boil
 	}
 }
 
+// installState returns the state of a pod whose install init containers are running or have failed.
+// A failed install waiting to be restarted is an error too.
+func installState(pod *corev1.Pod) (boilerrv1alpha1.ServerState, bool) {
+	for _, cs := range pod.Status.InitContainerStatuses {
+		if cs.Name != resources.InitContainerName && cs.Name != resources.GameCacheContainerName &&
+			cs.Name != resources.WorkshopContainerName {
+			continue
+		}
+		if cs.State.Running != nil {
+			return boilerrv1alpha1.ServerStateInstalling, true
+		}
+		if cs.State.Terminated != nil && cs.State.Terminated.ExitCode != 0 {
+			return boilerrv1alpha1.ServerStateError, true
+		}
+		if cs.State.Waiting != nil && cs.LastTerminationState.Terminated != nil &&
+			cs.LastTerminationState.Terminated.ExitCode != 0 {
+			return boilerrv1alpha1.ServerStateError, true
+		}
+	}
+	return "", false
+}
+
+// determineBuild returns the installed build ID and install time reported by the build info init container.
+// Returns an empty build ID if the pod has not completed an install with a readable app manifest.
+func (r *SteamServerReconciler) determineBuild(ctx context.Context, server *boilerrv1alpha1.SteamServer) (string, metav1.Time) {
+	pod := &corev1.Pod{}
+	if err := r.Get(ctx, client.ObjectKey{
+		Name:      fmt.Sprintf("%s-0", server.Name),
+		Namespace: server.Namespace,
+	}, pod); err != nil {
+		return "", metav1.Time{}
+	}
+
+	for _, cs := range pod.Status.InitContainerStatuses {
+		if cs.Name != resources.BuildInfoContainerName {
+			continue
+		}
+		terminated := cs.State.Terminated
+		if terminated == nil || terminated.ExitCode != 0 {
+			return "", metav1.Time{}
+		}
+		if buildID, ok := steamcmd.ParseBuildID(terminated.Message); ok {
+			return buildID, terminated.FinishedAt
+		}
+	}
+	return "", metav1.Time{}
+}
+
+// setInstalledCondition records the installed build ID and sets the Installed condition to the install time.
+func setInstalledCondition(server *boilerrv1alpha1.SteamServer, buildID string, installedAt metav1.Time) {
+	server.Status.AppBuildId = buildID
+
+	// Remove first so the transition time moves to the new install even though the status stays True
+	meta.RemoveStatusCondition(&server.Status.Conditions, boilerrv1alpha1.ConditionInstalled)
+	meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               boilerrv1alpha1.ConditionInstalled,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: server.Generation,
+		LastTransitionTime: installedAt,
+		Reason:             "BuildInstalled",
+		Message:            fmt.Sprintf("Installed build %s", buildID),
+	})
+}
+
 // determineAddress determines the external address from the Service.
 func (r *SteamServerReconciler) determineAddress(svc *corev1.Service, svcErr error) string {
 	if svcErr != nil {
@@ -518,6 +1034,8 @@ func (r *SteamServerReconciler) stateMessage(state boilerrv1alpha1.ServerState)
 		return "Game server is running"
 	case boilerrv1alpha1.ServerStateError:
 		return "An error occurred"
+	case boilerrv1alpha1.ServerStateStopped:
+		return "Game server is stopped"
 	default:
 		return ""
 	}
@@ -548,9 +1066,15 @@ func (r *SteamServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&boilerrv1alpha1.SteamServer{}).
 		Owns(&appsv1.StatefulSet{}).
+		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.PersistentVolumeClaim{}).
 		Owns(&corev1.ConfigMap{}).
+		Owns(&batchv1.CronJob{}).
+		Watches(
+			&batchv1.Job{},
+			handler.EnqueueRequestsFromMapFunc(r.findSteamServerForBackupJob),
+		).
 		Watches(
 			&boilerrv1alpha1.GameDefinition{},
 			handler.EnqueueRequestsFromMapFunc(r.findSteamServersForGameDef),
@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/a2s"
 	"github.com/CraightonH/boilerr/internal/resources"
 )
 
@@ -523,4 +524,105 @@ var _ = Describe("Controller Helper Functions", func() {
 			Expect(portsEqual(a, b)).To(BeTrue())
 		})
 	})
+
+	Context("buildServerInfoStatus", func() {
+		It("Should map A2S replies and skip unnamed players", func() {
+			info := &a2s.Info{Name: "Vikings", Map: "Midgard", Players: 2, MaxPlayers: 10, VAC: true, Version: "0.218.21"}
+			players := []a2s.Player{{Name: "Ragnar"}, {Name: ""}}
+
+			status := buildServerInfoStatus(info, players)
+			Expect(status.Name).To(Equal("Vikings"))
+			Expect(status.Map).To(Equal("Midgard"))
+			Expect(status.Players).To(Equal(int32(2)))
+			Expect(status.MaxPlayers).To(Equal(int32(10)))
+			Expect(status.VAC).To(BeTrue())
+			Expect(status.Version).To(Equal("0.218.21"))
+			Expect(status.PlayerNames).To(Equal([]string{"Ragnar"}))
+		})
+	})
+
+	Context("serverInfoEqual", func() {
+		It("Should ignore LastUpdated", func() {
+			now := metav1.Now()
+			a := &boilerrv1alpha1.ServerInfoStatus{Name: "Vikings", Players: 1, PlayerNames: []string{"Ragnar"}}
+			b := a.DeepCopy()
+			b.LastUpdated = &now
+			Expect(serverInfoEqual(a, b)).To(BeTrue())
+		})
+
+		It("Should detect player changes", func() {
+			a := &boilerrv1alpha1.ServerInfoStatus{Players: 1, PlayerNames: []string{"Ragnar"}}
+			b := &boilerrv1alpha1.ServerInfoStatus{Players: 1, PlayerNames: []string{"Lagertha"}}
+			Expect(serverInfoEqual(a, b)).To(BeFalse())
+		})
+
+		It("Should handle nil", func() {
+			Expect(serverInfoEqual(nil, nil)).To(BeTrue())
+			Expect(serverInfoEqual(nil, &boilerrv1alpha1.ServerInfoStatus{})).To(BeFalse())
+		})
+	})
+
+	Context("validateSteamCMD", func() {
+		r := &SteamServerReconciler{ProbeImage: "ghcr.io/craightonh/boilerr:latest"}
+		authenticated := false
+
+		It("Should require a beta branch for a beta password", func() {
+			server := &boilerrv1alpha1.SteamServer{}
+			server.Spec.BetaPasswordSecretRef = &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "beta"},
+				Key:                  "password",
+			}
+			Expect(r.validateSteamCMD(server)).To(MatchError("betaPasswordSecretRef requires beta"))
+
+			server.Spec.Beta = "private"
+			Expect(r.validateSteamCMD(server)).To(Succeed())
+		})
+
+		It("Should require credentials for an authenticated login", func() {
+			server := &boilerrv1alpha1.SteamServer{}
+			server.Spec.Anonymous = &authenticated
+			Expect(r.validateSteamCMD(server)).To(MatchError("anonymous false requires steamCredentialsSecret"))
+
+			server.Spec.SteamCredentialsSecret = "steam-account"
+			Expect(r.validateSteamCMD(server)).To(Succeed())
+		})
+
+		It("Should require an authenticated login and the probe image for Steam Guard", func() {
+			server := &boilerrv1alpha1.SteamServer{}
+			server.Spec.SteamGuard = &boilerrv1alpha1.SteamGuardSpec{
+				SharedSecretRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "steam-account"},
+					Key:                  "shared_secret",
+				},
+			}
+			Expect(r.validateSteamCMD(server)).To(MatchError("steamGuard requires anonymous false"))
+
+			server.Spec.Anonymous = &authenticated
+			server.Spec.SteamCredentialsSecret = "steam-account"
+			Expect((&SteamServerReconciler{}).validateSteamCMD(server)).
+				To(MatchError(ContainSubstring("--probe-image")))
+			Expect(r.validateSteamCMD(server)).To(Succeed())
+
+			server.Spec.GameCache = true
+			Expect(r.validateSteamCMD(server)).To(MatchError("steamGuard can't be combined with gameCache"))
+		})
+	})
+
+	Context("setInstalledCondition", func() {
+		It("Should record the build ID and move the install time on a new build", func() {
+			server := &boilerrv1alpha1.SteamServer{}
+			first := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+			second := metav1.NewTime(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+
+			setInstalledCondition(server, "100", first)
+			Expect(server.Status.AppBuildId).To(Equal("100"))
+
+			setInstalledCondition(server, "200", second)
+			Expect(server.Status.AppBuildId).To(Equal("200"))
+			Expect(server.Status.Conditions).To(HaveLen(1))
+			Expect(server.Status.Conditions[0].Type).To(Equal(boilerrv1alpha1.ConditionInstalled))
+			Expect(server.Status.Conditions[0].Status).To(Equal(metav1.ConditionTrue))
+			Expect(server.Status.Conditions[0].LastTransitionTime.Equal(&second)).To(BeTrue())
+		})
+	})
 })
@@ -0,0 +1,304 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/backup"
+	"github.com/CraightonH/boilerr/internal/resources"
+)
+
+const (
+	// RestoreFinalizerName is the finalizer that releases the restore lock when a SteamServerRestore is deleted.
+	RestoreFinalizerName = "boilerr.dev/restore-finalizer"
+
+	// restorePollInterval is how often a restore re-checks a locked or stopping server.
+	restorePollInterval = 5 * time.Second
+)
+
+// SteamServerRestoreReconciler reconciles a SteamServerRestore object.
+// A restore locks its SteamServer with RestoreLockAnnotation, which scales the server to zero,
+// runs a Job that extracts the archive into the server volume, and then releases the lock.
+type SteamServerRestoreReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Image is the operator image providing the backup binary that performs the restore.
+	Image string
+}
+
+// +kubebuilder:rbac:groups=boilerr.dev,resources=steamserverrestores,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=boilerr.dev,resources=steamserverrestores/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=boilerr.dev,resources=steamserverrestores/finalizers,verbs=update
+// +kubebuilder:rbac:groups=boilerr.dev,resources=steamservers,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+
+// Reconcile moves a SteamServerRestore through its phases:
+// Pending (lock the server), Suspending (wait for the pod to stop), Restoring (run the Job),
+// Resuming (release the lock) and Completed, or Failed.
+func (r *SteamServerRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	restore := &boilerrv1alpha1.SteamServerRestore{}
+	if err := r.Get(ctx, req.NamespacedName, restore); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !restore.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(restore, RestoreFinalizerName) {
+			if err := r.releaseLock(ctx, restore); err != nil {
+				return ctrl.Result{}, err
+			}
+			controllerutil.RemoveFinalizer(restore, RestoreFinalizerName)
+			return ctrl.Result{}, r.Update(ctx, restore)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	switch restore.Status.Phase {
+	case boilerrv1alpha1.RestorePhaseCompleted, boilerrv1alpha1.RestorePhaseFailed:
+		// Finished restores are kept as a record; make sure the server is not left locked
+		return ctrl.Result{}, r.releaseLock(ctx, restore)
+	}
+
+	if !controllerutil.ContainsFinalizer(restore, RestoreFinalizerName) {
+		controllerutil.AddFinalizer(restore, RestoreFinalizerName)
+		if err := r.Update(ctx, restore); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	if restore.Status.StartTime == nil {
+		now := metav1.Now()
+		restore.Status.StartTime = &now
+		restore.Status.Phase = boilerrv1alpha1.RestorePhasePending
+	}
+
+	if r.Image == "" {
+		return r.fail(ctx, restore, fmt.Errorf("restores require the operator to be run with --backup-image"))
+	}
+
+	server := &boilerrv1alpha1.SteamServer{}
+	if err := r.Get(ctx, client.ObjectKey{Name: restore.Spec.ServerName, Namespace: restore.Namespace}, server); err != nil {
+		if apierrors.IsNotFound(err) {
+			return r.fail(ctx, restore, fmt.Errorf("SteamServer %q not found", restore.Spec.ServerName))
+		}
+		return ctrl.Result{}, err
+	}
+
+	switch restore.Status.Phase {
+	case boilerrv1alpha1.RestorePhasePending:
+		return r.lock(ctx, restore, server)
+	case boilerrv1alpha1.RestorePhaseSuspending:
+		return r.waitForStop(ctx, restore, server)
+	case boilerrv1alpha1.RestorePhaseRestoring:
+		return r.runJob(ctx, restore, server)
+	case boilerrv1alpha1.RestorePhaseResuming:
+		if err := r.releaseLock(ctx, restore); err != nil {
+			return ctrl.Result{}, err
+		}
+		now := metav1.Now()
+		restore.Status.Phase = boilerrv1alpha1.RestorePhaseCompleted
+		restore.Status.CompletionTime = &now
+		return ctrl.Result{}, r.Status().Update(ctx, restore)
+	}
+	return ctrl.Result{}, nil
+}
+
+// lock takes the server's restore lock, waiting while another restore holds it.
+func (r *SteamServerRestoreReconciler) lock(ctx context.Context, restore *boilerrv1alpha1.SteamServerRestore, server *boilerrv1alpha1.SteamServer) (ctrl.Result, error) {
+	holder := server.Annotations[boilerrv1alpha1.RestoreLockAnnotation]
+	if holder != "" && holder != restore.Name {
+		return r.progress(ctx, restore, fmt.Sprintf("Waiting for SteamServerRestore %q to finish", holder))
+	}
+
+	if holder == "" {
+		log.FromContext(ctx).Info("Locking SteamServer for restore", "server", server.Name)
+		if server.Annotations == nil {
+			server.Annotations = map[string]string{}
+		}
+		server.Annotations[boilerrv1alpha1.RestoreLockAnnotation] = restore.Name
+		if err := r.Update(ctx, server); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	restore.Status.Phase = boilerrv1alpha1.RestorePhaseSuspending
+	return r.progress(ctx, restore, "Waiting for the server to stop")
+}
+
+// waitForStop waits for the locked server's pod to be gone, so the Job can mount its volume.
+func (r *SteamServerRestoreReconciler) waitForStop(ctx context.Context, restore *boilerrv1alpha1.SteamServerRestore, server *boilerrv1alpha1.SteamServer) (ctrl.Result, error) {
+	pod := &corev1.Pod{}
+	err := r.Get(ctx, client.ObjectKey{Name: fmt.Sprintf("%s-0", server.Name), Namespace: server.Namespace}, pod)
+	if err == nil {
+		return r.progress(ctx, restore, "Waiting for the server to stop")
+	}
+	if !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+
+	restore.Status.Phase = boilerrv1alpha1.RestorePhaseRestoring
+	return r.runJob(ctx, restore, server)
+}
+
+// runJob creates the restore Job, which extracts into the server's save volume, and records its outcome.
+func (r *SteamServerRestoreReconciler) runJob(ctx context.Context, restore *boilerrv1alpha1.SteamServerRestore, server *boilerrv1alpha1.SteamServer) (ctrl.Result, error) {
+	desired, err := resources.NewRestoreJobBuilder(restore).
+		WithImage(r.Image).
+		WithClaimName(resources.SaveClaimName(server)).
+		Build()
+	if err != nil {
+		return r.fail(ctx, restore, err)
+	}
+	restore.Status.Job = desired.Name
+
+	job := &batchv1.Job{}
+	err = r.Get(ctx, client.ObjectKeyFromObject(desired), job)
+	if apierrors.IsNotFound(err) {
+		if err := controllerutil.SetControllerReference(restore, desired, r.Scheme); err != nil {
+			return ctrl.Result{}, err
+		}
+		log.FromContext(ctx).Info("Creating restore Job", "name", desired.Name)
+		if err := r.Create(ctx, desired); err != nil {
+			return ctrl.Result{}, err
+		}
+		return r.progress(ctx, restore, "Extracting archive")
+	} else if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !jobFinished(job) {
+		return r.progress(ctx, restore, "Extracting archive")
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods,
+		client.InNamespace(job.Namespace),
+		client.MatchingLabels{batchv1.JobNameLabel: job.Name},
+	); err != nil {
+		return ctrl.Result{}, err
+	}
+	result, err := restoreJobResult(job, pods.Items)
+	if err != nil {
+		return r.fail(ctx, restore, err)
+	}
+
+	restore.Status.RestoredFiles = int64(result.Files)
+	restore.Status.RestoredBytes = result.Size
+	restore.Status.Phase = boilerrv1alpha1.RestorePhaseResuming
+	restore.Status.Progress = fmt.Sprintf("Restored %d files (%d bytes), starting the server", result.Files, result.Size)
+	if err := r.Status().Update(ctx, restore); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// restoreJobResult returns the result of a finished restore Job from its termination message,
+// or the error it failed with.
+func restoreJobResult(job *batchv1.Job, pods []corev1.Pod) (*backup.RestoreResult, error) {
+	var message string
+	for _, pod := range pods {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Name == resources.RestoreContainerName && cs.State.Terminated != nil && cs.State.Terminated.Message != "" {
+				message = cs.State.Terminated.Message
+			}
+		}
+	}
+
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
+			if message == "" {
+				message = c.Message
+			}
+			return nil, fmt.Errorf("restore Job failed: %s", message)
+		}
+	}
+
+	result := &backup.RestoreResult{}
+	if message != "" {
+		if err := json.Unmarshal([]byte(message), result); err != nil {
+			return nil, fmt.Errorf("reading restore Job result: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// progress records the current step and re-checks shortly.
+func (r *SteamServerRestoreReconciler) progress(ctx context.Context, restore *boilerrv1alpha1.SteamServerRestore, progress string) (ctrl.Result, error) {
+	restore.Status.Progress = progress
+	if err := r.Status().Update(ctx, restore); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: restorePollInterval}, nil
+}
+
+// fail marks the restore Failed and releases the server.
+func (r *SteamServerRestoreReconciler) fail(ctx context.Context, restore *boilerrv1alpha1.SteamServerRestore, err error) (ctrl.Result, error) {
+	log.FromContext(ctx).Error(err, "Restore failed")
+	if releaseErr := r.releaseLock(ctx, restore); releaseErr != nil {
+		return ctrl.Result{}, releaseErr
+	}
+
+	now := metav1.Now()
+	restore.Status.Phase = boilerrv1alpha1.RestorePhaseFailed
+	restore.Status.Message = err.Error()
+	restore.Status.Progress = ""
+	restore.Status.CompletionTime = &now
+	// Don't requeue - the restore must be recreated to retry
+	return ctrl.Result{}, r.Status().Update(ctx, restore)
+}
+
+// releaseLock removes the server's restore lock if this restore holds it, which lets the server start.
+func (r *SteamServerRestoreReconciler) releaseLock(ctx context.Context, restore *boilerrv1alpha1.SteamServerRestore) error {
+	server := &boilerrv1alpha1.SteamServer{}
+	if err := r.Get(ctx, client.ObjectKey{Name: restore.Spec.ServerName, Namespace: restore.Namespace}, server); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if server.Annotations[boilerrv1alpha1.RestoreLockAnnotation] != restore.Name {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Releasing SteamServer restore lock", "server", server.Name)
+	patch := client.MergeFrom(server.DeepCopy())
+	delete(server.Annotations, boilerrv1alpha1.RestoreLockAnnotation)
+	return r.Patch(ctx, server, patch)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SteamServerRestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&boilerrv1alpha1.SteamServerRestore{}).
+		Owns(&batchv1.Job{}).
+		Named("steamserverrestore").
+		Complete(r)
+}
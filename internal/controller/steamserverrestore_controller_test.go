@@ -0,0 +1,78 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/CraightonH/boilerr/internal/resources"
+)
+
+var _ = Describe("Restore Helper Functions", func() {
+	newJob := func(condition batchv1.JobConditionType) *batchv1.Job {
+		return &batchv1.Job{
+			Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{{
+					Type:    condition,
+					Status:  corev1.ConditionTrue,
+					Message: "Job has reached the specified backoff limit",
+				}},
+			},
+		}
+	}
+
+	newPod := func(message string) corev1.Pod {
+		return corev1.Pod{
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: resources.RestoreContainerName,
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Message: message},
+					},
+				}},
+			},
+		}
+	}
+
+	Context("restoreJobResult", func() {
+		It("should read the restored files and size from the termination message", func() {
+			result, err := restoreJobResult(newJob(batchv1.JobComplete), []corev1.Pod{newPod(`{"files":42,"size":1048576}`)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Files).To(Equal(42))
+			Expect(result.Size).To(Equal(int64(1048576)))
+		})
+
+		It("should return the termination message of a failed Job", func() {
+			_, err := restoreJobResult(newJob(batchv1.JobFailed), []corev1.Pod{newPod("opening archive: not found")})
+			Expect(err).To(MatchError(ContainSubstring("opening archive: not found")))
+		})
+
+		It("should fall back to the Job condition when the pod is gone", func() {
+			_, err := restoreJobResult(newJob(batchv1.JobFailed), nil)
+			Expect(err).To(MatchError(ContainSubstring("backoff limit")))
+		})
+
+		It("should reject an unreadable result", func() {
+			_, err := restoreJobResult(newJob(batchv1.JobComplete), []corev1.Pod{newPod("not json")})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
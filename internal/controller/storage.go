@@ -0,0 +1,155 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+)
+
+// Reasons for the StorageResizing and FileSystemResizePending conditions.
+const (
+	storageReasonExpanding = "Expanding"
+	storageReasonPending   = "FileSystemResizePending"
+	storageReasonResized   = "Resized"
+)
+
+// resizeVolume expands an existing PVC when its desired size grows.
+// Shrinking a volume or changing its StorageClass is an error, since a PVC can do neither.
+func (r *SteamServerReconciler) resizeVolume(ctx context.Context, existing, desired *corev1.PersistentVolumeClaim) error {
+	expand, err := checkStorageChange(existing, desired)
+	if err != nil || !expand {
+		return err
+	}
+	return r.expandPVC(ctx, existing, desired.Spec.Resources.Requests[corev1.ResourceStorage])
+}
+
+// checkStorageChange compares the existing PVC with the desired one.
+// Returns whether the PVC must be expanded, or an error for a change it can't make.
+func checkStorageChange(existing, desired *corev1.PersistentVolumeClaim) (bool, error) {
+	if desired.Spec.StorageClassName != nil && existing.Spec.StorageClassName != nil &&
+		*desired.Spec.StorageClassName != *existing.Spec.StorageClassName {
+		return false, fmt.Errorf("the StorageClass of PVC %s cannot be changed from %q to %q",
+			existing.Name, *existing.Spec.StorageClassName, *desired.Spec.StorageClassName)
+	}
+
+	current := existing.Spec.Resources.Requests[corev1.ResourceStorage]
+	size := desired.Spec.Resources.Requests[corev1.ResourceStorage]
+	switch size.Cmp(current) {
+	case -1:
+		return false, fmt.Errorf("the size of PVC %s cannot be reduced from %s to %s, volumes can only grow",
+			existing.Name, current.String(), size.String())
+	case 1:
+		return true, nil
+	}
+	return false, nil
+}
+
+// expandPVC requests a larger size for a PVC whose StorageClass allows volume expansion.
+func (r *SteamServerReconciler) expandPVC(ctx context.Context, pvc *corev1.PersistentVolumeClaim, size resource.Quantity) error {
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return fmt.Errorf("PVC %s has no StorageClass, so it cannot grow to %s", pvc.Name, size.String())
+	}
+
+	storageClass := &storagev1.StorageClass{}
+	if err := r.Get(ctx, client.ObjectKey{Name: *pvc.Spec.StorageClassName}, storageClass); err != nil {
+		return err
+	}
+	if storageClass.AllowVolumeExpansion == nil || !*storageClass.AllowVolumeExpansion {
+		return fmt.Errorf("StorageClass %q does not allow volume expansion, so PVC %s cannot grow to %s",
+			storageClass.Name, pvc.Name, size.String())
+	}
+
+	log.FromContext(ctx).Info("Expanding PVC", "name", pvc.Name, "size", size.String())
+	patch := client.MergeFrom(pvc.DeepCopy())
+	if pvc.Spec.Resources.Requests == nil {
+		pvc.Spec.Resources.Requests = corev1.ResourceList{}
+	}
+	pvc.Spec.Resources.Requests[corev1.ResourceStorage] = size
+	return r.Patch(ctx, pvc, patch)
+}
+
+// setStorageConditions mirrors the resize progress of the server PVCs into the StorageResizing and
+// FileSystemResizePending conditions. The conditions are only added once a resize starts.
+// Returns whether the conditions changed.
+func setStorageConditions(conditions *[]metav1.Condition, pvcs []*corev1.PersistentVolumeClaim, generation int64) bool {
+	var resizing, fsPending []string
+	for _, pvc := range pvcs {
+		requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		capacity, hasCapacity := pvc.Status.Capacity[corev1.ResourceStorage]
+
+		expanding := hasCapacity && requested.Cmp(capacity) > 0
+		for _, c := range pvc.Status.Conditions {
+			if c.Status != corev1.ConditionTrue {
+				continue
+			}
+			switch c.Type {
+			case corev1.PersistentVolumeClaimResizing:
+				expanding = true
+			case corev1.PersistentVolumeClaimFileSystemResizePending:
+				message := c.Message
+				if message == "" {
+					message = "Waiting for the node to resize the file system"
+				}
+				fsPending = append(fsPending, fmt.Sprintf("%s: %s", pvc.Name, message))
+			}
+		}
+		if expanding {
+			resizing = append(resizing, fmt.Sprintf("Expanding %s from %s to %s", pvc.Name, capacity.String(), requested.String()))
+		}
+	}
+
+	changed := setResizeCondition(conditions, boilerrv1alpha1.ConditionStorageResizing, storageReasonExpanding,
+		resizing, "Volumes have their requested size", generation)
+	return setResizeCondition(conditions, boilerrv1alpha1.ConditionFileSystemResizePending, storageReasonPending,
+		fsPending, "File system resize completed", generation) || changed
+}
+
+// setResizeCondition sets a resize condition True with the given messages, or False with doneMessage
+// once a resize it reported is done. Returns whether the condition changed.
+func setResizeCondition(conditions *[]metav1.Condition, condType, reason string, messages []string, doneMessage string, generation int64) bool {
+	if len(messages) > 0 {
+		return meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               condType,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			Reason:             reason,
+			Message:            strings.Join(messages, "; "),
+		})
+	}
+	if !meta.IsStatusConditionTrue(*conditions, condType) {
+		return false
+	}
+	return meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               condType,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             storageReasonResized,
+		Message:            doneMessage,
+	})
+}
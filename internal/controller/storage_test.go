@@ -0,0 +1,136 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+)
+
+var _ = Describe("Storage Helper Functions", func() {
+	newPVC := func(size, storageClass string) *corev1.PersistentVolumeClaim {
+		pvc := &corev1.PersistentVolumeClaim{
+			Spec: corev1.PersistentVolumeClaimSpec{
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+				},
+			},
+		}
+		if storageClass != "" {
+			pvc.Spec.StorageClassName = &storageClass
+		}
+		return pvc
+	}
+
+	Context("checkStorageChange", func() {
+		It("should expand a volume when storage.size grows", func() {
+			expand, err := checkStorageChange(newPVC("20Gi", "fast"), newPVC("50Gi", "fast"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(expand).To(BeTrue())
+		})
+
+		It("should leave an unchanged volume alone", func() {
+			expand, err := checkStorageChange(newPVC("20Gi", "fast"), newPVC("20480Mi", ""))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(expand).To(BeFalse())
+		})
+
+		It("should reject shrinking a volume", func() {
+			_, err := checkStorageChange(newPVC("50Gi", "fast"), newPVC("20Gi", "fast"))
+			Expect(err).To(MatchError(ContainSubstring("cannot be reduced from 50Gi to 20Gi")))
+		})
+
+		It("should reject changing the StorageClass", func() {
+			_, err := checkStorageChange(newPVC("20Gi", "fast"), newPVC("20Gi", "slow"))
+			Expect(err).To(MatchError(ContainSubstring(`from "fast" to "slow"`)))
+		})
+	})
+
+	Context("setStorageConditions", func() {
+		resizingPVC := func() *corev1.PersistentVolumeClaim {
+			pvc := newPVC("50Gi", "fast")
+			pvc.Name = "valheim-data"
+			pvc.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("20Gi")}
+			return pvc
+		}
+
+		It("should not add conditions to a volume that was never resized", func() {
+			pvc := newPVC("20Gi", "fast")
+			pvc.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("20Gi")}
+			var conditions []metav1.Condition
+			Expect(setStorageConditions(&conditions, []*corev1.PersistentVolumeClaim{pvc}, 1)).To(BeFalse())
+			Expect(conditions).To(BeEmpty())
+		})
+
+		It("should report a volume smaller than requested as resizing", func() {
+			var conditions []metav1.Condition
+			Expect(setStorageConditions(&conditions, []*corev1.PersistentVolumeClaim{resizingPVC()}, 1)).To(BeTrue())
+			cond := meta.FindStatusCondition(conditions, boilerrv1alpha1.ConditionStorageResizing)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Message).To(Equal("Expanding valheim-data from 20Gi to 50Gi"))
+		})
+
+		It("should surface a pending file system resize", func() {
+			pvc := resizingPVC()
+			pvc.Status.Conditions = []corev1.PersistentVolumeClaimCondition{{
+				Type:    corev1.PersistentVolumeClaimFileSystemResizePending,
+				Status:  corev1.ConditionTrue,
+				Message: "Waiting for user to (re-)start a pod to finish file system resize of volume on node.",
+			}}
+			var conditions []metav1.Condition
+			setStorageConditions(&conditions, []*corev1.PersistentVolumeClaim{pvc}, 1)
+			cond := meta.FindStatusCondition(conditions, boilerrv1alpha1.ConditionFileSystemResizePending)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Message).To(ContainSubstring("finish file system resize"))
+		})
+
+		It("should clear the conditions once the volume reaches the requested size", func() {
+			var conditions []metav1.Condition
+			pvc := resizingPVC()
+			pvc.Status.Conditions = []corev1.PersistentVolumeClaimCondition{{
+				Type:   corev1.PersistentVolumeClaimFileSystemResizePending,
+				Status: corev1.ConditionTrue,
+			}}
+			setStorageConditions(&conditions, []*corev1.PersistentVolumeClaim{pvc}, 1)
+
+			pvc.Status.Capacity[corev1.ResourceStorage] = resource.MustParse("50Gi")
+			pvc.Status.Conditions = nil
+			Expect(setStorageConditions(&conditions, []*corev1.PersistentVolumeClaim{pvc}, 1)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(conditions, boilerrv1alpha1.ConditionStorageResizing)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(conditions, boilerrv1alpha1.ConditionFileSystemResizePending)).To(BeTrue())
+		})
+
+		It("should report each resizing volume", func() {
+			saves := resizingPVC()
+			saves.Name = "valheim-saves"
+			var conditions []metav1.Condition
+			setStorageConditions(&conditions, []*corev1.PersistentVolumeClaim{resizingPVC(), saves}, 1)
+			cond := meta.FindStatusCondition(conditions, boilerrv1alpha1.ConditionStorageResizing)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Message).To(Equal("Expanding valheim-data from 20Gi to 50Gi; Expanding valheim-saves from 20Gi to 50Gi"))
+		})
+	})
+})
@@ -0,0 +1,218 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/resources"
+	"github.com/CraightonH/boilerr/internal/steamapi"
+)
+
+// DefaultUpdateCheckInterval is the default time between checks for new Steam builds.
+const DefaultUpdateCheckInterval = 15 * time.Minute
+
+// BuildSource looks up the latest published Steam build of an app.
+type BuildSource interface {
+	LatestBuildID(ctx context.Context, appID int32, branch string) (string, error)
+}
+
+// UpdateChecker periodically looks up the latest Steam build for SteamServers with an
+// OnRestart or Automatic updatePolicy, and for new gameCache servers, and records it in status.latestBuildId.
+// Each distinct AppId and branch is looked up once per check.
+// The SteamServer controller restarts Automatic servers when the latest build differs from the installed one.
+type UpdateChecker struct {
+	client.Client
+
+	// Source looks up the latest builds. Defaults to a steamapi.Client.
+	Source BuildSource
+
+	// Interval between checks. Defaults to DefaultUpdateCheckInterval.
+	Interval time.Duration
+}
+
+// +kubebuilder:rbac:groups=boilerr.dev,resources=steamservers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=boilerr.dev,resources=steamservers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=boilerr.dev,resources=gamedefinitions,verbs=get;list;watch
+
+// Start checks for new builds every Interval until ctx is cancelled.
+func (u *UpdateChecker) Start(ctx context.Context) error {
+	interval := u.Interval
+	if interval <= 0 {
+		interval = DefaultUpdateCheckInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := u.checkAll(ctx); err != nil {
+			log.FromContext(ctx).WithName("updatechecker").Error(err, "Failed to check for Steam updates")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection ensures only the leader checks for updates.
+func (u *UpdateChecker) NeedLeaderElection() bool {
+	return true
+}
+
+// buildKey identifies a Steam app branch.
+type buildKey struct {
+	appID  int32
+	branch string
+}
+
+// checkAll looks up the latest build for every distinct app branch in use and updates each SteamServer.
+func (u *UpdateChecker) checkAll(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("updatechecker")
+
+	var serverList boilerrv1alpha1.SteamServerList
+	if err := u.List(ctx, &serverList); err != nil {
+		return err
+	}
+
+	source := u.Source
+	if source == nil {
+		source = steamapi.NewClient(steamapi.DefaultBaseURL)
+	}
+
+	latest := map[buildKey]string{}
+	for i := range serverList.Items {
+		server := &serverList.Items[i]
+
+		var buildID string
+		if checksForUpdates(server) {
+			key, err := u.buildKey(ctx, server)
+			if err != nil {
+				logger.Error(err, "Failed to resolve app", "steamserver", client.ObjectKeyFromObject(server))
+				continue
+			}
+			if key.appID == 0 {
+				continue
+			}
+
+			var ok bool
+			if buildID, ok = latest[key]; !ok {
+				buildID, err = source.LatestBuildID(ctx, key.appID, key.branch)
+				if err != nil {
+					logger.Error(err, "Failed to look up latest build", "appId", key.appID, "branch", key.branch)
+				}
+				// Cache failures too, so one bad lookup is not repeated for every server
+				latest[key] = buildID
+			}
+			if buildID == "" {
+				continue
+			}
+		}
+
+		if err := u.updateServer(ctx, server, buildID); err != nil {
+			logger.Error(err, "Failed to update status", "steamserver", client.ObjectKeyFromObject(server))
+		}
+	}
+
+	return nil
+}
+
+// checksForUpdates returns whether the latest build of a SteamServer is looked up: under the OnRestart
+// and Automatic update policies, and for a gameCache server that needs a build to pick its game cache.
+func checksForUpdates(server *boilerrv1alpha1.SteamServer) bool {
+	switch server.Spec.UpdatePolicy {
+	case boilerrv1alpha1.UpdatePolicyOnRestart, boilerrv1alpha1.UpdatePolicyAutomatic:
+		return true
+	}
+	return server.Spec.GameCache && server.Status.AppBuildId == ""
+}
+
+// buildKey returns the app branch a SteamServer installs.
+func (u *UpdateChecker) buildKey(ctx context.Context, server *boilerrv1alpha1.SteamServer) (buildKey, error) {
+	var gameDef *boilerrv1alpha1.GameDefinition
+	if server.Spec.GameDefinition != "" {
+		gameDef = &boilerrv1alpha1.GameDefinition{}
+		if err := u.Get(ctx, client.ObjectKey{Name: server.Spec.GameDefinition}, gameDef); err != nil {
+			return buildKey{}, err
+		}
+	}
+	return serverBuildKey(server, gameDef), nil
+}
+
+// serverBuildKey returns the app branch a SteamServer installs, given its GameDefinition.
+func serverBuildKey(server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) buildKey {
+	branch := server.Spec.Beta
+	if branch == "" {
+		branch = steamapi.DefaultBranch
+	}
+	return buildKey{appID: resources.AppID(server, gameDef), branch: branch}
+}
+
+// updateServer records the latest build and the UpdateAvailable condition.
+// An empty latest build clears both, for servers that no longer check for updates.
+func (u *UpdateChecker) updateServer(ctx context.Context, server *boilerrv1alpha1.SteamServer, latest string) error {
+	patch := client.MergeFromWithOptions(server.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if !setUpdateStatus(server, latest) {
+		return nil
+	}
+	return u.Status().Patch(ctx, server, patch)
+}
+
+// setUpdateStatus sets status.latestBuildId and the UpdateAvailable condition. Returns whether anything changed.
+func setUpdateStatus(server *boilerrv1alpha1.SteamServer, latest string) bool {
+	if latest == "" {
+		changed := server.Status.LatestBuildId != ""
+		server.Status.LatestBuildId = ""
+		return meta.RemoveStatusCondition(&server.Status.Conditions, boilerrv1alpha1.ConditionUpdateAvailable) || changed
+	}
+
+	changed := server.Status.LatestBuildId != latest
+	server.Status.LatestBuildId = latest
+
+	installed := server.Status.AppBuildId
+	condition := metav1.Condition{
+		Type:               boilerrv1alpha1.ConditionUpdateAvailable,
+		ObservedGeneration: server.Generation,
+	}
+	switch {
+	case installed == "":
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "BuildUnknown"
+		condition.Message = fmt.Sprintf("Latest build is %s, installed build is not known yet", latest)
+	case installed != latest:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "NewBuildPublished"
+		condition.Message = fmt.Sprintf("Build %s is available, build %s is installed", latest, installed)
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "UpToDate"
+		condition.Message = fmt.Sprintf("Build %s is installed", installed)
+	}
+
+	return meta.SetStatusCondition(&server.Status.Conditions, condition) || changed
+}
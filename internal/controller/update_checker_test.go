@@ -0,0 +1,195 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/resources"
+)
+
+// fakeBuildSource returns fixed build IDs and counts lookups.
+type fakeBuildSource struct {
+	builds  map[int32]string
+	lookups int
+}
+
+func (f *fakeBuildSource) LatestBuildID(_ context.Context, appID int32, _ string) (string, error) {
+	f.lookups++
+	return f.builds[appID], nil
+}
+
+var _ = Describe("UpdateChecker", func() {
+	const (
+		timeout  = time.Second * 10
+		interval = time.Millisecond * 250
+	)
+
+	It("Should record the latest build and restart Automatic servers", func() {
+		gameDef := createTestGameDefinition("update-game", 896660)
+		defer deleteTestGameDefinition("update-game")
+
+		var servers []*boilerrv1alpha1.SteamServer
+		for _, name := range []string{"update-auto", "update-auto-2"} {
+			server := &boilerrv1alpha1.SteamServer{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: boilerrv1alpha1.SteamServerSpec{
+					GameDefinition: gameDef.Name,
+					UpdatePolicy:   boilerrv1alpha1.UpdatePolicyAutomatic,
+				},
+			}
+			Expect(k8sClient.Create(ctx, server)).Should(Succeed())
+			servers = append(servers, server)
+		}
+		defer func() {
+			for _, server := range servers {
+				_ = k8sClient.Delete(ctx, server)
+			}
+		}()
+
+		By("Recording an installed build")
+		for _, server := range servers {
+			key := types.NamespacedName{Name: server.Name, Namespace: "default"}
+			Eventually(func() error {
+				current := &boilerrv1alpha1.SteamServer{}
+				if err := k8sClient.Get(ctx, key, current); err != nil {
+					return err
+				}
+				current.Status.AppBuildId = "100"
+				return k8sClient.Status().Update(ctx, current)
+			}, timeout, interval).Should(Succeed())
+		}
+
+		By("Checking for updates")
+		source := &fakeBuildSource{builds: map[int32]string{896660: "200"}}
+		checker := &UpdateChecker{Client: k8sClient, Source: source}
+		Expect(checker.checkAll(ctx)).To(Succeed())
+		Expect(source.lookups).To(Equal(1))
+
+		key := types.NamespacedName{Name: "update-auto", Namespace: "default"}
+		Eventually(func() string {
+			current := &boilerrv1alpha1.SteamServer{}
+			if err := k8sClient.Get(ctx, key, current); err != nil {
+				return ""
+			}
+			return current.Status.LatestBuildId
+		}, timeout, interval).Should(Equal("200"))
+
+		By("Restarting the pod to install the new build")
+		Eventually(func() string {
+			sts := &appsv1.StatefulSet{}
+			if err := k8sClient.Get(ctx, key, sts); err != nil {
+				return ""
+			}
+			return sts.Spec.Template.Annotations[resources.TargetBuildAnnotation]
+		}, timeout, interval).Should(Equal("200"))
+	})
+})
+
+var _ = Describe("Update Helper Functions", func() {
+	Context("checksForUpdates", func() {
+		It("Should check OnRestart, Automatic and new gameCache servers", func() {
+			server := &boilerrv1alpha1.SteamServer{}
+			server.Spec.UpdatePolicy = boilerrv1alpha1.UpdatePolicyManual
+			Expect(checksForUpdates(server)).To(BeFalse())
+
+			server.Spec.GameCache = true
+			Expect(checksForUpdates(server)).To(BeTrue())
+
+			server.Status.AppBuildId = "100"
+			Expect(checksForUpdates(server)).To(BeFalse())
+
+			server.Spec.UpdatePolicy = boilerrv1alpha1.UpdatePolicyOnRestart
+			Expect(checksForUpdates(server)).To(BeTrue())
+		})
+	})
+
+	Context("setUpdateStatus", func() {
+		It("Should report an available update", func() {
+			server := &boilerrv1alpha1.SteamServer{}
+			server.Status.AppBuildId = "100"
+
+			Expect(setUpdateStatus(server, "200")).To(BeTrue())
+			Expect(server.Status.LatestBuildId).To(Equal("200"))
+			cond := meta.FindStatusCondition(server.Status.Conditions, boilerrv1alpha1.ConditionUpdateAvailable)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+
+			Expect(setUpdateStatus(server, "200")).To(BeFalse())
+		})
+
+		It("Should report up to date", func() {
+			server := &boilerrv1alpha1.SteamServer{}
+			server.Status.AppBuildId = "200"
+
+			setUpdateStatus(server, "200")
+			cond := meta.FindStatusCondition(server.Status.Conditions, boilerrv1alpha1.ConditionUpdateAvailable)
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		})
+
+		It("Should clear status when no longer checking", func() {
+			server := &boilerrv1alpha1.SteamServer{}
+			setUpdateStatus(server, "200")
+
+			Expect(setUpdateStatus(server, "")).To(BeTrue())
+			Expect(server.Status.LatestBuildId).To(BeEmpty())
+			Expect(server.Status.Conditions).To(BeEmpty())
+		})
+	})
+
+	Context("targetBuild", func() {
+		newServer := func(policy boilerrv1alpha1.UpdatePolicy, installed, latest string) *boilerrv1alpha1.SteamServer {
+			return &boilerrv1alpha1.SteamServer{
+				Spec: boilerrv1alpha1.SteamServerSpec{UpdatePolicy: policy},
+				Status: boilerrv1alpha1.SteamServerStatus{
+					AppBuildId:    installed,
+					LatestBuildId: latest,
+				},
+			}
+		}
+
+		It("Should target a newer build under Automatic", func() {
+			Expect(targetBuild(newServer(boilerrv1alpha1.UpdatePolicyAutomatic, "100", "200"), "", true)).To(Equal("200"))
+		})
+
+		It("Should keep the current target when up to date", func() {
+			Expect(targetBuild(newServer(boilerrv1alpha1.UpdatePolicyAutomatic, "200", "200"), "150", true)).To(Equal("150"))
+		})
+
+		It("Should not restart under OnRestart", func() {
+			Expect(targetBuild(newServer(boilerrv1alpha1.UpdatePolicyOnRestart, "100", "200"), "", true)).To(BeEmpty())
+		})
+
+		It("Should wait for the installed build to be known", func() {
+			Expect(targetBuild(newServer(boilerrv1alpha1.UpdatePolicyAutomatic, "", "200"), "", true)).To(BeEmpty())
+		})
+
+		It("Should wait for the maintenance window", func() {
+			Expect(targetBuild(newServer(boilerrv1alpha1.UpdatePolicyAutomatic, "100", "200"), "", false)).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,181 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/resources"
+)
+
+var (
+	// errWakeNotEnabled is returned when a wake request targets a server without wakeOnConnect.
+	errWakeNotEnabled = errors.New("wakeOnConnect is not enabled")
+
+	// errWakeUnauthorized is returned when a wake request doesn't carry the server's wake token.
+	errWakeUnauthorized = errors.New("invalid wake token")
+)
+
+// WakeURL returns the URL a SteamServer's wake proxy posts to when a player connects.
+func WakeURL(base string, server *boilerrv1alpha1.SteamServer) string {
+	return fmt.Sprintf("%s/wake/%s/%s", strings.TrimSuffix(base, "/"), server.Namespace, server.Name)
+}
+
+// WakeServer serves the endpoint wake proxies call when a player connects to a suspended SteamServer.
+// A wake request sets the server's status.wokenAt, which resumes it without changing spec.suspended,
+// and its WakeRequested condition. Requests must carry the token from the server's wake Secret,
+// which only its wake proxy is given.
+type WakeServer struct {
+	client.Client
+
+	// APIReader reads wake token Secrets, which the manager does not cache.
+	APIReader client.Reader
+
+	// BindAddress is the address the wake endpoint listens on.
+	BindAddress string
+
+	// Recorder emits an Event when a server is woken. Optional.
+	Recorder record.EventRecorder
+}
+
+// Start serves wake requests until ctx is cancelled.
+func (s *WakeServer) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /wake/{namespace}/{name}", s.handleWake)
+
+	srv := &http.Server{
+		Addr:              s.BindAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errs := make(chan error, 1)
+	go func() { errs <- srv.ListenAndServe() }()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	case err := <-errs:
+		return err
+	}
+}
+
+// NeedLeaderElection returns false so every replica behind the wake Service can serve requests.
+func (s *WakeServer) NeedLeaderElection() bool {
+	return false
+}
+
+// handleWake resumes the SteamServer named in the request path.
+func (s *WakeServer) handleWake(w http.ResponseWriter, r *http.Request) {
+	key := client.ObjectKey{Namespace: r.PathValue("namespace"), Name: r.PathValue("name")}
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	err := s.wake(r.Context(), key, token)
+	switch {
+	case err == nil:
+		w.WriteHeader(http.StatusAccepted)
+	case errors.Is(err, errWakeUnauthorized):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case apierrors.IsNotFound(err):
+		http.Error(w, "SteamServer not found", http.StatusNotFound)
+	case errors.Is(err, errWakeNotEnabled):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	default:
+		log.FromContext(r.Context()).Error(err, "Failed to wake SteamServer", "steamserver", key)
+		http.Error(w, "failed to wake server", http.StatusInternalServerError)
+	}
+}
+
+// wake records the wake in status.wokenAt and the WakeRequested condition.
+// Waking a server that isn't suspended is a no-op.
+func (s *WakeServer) wake(ctx context.Context, key client.ObjectKey, token string) error {
+	if err := s.checkToken(ctx, key, token); err != nil {
+		return err
+	}
+
+	server := &boilerrv1alpha1.SteamServer{}
+	if err := s.Get(ctx, key, server); err != nil {
+		return err
+	}
+	if !server.Spec.WakeOnConnect {
+		return errWakeNotEnabled
+	}
+	if !resources.Suspended(server) {
+		return nil
+	}
+
+	// Optimistic locking keeps a concurrent idle shutdown from being undone
+	patch := client.MergeFromWithOptions(server.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	now := metav1.Now()
+	server.Status.WokenAt = &now
+	meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               boilerrv1alpha1.ConditionWakeRequested,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: server.Generation,
+		Reason:             "PlayerConnected",
+		Message:            "A player connected to the suspended server",
+	})
+	if err := s.Status().Patch(ctx, server, patch); err != nil {
+		return err
+	}
+
+	log.FromContext(ctx).Info("Woke SteamServer", "steamserver", key)
+	if s.Recorder != nil {
+		s.Recorder.Event(server, corev1.EventTypeNormal, "WakeRequested", "Resuming after a player connected")
+	}
+	return nil
+}
+
+// checkToken returns errWakeUnauthorized unless token matches the server's wake Secret.
+// A server without a wake Secret is reported the same way, so requests can't probe for servers.
+func (s *WakeServer) checkToken(ctx context.Context, key client.ObjectKey, token string) error {
+	reader := s.APIReader
+	if reader == nil {
+		reader = s.Client
+	}
+	secret := &corev1.Secret{}
+	err := reader.Get(ctx, client.ObjectKey{Namespace: key.Namespace, Name: resources.WakeTokenSecretName(key.Name)}, secret)
+	if apierrors.IsNotFound(err) {
+		return errWakeUnauthorized
+	}
+	if err != nil {
+		return err
+	}
+
+	expected := secret.Data[resources.WakeTokenKey]
+	if token == "" || len(expected) == 0 || subtle.ConstantTimeCompare([]byte(token), expected) != 1 {
+		return errWakeUnauthorized
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/resources"
+)
+
+var _ = Describe("WakeServer", func() {
+	// serveWake sends a wake request with token through the WakeServer routes.
+	serveWake := func(server *WakeServer, namespace, name, token string) int {
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /wake/{namespace}/{name}", server.handleWake)
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/wake/"+namespace+"/"+name, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		mux.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// createWakeToken creates the wake token Secret of a server.
+	createWakeToken := func(name, token string) *corev1.Secret {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: resources.WakeTokenSecretName(name), Namespace: "default"},
+			StringData: map[string]string{resources.WakeTokenKey: token},
+		}
+		Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+		return secret
+	}
+
+	It("Should resume a suspended server with wakeOnConnect without changing its spec", func() {
+		gameDef := createTestGameDefinition("wake-game", 896660)
+		defer deleteTestGameDefinition("wake-game")
+
+		server := &boilerrv1alpha1.SteamServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "wake-server", Namespace: "default"},
+			Spec: boilerrv1alpha1.SteamServerSpec{
+				GameDefinition: gameDef.Name,
+				Suspended:      true,
+				WakeOnConnect:  true,
+			},
+		}
+		Expect(k8sClient.Create(ctx, server)).Should(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, server) }()
+		secret := createWakeToken("wake-server", "s3cret")
+		defer func() { _ = k8sClient.Delete(ctx, secret) }()
+
+		wakeServer := &WakeServer{Client: k8sClient}
+		Expect(serveWake(wakeServer, "default", "wake-server", "")).To(Equal(http.StatusUnauthorized))
+		Expect(serveWake(wakeServer, "default", "wake-server", "wrong")).To(Equal(http.StatusUnauthorized))
+		Expect(serveWake(wakeServer, "default", "wake-server", "s3cret")).To(Equal(http.StatusAccepted))
+
+		current := &boilerrv1alpha1.SteamServer{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "wake-server", Namespace: "default"}, current)).To(Succeed())
+		Expect(current.Spec.Suspended).To(BeTrue())
+		Expect(current.Status.WokenAt).NotTo(BeNil())
+		Expect(resources.Suspended(current)).To(BeFalse())
+		Expect(meta.IsStatusConditionTrue(current.Status.Conditions, boilerrv1alpha1.ConditionWakeRequested)).To(BeTrue())
+	})
+
+	It("Should refuse servers without wakeOnConnect or a wake token", func() {
+		gameDef := createTestGameDefinition("nowake-game", 896660)
+		defer deleteTestGameDefinition("nowake-game")
+
+		server := &boilerrv1alpha1.SteamServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "nowake-server", Namespace: "default"},
+			Spec: boilerrv1alpha1.SteamServerSpec{
+				GameDefinition: gameDef.Name,
+				Suspended:      true,
+			},
+		}
+		Expect(k8sClient.Create(ctx, server)).Should(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, server) }()
+		secret := createWakeToken("nowake-server", "s3cret")
+		defer func() { _ = k8sClient.Delete(ctx, secret) }()
+
+		wakeServer := &WakeServer{Client: k8sClient}
+		Expect(serveWake(wakeServer, "default", "nowake-server", "s3cret")).To(Equal(http.StatusForbidden))
+		Expect(serveWake(wakeServer, "default", "missing", "s3cret")).To(Equal(http.StatusUnauthorized))
+	})
+})
+
+var _ = Describe("Wake Helper Functions", func() {
+	Context("WakeURL", func() {
+		It("Should build the per-server wake URL", func() {
+			server := &boilerrv1alpha1.SteamServer{ObjectMeta: metav1.ObjectMeta{Name: "valheim", Namespace: "games"}}
+			Expect(WakeURL("http://boilerr-wake.boilerr-system.svc:8082/", server)).
+				To(Equal("http://boilerr-wake.boilerr-system.svc:8082/wake/games/valheim"))
+		})
+	})
+
+	Context("wakeProxyActive", func() {
+		r := &SteamServerReconciler{WakeProxyImage: "boilerr:latest", WakeURL: "http://boilerr-wake:8082"}
+
+		It("Should run while suspended", func() {
+			server := &boilerrv1alpha1.SteamServer{Spec: boilerrv1alpha1.SteamServerSpec{Suspended: true, WakeOnConnect: true}}
+			Expect(r.wakeProxyActive(server)).To(BeTrue())
+		})
+
+		It("Should keep running until a woken server is Running", func() {
+			server := &boilerrv1alpha1.SteamServer{Spec: boilerrv1alpha1.SteamServerSpec{WakeOnConnect: true}}
+			Expect(r.wakeProxyActive(server)).To(BeFalse())
+
+			meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+				Type:   boilerrv1alpha1.ConditionWakeRequested,
+				Status: metav1.ConditionTrue,
+				Reason: "PlayerConnected",
+			})
+			Expect(r.wakeProxyActive(server)).To(BeTrue())
+		})
+
+		It("Should stand in again once a woken server is suspended again", func() {
+			now := metav1.Now()
+			server := &boilerrv1alpha1.SteamServer{Spec: boilerrv1alpha1.SteamServerSpec{Suspended: true, WakeOnConnect: true}}
+			server.Status.WokenAt = &now
+			Expect(r.wakeProxyActive(server)).To(BeFalse())
+
+			server.Status.WokenAt = nil
+			Expect(r.wakeProxyActive(server)).To(BeTrue())
+		})
+
+		It("Should not run without wakeOnConnect or operator configuration", func() {
+			server := &boilerrv1alpha1.SteamServer{Spec: boilerrv1alpha1.SteamServerSpec{Suspended: true}}
+			Expect(r.wakeProxyActive(server)).To(BeFalse())
+
+			server.Spec.WakeOnConnect = true
+			Expect((&SteamServerReconciler{}).wakeProxyActive(server)).To(BeFalse())
+		})
+	})
+})
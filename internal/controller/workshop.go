@@ -0,0 +1,157 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/resources"
+	"github.com/CraightonH/boilerr/internal/steamcmd"
+)
+
+// validateMods checks that the GameDefinition can install the Workshop mods of a SteamServer.
+func validateMods(server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) error {
+	if len(server.Spec.Mods) == 0 {
+		return nil
+	}
+	if gameDef == nil || gameDef.Spec.Workshop == nil {
+		return fmt.Errorf("mods require GameDefinition %q to declare workshop", server.Spec.GameDefinition)
+	}
+	if server.Spec.GameCache {
+		return fmt.Errorf("mods can't be installed with gameCache")
+	}
+
+	seen := make(map[int64]bool, len(server.Spec.Mods))
+	for i, mod := range server.Spec.Mods {
+		if seen[mod.ID] {
+			return fmt.Errorf("mods[%d]: Workshop item %d is listed more than once", i, mod.ID)
+		}
+		seen[mod.ID] = true
+	}
+	return nil
+}
+
+// determineMods returns the Workshop items reported by the workshop init container.
+// Returns false if the pod has not completed an install of its mods.
+func (r *SteamServerReconciler) determineMods(ctx context.Context, server *boilerrv1alpha1.SteamServer) ([]boilerrv1alpha1.InstalledMod, bool) {
+	pod := &corev1.Pod{}
+	if err := r.Get(ctx, client.ObjectKey{
+		Name:      fmt.Sprintf("%s-0", server.Name),
+		Namespace: server.Namespace,
+	}, pod); err != nil {
+		return nil, false
+	}
+
+	for _, cs := range pod.Status.InitContainerStatuses {
+		if cs.Name != resources.WorkshopContainerName {
+			continue
+		}
+		terminated := cs.State.Terminated
+		if terminated == nil || terminated.ExitCode != 0 {
+			return nil, false
+		}
+		items, ok := steamcmd.ParseWorkshopItems(terminated.Message)
+		if !ok {
+			return nil, false
+		}
+		return installedMods(server, items), true
+	}
+	return nil, false
+}
+
+// installedMods converts the Workshop items of an install into status.mods, in spec.mods order.
+func installedMods(server *boilerrv1alpha1.SteamServer, items []steamcmd.WorkshopItem) []boilerrv1alpha1.InstalledMod {
+	order := make(map[int64]int, len(server.Spec.Mods))
+	for i, mod := range server.Spec.Mods {
+		order[mod.ID] = i
+	}
+	byPosition := make(map[int]steamcmd.WorkshopItem, len(items))
+	var unlisted []steamcmd.WorkshopItem
+	for _, item := range items {
+		if i, ok := order[item.ID]; ok {
+			byPosition[i] = item
+		} else {
+			unlisted = append(unlisted, item)
+		}
+	}
+
+	var sorted []steamcmd.WorkshopItem
+	for i := range server.Spec.Mods {
+		if item, ok := byPosition[i]; ok {
+			sorted = append(sorted, item)
+		}
+	}
+	sorted = append(sorted, unlisted...)
+
+	var mods []boilerrv1alpha1.InstalledMod
+	for _, item := range sorted {
+		mod := boilerrv1alpha1.InstalledMod{ID: item.ID, Version: item.Manifest}
+		if item.TimeUpdated > 0 {
+			mod.TimeUpdated = &metav1.Time{Time: time.Unix(item.TimeUpdated, 0).UTC()}
+		}
+		mods = append(mods, mod)
+	}
+	return mods
+}
+
+// setModVersionCondition compares the installed versions of the Workshop mods with their pinned
+// mods[].version and reports any difference in the ModVersionMismatch condition. The condition is
+// removed when no mod is pinned. Returns whether the status changed.
+func setModVersionCondition(server *boilerrv1alpha1.SteamServer, installed []boilerrv1alpha1.InstalledMod) bool {
+	versions := make(map[int64]string, len(installed))
+	for _, mod := range installed {
+		versions[mod.ID] = mod.Version
+	}
+
+	pinned := false
+	var mismatches []string
+	for _, mod := range resources.EnabledMods(server) {
+		if mod.Version == "" {
+			continue
+		}
+		pinned = true
+		if version, ok := versions[mod.ID]; ok && version != mod.Version {
+			mismatches = append(mismatches, fmt.Sprintf("Workshop item %d is at version %s instead of pinned %s", mod.ID, version, mod.Version))
+		}
+	}
+	if !pinned {
+		return meta.RemoveStatusCondition(&server.Status.Conditions, boilerrv1alpha1.ConditionModVersionMismatch)
+	}
+
+	condition := metav1.Condition{
+		Type:               boilerrv1alpha1.ConditionModVersionMismatch,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: server.Generation,
+		Reason:             "AtPinnedVersion",
+		Message:            "Pinned Workshop mods are at their pinned versions",
+	}
+	if len(mismatches) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "VersionMismatch"
+		condition.Message = strings.Join(mismatches, "; ") + "; update or remove mods[].version"
+	}
+	return meta.SetStatusCondition(&server.Status.Conditions, condition)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 CraightonH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/steamcmd"
+)
+
+var _ = Describe("Workshop Helper Functions", func() {
+	newServer := func(ids ...int64) *boilerrv1alpha1.SteamServer {
+		server := &boilerrv1alpha1.SteamServer{
+			Spec: boilerrv1alpha1.SteamServerSpec{GameDefinition: "valheim"},
+		}
+		for _, id := range ids {
+			server.Spec.Mods = append(server.Spec.Mods, boilerrv1alpha1.WorkshopMod{ID: id})
+		}
+		return server
+	}
+	gameDef := &boilerrv1alpha1.GameDefinition{
+		Spec: boilerrv1alpha1.GameDefinitionSpec{
+			Workshop: &boilerrv1alpha1.WorkshopSpec{AppId: 892970},
+		},
+	}
+
+	It("Should accept mods the GameDefinition can install", func() {
+		Expect(validateMods(newServer(1, 2), gameDef)).To(Succeed())
+		Expect(validateMods(newServer(), nil)).To(Succeed())
+	})
+
+	It("Should reject mods the GameDefinition can't install", func() {
+		Expect(validateMods(newServer(1), &boilerrv1alpha1.GameDefinition{})).
+			To(MatchError(ContainSubstring("to declare workshop")))
+
+		server := newServer(1)
+		server.Spec.GameCache = true
+		Expect(validateMods(server, gameDef)).To(MatchError(ContainSubstring("gameCache")))
+
+		Expect(validateMods(newServer(1, 2, 1), gameDef)).
+			To(MatchError("mods[2]: Workshop item 1 is listed more than once"))
+	})
+
+	It("Should report installed mods in spec order", func() {
+		mods := installedMods(newServer(2, 1), []steamcmd.WorkshopItem{
+			{ID: 1, TimeUpdated: 1700000000, Manifest: "41"},
+			{ID: 3, Manifest: "43"},
+			{ID: 2, TimeUpdated: 1700000001, Manifest: "42"},
+		})
+
+		Expect(mods).To(HaveLen(3))
+		Expect(mods[0].ID).To(Equal(int64(2)))
+		Expect(mods[0].Version).To(Equal("42"))
+		Expect(mods[0].TimeUpdated.Time).To(Equal(time.Unix(1700000001, 0).UTC()))
+		Expect(mods[1].ID).To(Equal(int64(1)))
+		Expect(mods[2].ID).To(Equal(int64(3)))
+		Expect(mods[2].TimeUpdated).To(BeNil())
+	})
+	It("Should report pinned mods at another version without failing", func() {
+		server := newServer(1, 2)
+		Expect(setModVersionCondition(server, nil)).To(BeFalse())
+
+		server.Spec.Mods[1].Version = "42"
+		installed := []boilerrv1alpha1.InstalledMod{{ID: 1, Version: "41"}, {ID: 2, Version: "42"}}
+		Expect(setModVersionCondition(server, installed)).To(BeTrue())
+		Expect(meta.IsStatusConditionFalse(server.Status.Conditions, boilerrv1alpha1.ConditionModVersionMismatch)).To(BeTrue())
+
+		installed[1].Version = "43"
+		Expect(setModVersionCondition(server, installed)).To(BeTrue())
+		condition := meta.FindStatusCondition(server.Status.Conditions, boilerrv1alpha1.ConditionModVersionMismatch)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Message).To(HavePrefix("Workshop item 2 is at version 43 instead of pinned 42"))
+
+		server.Spec.Mods[1].Version = ""
+		Expect(setModVersionCondition(server, installed)).To(BeTrue())
+		Expect(server.Status.Conditions).To(BeEmpty())
+	})
+})
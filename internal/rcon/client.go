@@ -0,0 +1,148 @@
+// Package rcon implements a client for the Source RCON protocol, which many game servers use
+// for remote administration commands such as broadcasts and world saves.
+//
+// See https://developer.valvesoftware.com/wiki/Source_RCON_Protocol for the wire format.
+package rcon
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// DefaultTimeout is the default time allowed for connecting and running all commands.
+const DefaultTimeout = 10 * time.Second
+
+// Packet types.
+const (
+	typeResponseValue = 0
+	typeExecCommand   = 2
+	typeAuthResponse  = 2
+	typeAuth          = 3
+)
+
+// maxPacketSize is the largest packet the protocol allows.
+const maxPacketSize = 4096
+
+// ErrAuthFailed is returned when the server rejects the RCON password.
+var ErrAuthFailed = errors.New("rcon: authentication failed")
+
+// Client runs RCON commands against game servers.
+type Client struct {
+	// Timeout bounds connecting and running all commands. Defaults to DefaultTimeout.
+	Timeout time.Duration
+}
+
+// NewClient creates a Client with the given timeout.
+func NewClient(timeout time.Duration) *Client {
+	return &Client{Timeout: timeout}
+}
+
+// Run authenticates to addr and runs each command in order, returning their responses.
+func (c *Client) Run(ctx context.Context, addr, password string, commands []string) ([]string, error) {
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("rcon: dial: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := authenticate(conn, password); err != nil {
+		return nil, err
+	}
+
+	responses := make([]string, 0, len(commands))
+	for i, command := range commands {
+		id := int32(i + 2)
+		if err := writePacket(conn, id, typeExecCommand, command); err != nil {
+			return responses, err
+		}
+		p, err := readPacket(conn)
+		if err != nil {
+			return responses, fmt.Errorf("rcon: %q: %w", command, err)
+		}
+		responses = append(responses, p.body)
+	}
+	return responses, nil
+}
+
+// authenticate sends the password and waits for the auth response.
+// Some servers send an empty response value before the auth response.
+func authenticate(conn io.ReadWriter, password string) error {
+	const authID = 1
+	if err := writePacket(conn, authID, typeAuth, password); err != nil {
+		return err
+	}
+	for {
+		p, err := readPacket(conn)
+		if err != nil {
+			return fmt.Errorf("rcon: auth: %w", err)
+		}
+		if p.kind != typeAuthResponse {
+			continue
+		}
+		if p.id == -1 {
+			return ErrAuthFailed
+		}
+		return nil
+	}
+}
+
+// packet is a single RCON packet.
+type packet struct {
+	id   int32
+	kind int32
+	body string
+}
+
+// writePacket encodes and sends a packet.
+func writePacket(w io.Writer, id, kind int32, body string) error {
+	size := int32(4 + 4 + len(body) + 2)
+	buf := make([]byte, 0, 4+size)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(size))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(id))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(kind))
+	buf = append(buf, body...)
+	buf = append(buf, 0, 0)
+	if _, err := w.Write(buf); err != nil {
+		return fmt.Errorf("rcon: write: %w", err)
+	}
+	return nil
+}
+
+// readPacket reads and decodes a single packet.
+func readPacket(r io.Reader) (packet, error) {
+	var size int32
+	if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+		return packet{}, err
+	}
+	if size < 10 || size > maxPacketSize {
+		return packet{}, fmt.Errorf("invalid packet size %d", size)
+	}
+
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return packet{}, err
+	}
+	return packet{
+		id:   int32(binary.LittleEndian.Uint32(buf[0:4])),
+		kind: int32(binary.LittleEndian.Uint32(buf[4:8])),
+		body: string(buf[8 : len(buf)-2]),
+	}, nil
+}
@@ -0,0 +1,76 @@
+package rcon
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+// startFakeServer serves RCON on localhost, answering each command with "ok: <command>".
+func startFakeServer(t *testing.T, password string) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				for {
+					p, err := readPacket(conn)
+					if err != nil {
+						return
+					}
+					switch p.kind {
+					case typeAuth:
+						id := p.id
+						if p.body != password {
+							id = -1
+						}
+						_ = writePacket(conn, p.id, typeResponseValue, "")
+						_ = writePacket(conn, id, typeAuthResponse, "")
+					case typeExecCommand:
+						_ = writePacket(conn, p.id, typeResponseValue, "ok: "+p.body)
+					}
+				}
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestClient_Run(t *testing.T) {
+	addr := startFakeServer(t, "secret")
+
+	responses, err := NewClient(time.Second).Run(context.Background(), addr, "secret", []string{"say restarting", "save"})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	want := []string{"ok: say restarting", "ok: save"}
+	if len(responses) != len(want) {
+		t.Fatalf("Run() = %v, want %v", responses, want)
+	}
+	for i := range want {
+		if responses[i] != want[i] {
+			t.Errorf("response[%d] = %q, want %q", i, responses[i], want[i])
+		}
+	}
+}
+
+func TestClient_RunWrongPassword(t *testing.T) {
+	addr := startFakeServer(t, "secret")
+
+	_, err := NewClient(time.Second).Run(context.Background(), addr, "wrong", []string{"save"})
+	if !errors.Is(err, ErrAuthFailed) {
+		t.Errorf("Run() error = %v, want ErrAuthFailed", err)
+	}
+}
@@ -0,0 +1,264 @@
+package resources
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+)
+
+const (
+	// BackupContainerName is the name of the backup container.
+	BackupContainerName = "backup"
+	// BackupBinaryPath is the path of the backup binary in the operator image.
+	BackupBinaryPath = "/backup"
+	// BackupDestinationVolumeName is the volume name of the backup destination PVC.
+	BackupDestinationVolumeName = "backup-destination"
+	// BackupDestinationMountPath is where the backup destination PVC is mounted.
+	BackupDestinationMountPath = "/backup-destination"
+	// BackupTempVolumeName is the scratch volume archives are written to before upload.
+	BackupTempVolumeName = "tmp"
+	// DefaultBackupRetention is used when backup.retention is not set.
+	DefaultBackupRetention int32 = 7
+)
+
+// BackupCronJobBuilder builds the backup CronJob for a SteamServer with spec.backup.
+// Each Job archives backup.paths from the server volume and stores the archive at backup.destination.
+type BackupCronJobBuilder struct {
+	server *boilerrv1alpha1.SteamServer
+	image  string
+}
+
+// NewBackupCronJobBuilder creates a new BackupCronJobBuilder.
+func NewBackupCronJobBuilder(server *boilerrv1alpha1.SteamServer) *BackupCronJobBuilder {
+	return &BackupCronJobBuilder{server: server}
+}
+
+// WithImage sets the operator image that provides the backup binary.
+func (b *BackupCronJobBuilder) WithImage(image string) *BackupCronJobBuilder {
+	b.image = image
+	return b
+}
+
+// Build creates the backup CronJob.
+// Returns an error if the backup destination is not exactly one of persistentVolumeClaim or s3.
+func (b *BackupCronJobBuilder) Build() (*batchv1.CronJob, error) {
+	spec := b.server.Spec.Backup
+	dest := spec.Destination
+	if (dest.PersistentVolumeClaim == nil) == (dest.S3 == nil) {
+		return nil, fmt.Errorf("backup.destination must set exactly one of persistentVolumeClaim or s3")
+	}
+
+	labels := BackupLabels(b.server)
+	var timeZone *string
+	if spec.TimeZone != "" {
+		timeZone = &spec.TimeZone
+	}
+	historyLimit := int32(3)
+	backoffLimit := int32(1)
+	rootUser := int64(0)
+	runAsNonRoot := false
+	// Don't back up a volume while it is being restored
+	suspend := Restoring(b.server)
+
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      BackupCronJobName(b.server.Name),
+			Namespace: b.server.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:                   spec.Schedule,
+			TimeZone:                   timeZone,
+			ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+			Suspend:                    &suspend,
+			SuccessfulJobsHistoryLimit: &historyLimit,
+			FailedJobsHistoryLimit:     &historyLimit,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: batchv1.JobSpec{
+					BackoffLimit: &backoffLimit,
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: labels,
+						},
+						Spec: corev1.PodSpec{
+							RestartPolicy: corev1.RestartPolicyNever,
+							Affinity:      serverPodAffinity(b.server),
+							SecurityContext: &corev1.PodSecurityContext{
+								// Game files are owned by the game image's user, so read them as root
+								RunAsUser:    &rootUser,
+								RunAsNonRoot: &runAsNonRoot,
+							},
+							Containers: []corev1.Container{b.buildContainer()},
+							Volumes:    b.buildVolumes(),
+						},
+					},
+				},
+			},
+		},
+	}
+	cronJob.Annotations = map[string]string{SpecHashAnnotation: SpecHash(&cronJob.Spec)}
+	return cronJob, nil
+}
+
+// buildContainer creates the backup container.
+func (b *BackupCronJobBuilder) buildContainer() corev1.Container {
+	spec := b.server.Spec.Backup
+	retention := DefaultBackupRetention
+	if spec.Retention > 0 {
+		retention = spec.Retention
+	}
+
+	command := []string{
+		BackupBinaryPath, "create",
+		"-root", ServerFilesMountPath,
+		"-paths", strings.Join(spec.Paths, ","),
+		"-name", b.server.Name,
+		"-retention", strconv.Itoa(int(retention)),
+	}
+	mounts := []corev1.VolumeMount{
+		{Name: ServerFilesVolumeName, MountPath: ServerFilesMountPath, ReadOnly: true},
+		{Name: BackupTempVolumeName, MountPath: "/tmp"},
+	}
+	var env []corev1.EnvVar
+
+	if pvc := spec.Destination.PersistentVolumeClaim; pvc != nil {
+		path := pvc.Path
+		if path == "" {
+			path = b.server.Name
+		}
+		command = append(command, "-dir", BackupDestinationMountPath+"/"+strings.Trim(path, "/"))
+		mounts = append(mounts, corev1.VolumeMount{Name: BackupDestinationVolumeName, MountPath: BackupDestinationMountPath})
+	}
+	if s3 := spec.Destination.S3; s3 != nil {
+		command = append(command,
+			"-s3-endpoint", s3.Endpoint,
+			"-s3-bucket", s3.Bucket,
+			"-s3-prefix", s3.Prefix,
+		)
+		if s3.Region != "" {
+			command = append(command, "-s3-region", s3.Region)
+		}
+		env = s3CredentialsEnv(s3.CredentialsSecretRef)
+	}
+
+	return corev1.Container{
+		Name:         BackupContainerName,
+		Image:        b.image,
+		Command:      command,
+		Env:          env,
+		VolumeMounts: mounts,
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("50m"),
+				corev1.ResourceMemory: resource.MustParse("32Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("256Mi"),
+			},
+		},
+	}
+}
+
+// buildVolumes creates the server volume, scratch space and destination PVC volumes.
+func (b *BackupCronJobBuilder) buildVolumes() []corev1.Volume {
+	volumes := []corev1.Volume{
+		{
+			Name: ServerFilesVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: SaveClaimName(b.server),
+					ReadOnly:  true,
+				},
+			},
+		},
+		{
+			Name: BackupTempVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+	if pvc := b.server.Spec.Backup.Destination.PersistentVolumeClaim; pvc != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: BackupDestinationVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: pvc.ClaimName,
+				},
+			},
+		})
+	}
+	return volumes
+}
+
+// s3CredentialsEnv returns the S3 credential environment variables from a Secret.
+func s3CredentialsEnv(ref corev1.LocalObjectReference) []corev1.EnvVar {
+	env := make([]corev1.EnvVar, 0, 2)
+	for _, key := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY"} {
+		env = append(env, corev1.EnvVar{
+			Name: key,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: ref,
+					Key:                  key,
+				},
+			},
+		})
+	}
+	return env
+}
+
+// serverPodAffinity places the pod on the node running the game server pod, which is the only node
+// that can mount its ReadWriteOnce volume while it runs. While the server is scaled down there is
+// no pod to follow, so the node is only preferred.
+func serverPodAffinity(server *boilerrv1alpha1.SteamServer) *corev1.Affinity {
+	term := corev1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app.kubernetes.io/name":     "steamserver",
+				"app.kubernetes.io/instance": server.Name,
+			},
+		},
+		TopologyKey: corev1.LabelHostname,
+	}
+
+	if ScaledDown(server) {
+		return &corev1.Affinity{
+			PodAffinity: &corev1.PodAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+					{Weight: 100, PodAffinityTerm: term},
+				},
+			},
+		}
+	}
+	return &corev1.Affinity{
+		PodAffinity: &corev1.PodAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{term},
+		},
+	}
+}
+
+// BackupCronJobName returns the backup CronJob name for a SteamServer.
+func BackupCronJobName(serverName string) string {
+	return serverName + "-backup"
+}
+
+// BackupLabels returns the labels of the backup CronJob, its Jobs and their pods.
+func BackupLabels(server *boilerrv1alpha1.SteamServer) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       "backup",
+		"app.kubernetes.io/instance":   server.Name,
+		"app.kubernetes.io/managed-by": "boilerr",
+		"boilerr.dev/game":             server.Spec.GameDefinition,
+	}
+}
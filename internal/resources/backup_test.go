@@ -0,0 +1,235 @@
+package resources
+
+import (
+	"slices"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+)
+
+func TestBackupCronJobBuilder_Build(t *testing.T) {
+	tests := []struct {
+		name   string
+		server *boilerrv1alpha1.SteamServer
+		checks func(t *testing.T, cj *batchv1.CronJob)
+	}{
+		{
+			name: "pvc destination",
+			server: &boilerrv1alpha1.SteamServer{
+				ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+				Spec: boilerrv1alpha1.SteamServerSpec{
+					GameDefinition: "valheim",
+					Backup: &boilerrv1alpha1.BackupSpec{
+						Schedule: "0 */6 * * *",
+						TimeZone: "America/Denver",
+						Paths:    []string{"worlds", "adminlist.txt"},
+						Destination: boilerrv1alpha1.BackupDestination{
+							PersistentVolumeClaim: &boilerrv1alpha1.PVCBackupDestination{ClaimName: "backups"},
+						},
+					},
+				},
+			},
+			checks: func(t *testing.T, cj *batchv1.CronJob) {
+				if cj.Name != BackupCronJobName(testServerName) {
+					t.Errorf("expected name %s, got %s", BackupCronJobName(testServerName), cj.Name)
+				}
+				if cj.Spec.Schedule != "0 */6 * * *" || cj.Spec.TimeZone == nil || *cj.Spec.TimeZone != "America/Denver" {
+					t.Errorf("expected schedule and time zone from spec.backup, got %q %v", cj.Spec.Schedule, cj.Spec.TimeZone)
+				}
+				if cj.Spec.ConcurrencyPolicy != batchv1.ForbidConcurrent {
+					t.Errorf("expected Forbid concurrency, got %s", cj.Spec.ConcurrencyPolicy)
+				}
+				if cj.Spec.JobTemplate.Labels["app.kubernetes.io/instance"] != testServerName {
+					t.Errorf("expected Jobs to be labeled with the server, got %v", cj.Spec.JobTemplate.Labels)
+				}
+
+				pod := cj.Spec.JobTemplate.Spec.Template.Spec
+				c := pod.Containers[0]
+				expected := []string{
+					BackupBinaryPath, "create",
+					"-root", ServerFilesMountPath,
+					"-paths", "worlds,adminlist.txt",
+					"-name", testServerName,
+					"-retention", "7",
+					"-dir", BackupDestinationMountPath + "/" + testServerName,
+				}
+				if !slices.Equal(c.Command, expected) {
+					t.Errorf("expected command %v, got %v", expected, c.Command)
+				}
+
+				var serverVolume, destVolume *corev1.Volume
+				for i := range pod.Volumes {
+					switch pod.Volumes[i].Name {
+					case ServerFilesVolumeName:
+						serverVolume = &pod.Volumes[i]
+					case BackupDestinationVolumeName:
+						destVolume = &pod.Volumes[i]
+					}
+				}
+				if serverVolume == nil || serverVolume.PersistentVolumeClaim.ClaimName != PVCName(testServerName) ||
+					!serverVolume.PersistentVolumeClaim.ReadOnly {
+					t.Errorf("expected the server PVC mounted read-only, got %v", serverVolume)
+				}
+				if destVolume == nil || destVolume.PersistentVolumeClaim.ClaimName != "backups" {
+					t.Errorf("expected the backup PVC volume, got %v", destVolume)
+				}
+			},
+		},
+		{
+			name: "s3 destination",
+			server: &boilerrv1alpha1.SteamServer{
+				ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+				Spec: boilerrv1alpha1.SteamServerSpec{
+					GameDefinition: "valheim",
+					Backup: &boilerrv1alpha1.BackupSpec{
+						Schedule:  "0 */6 * * *",
+						Paths:     []string{"worlds"},
+						Retention: 3,
+						Destination: boilerrv1alpha1.BackupDestination{
+							S3: &boilerrv1alpha1.S3BackupDestination{
+								Endpoint:             "http://minio.minio:9000",
+								Bucket:               "saves",
+								Prefix:               "valheim/",
+								CredentialsSecretRef: corev1.LocalObjectReference{Name: "minio-creds"},
+							},
+						},
+					},
+				},
+			},
+			checks: func(t *testing.T, cj *batchv1.CronJob) {
+				if cj.Spec.TimeZone != nil {
+					t.Errorf("expected no time zone without backup.timeZone, got %q", *cj.Spec.TimeZone)
+				}
+				c := cj.Spec.JobTemplate.Spec.Template.Spec.Containers[0]
+				for _, arg := range []string{"-retention", "3", "-s3-endpoint", "http://minio.minio:9000", "-s3-bucket", "saves"} {
+					if !slices.Contains(c.Command, arg) {
+						t.Errorf("expected %q in command %v", arg, c.Command)
+					}
+				}
+				if slices.Contains(c.Command, "-s3-region") {
+					t.Errorf("expected no region without s3.region, got %v", c.Command)
+				}
+				if len(c.Env) != 2 || c.Env[0].ValueFrom.SecretKeyRef.Name != "minio-creds" {
+					t.Errorf("expected credentials from the Secret, got %v", c.Env)
+				}
+				for _, v := range cj.Spec.JobTemplate.Spec.Template.Spec.Volumes {
+					if v.Name == BackupDestinationVolumeName {
+						t.Error("expected no backup PVC volume for s3")
+					}
+				}
+			},
+		},
+		{
+			name: "suspended while a restore holds the lock",
+			server: &boilerrv1alpha1.SteamServer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        testServerName,
+					Namespace:   testNamespace,
+					Annotations: map[string]string{boilerrv1alpha1.RestoreLockAnnotation: "restore-1"},
+				},
+				Spec: boilerrv1alpha1.SteamServerSpec{
+					GameDefinition: "valheim",
+					Backup: &boilerrv1alpha1.BackupSpec{
+						Schedule: "0 */6 * * *",
+						Paths:    []string{"worlds"},
+						Destination: boilerrv1alpha1.BackupDestination{
+							PersistentVolumeClaim: &boilerrv1alpha1.PVCBackupDestination{ClaimName: "backups"},
+						},
+					},
+				},
+			},
+			checks: func(t *testing.T, cj *batchv1.CronJob) {
+				if cj.Spec.Suspend == nil || !*cj.Spec.Suspend {
+					t.Error("expected backups to be suspended while a restore holds the lock")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cj, err := NewBackupCronJobBuilder(tt.server).WithImage("ghcr.io/craightonh/boilerr:v1").Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			tt.checks(t, cj)
+		})
+	}
+}
+
+func TestBackupCronJobBuilder_InvalidDestination(t *testing.T) {
+	tests := []struct {
+		name string
+		dest boilerrv1alpha1.BackupDestination
+	}{
+		{name: "none", dest: boilerrv1alpha1.BackupDestination{}},
+		{name: "both", dest: boilerrv1alpha1.BackupDestination{
+			PersistentVolumeClaim: &boilerrv1alpha1.PVCBackupDestination{ClaimName: "backups"},
+			S3:                    &boilerrv1alpha1.S3BackupDestination{Endpoint: "http://minio:9000", Bucket: "saves"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &boilerrv1alpha1.SteamServer{
+				ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+				Spec: boilerrv1alpha1.SteamServerSpec{
+					GameDefinition: "valheim",
+					Backup: &boilerrv1alpha1.BackupSpec{
+						Schedule:    "0 */6 * * *",
+						Paths:       []string{"worlds"},
+						Destination: tt.dest,
+					},
+				},
+			}
+			if _, err := NewBackupCronJobBuilder(server).Build(); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestBackupCronJobBuilder_Affinity(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			GameDefinition: "valheim",
+			Backup: &boilerrv1alpha1.BackupSpec{
+				Schedule: "0 */6 * * *",
+				Paths:    []string{"worlds"},
+				Destination: boilerrv1alpha1.BackupDestination{
+					PersistentVolumeClaim: &boilerrv1alpha1.PVCBackupDestination{ClaimName: "backups"},
+				},
+			},
+		},
+	}
+
+	running, err := NewBackupCronJobBuilder(server).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	affinity := running.Spec.JobTemplate.Spec.Template.Spec.Affinity.PodAffinity
+	if len(affinity.RequiredDuringSchedulingIgnoredDuringExecution) != 1 || len(affinity.PreferredDuringSchedulingIgnoredDuringExecution) != 0 {
+		t.Errorf("expected the server pod's node to be required while it runs, got %+v", affinity)
+	}
+
+	server.Spec.Suspended = true
+	suspended, err := NewBackupCronJobBuilder(server).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	affinity = suspended.Spec.JobTemplate.Spec.Template.Spec.Affinity.PodAffinity
+	if len(affinity.RequiredDuringSchedulingIgnoredDuringExecution) != 0 || len(affinity.PreferredDuringSchedulingIgnoredDuringExecution) != 1 {
+		t.Errorf("expected the server pod's node to only be preferred while suspended, got %+v", affinity)
+	}
+
+	if running.Annotations[SpecHashAnnotation] == "" ||
+		running.Annotations[SpecHashAnnotation] == suspended.Annotations[SpecHashAnnotation] {
+		t.Errorf("expected the spec hash to change with the spec, got %q and %q",
+			running.Annotations[SpecHashAnnotation], suspended.Annotations[SpecHashAnnotation])
+	}
+}
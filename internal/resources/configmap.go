@@ -0,0 +1,136 @@
+package resources
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/config"
+)
+
+// ConfigFilesVolumeName is the volume name for rendered config files.
+const ConfigFilesVolumeName = "config-files"
+
+// configFileSource is a config file before rendering.
+type configFileSource struct {
+	path     string
+	content  string
+	template bool
+	modList  bool
+}
+
+// ConfigMapBuilder builds the config file ConfigMap for a SteamServer.
+type ConfigMapBuilder struct {
+	server  *boilerrv1alpha1.SteamServer
+	gameDef *boilerrv1alpha1.GameDefinition
+}
+
+// NewConfigMapBuilder creates a new ConfigMapBuilder.
+// gameDef can be nil for backwards compatibility (fallback mode).
+func NewConfigMapBuilder(server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) *ConfigMapBuilder {
+	return &ConfigMapBuilder{server: server, gameDef: gameDef}
+}
+
+// Build creates the ConfigMap holding all config files for the SteamServer.
+// GameDefinition templates are rendered against the resolved config values.
+// Returns nil if there are no config files to mount.
+func (b *ConfigMapBuilder) Build() (*corev1.ConfigMap, error) {
+	sources := mergeConfigFiles(b.server, b.gameDef)
+	if len(sources) == 0 {
+		return nil, nil
+	}
+
+	values, _ := config.ResolveConfigValues(b.server.Spec.Config, gameDefConfigSchema(b.gameDef))
+
+	data := make(map[string]string, len(sources))
+	for i, src := range sources {
+		content := src.content
+		var err error
+		switch {
+		case src.modList:
+			content, err = renderModList(content, b.server, b.gameDef)
+		case src.template:
+			content, err = config.InterpolateString(content, values)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("config file %q: %w", src.path, err)
+		}
+		if key, ok := config.SecretReference(content, b.server.Spec.Config); ok && src.template {
+			return nil, fmt.Errorf("config file %q uses secret config key %q; secret values can only be used in env and args", src.path, key)
+		}
+		data[configFileKey(i)] = content
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName(b.server.Name),
+			Namespace: b.server.Namespace,
+			Labels:    b.labels(),
+		},
+		Data: data,
+	}, nil
+}
+
+// labels returns the common labels for the ConfigMap.
+func (b *ConfigMapBuilder) labels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       "steamserver",
+		"app.kubernetes.io/instance":   b.server.Name,
+		"app.kubernetes.io/managed-by": "boilerr",
+		"boilerr.dev/game":             b.server.Spec.GameDefinition,
+	}
+}
+
+// mergeConfigFiles returns the config files to mount, in a stable order:
+// GameDefinition.ConfigFiles, then configSchema "configFile" mappings (sorted by key),
+// then the Workshop mod list, then SteamServer.ConfigFiles. A SteamServer file replaces an earlier file with the same path.
+func mergeConfigFiles(server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) []configFileSource {
+	var sources []configFileSource
+	seen := make(map[string]int)
+
+	add := func(src configFileSource) {
+		if idx, exists := seen[src.path]; exists {
+			sources[idx] = src
+			return
+		}
+		seen[src.path] = len(sources)
+		sources = append(sources, src)
+	}
+
+	if gameDef != nil {
+		for _, cf := range gameDef.Spec.ConfigFiles {
+			add(configFileSource{path: cf.Path, content: cf.Content, template: true})
+		}
+
+		schema := gameDef.Spec.ConfigSchema
+		values, _ := config.ResolveConfigValues(server.Spec.Config, schema)
+		for _, mapped := range config.ConfigFileMappings(schema, values) {
+			add(configFileSource{path: mapped.Path, content: mapped.Content, template: true})
+		}
+
+		if modList := modListFile(server, gameDef); modList != nil {
+			add(*modList)
+		}
+	}
+
+	for _, cf := range server.Spec.ConfigFiles {
+		add(configFileSource{path: cf.Path, content: cf.Content})
+	}
+
+	return sources
+}
+
+// gameDefConfigSchema returns the GameDefinition config schema, or an empty schema in fallback mode.
+func gameDefConfigSchema(gameDef *boilerrv1alpha1.GameDefinition) map[string]boilerrv1alpha1.ConfigSchemaEntry {
+	if gameDef != nil && gameDef.Spec.ConfigSchema != nil {
+		return gameDef.Spec.ConfigSchema
+	}
+	return make(map[string]boilerrv1alpha1.ConfigSchemaEntry)
+}
+
+// configFileKey returns the ConfigMap key for the config file at index i.
+func configFileKey(i int) string {
+	return fmt.Sprintf("config-%d", i)
+}
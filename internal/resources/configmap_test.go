@@ -0,0 +1,297 @@
+package resources
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/config"
+)
+
+func TestConfigMapBuilder_Build(t *testing.T) {
+	tests := []struct {
+		name    string
+		server  *boilerrv1alpha1.SteamServer
+		gameDef *boilerrv1alpha1.GameDefinition
+		checks  func(t *testing.T, cm *corev1.ConfigMap)
+	}{
+		{
+			name: "no config files returns nil",
+			server: &boilerrv1alpha1.SteamServer{
+				ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+				Spec:       boilerrv1alpha1.SteamServerSpec{GameDefinition: "valheim"},
+			},
+			checks: func(t *testing.T, cm *corev1.ConfigMap) {
+				if cm != nil {
+					t.Errorf("expected nil ConfigMap, got %v", cm)
+				}
+			},
+		},
+		{
+			name: "server config files kept literal",
+			server: &boilerrv1alpha1.SteamServer{
+				ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+				Spec: boilerrv1alpha1.SteamServerSpec{
+					GameDefinition: "valheim",
+					ConfigFiles: []boilerrv1alpha1.ConfigFile{
+						{Path: "/config/server.cfg", Content: "hostname {{.Config.serverName}}"},
+					},
+				},
+			},
+			checks: func(t *testing.T, cm *corev1.ConfigMap) {
+				if cm.Name != ConfigMapName(testServerName) {
+					t.Errorf("expected name %q, got %s", ConfigMapName(testServerName), cm.Name)
+				}
+				if cm.Data["config-0"] != "hostname {{.Config.serverName}}" {
+					t.Errorf("expected literal content, got %q", cm.Data["config-0"])
+				}
+			},
+		},
+		{
+			name: "game definition templates rendered with config values",
+			server: &boilerrv1alpha1.SteamServer{
+				ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+				Spec: boilerrv1alpha1.SteamServerSpec{
+					GameDefinition: "valheim",
+					Config: map[string]boilerrv1alpha1.ConfigValue{
+						"admins":     {Value: "76561198012345678"},
+						"serverName": {Value: "Vikings"},
+					},
+				},
+			},
+			gameDef: &boilerrv1alpha1.GameDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+				Spec: boilerrv1alpha1.GameDefinitionSpec{
+					AppId:   896660,
+					Command: "./valheim_server.x86_64",
+					ConfigSchema: map[string]boilerrv1alpha1.ConfigSchemaEntry{
+						"admins":     {},
+						"serverName": {Default: "My Server"},
+						"settings": {
+							Default: "on",
+							MapTo: &boilerrv1alpha1.ConfigMapping{
+								Type:     "configFile",
+								Path:     "/data/server/settings.json",
+								Template: `{"name": "{{.Config.serverName}}"}`,
+							},
+						},
+					},
+					ConfigFiles: []boilerrv1alpha1.ConfigFileTemplate{
+						{Path: "/data/saves/adminlist.txt", Content: "{{.Config.admins}}"},
+						{Path: "/data/server/game.ini", Content: "ServerName={{.Config.serverName}}"},
+					},
+				},
+			},
+			checks: func(t *testing.T, cm *corev1.ConfigMap) {
+				if len(cm.Data) != 3 {
+					t.Fatalf("expected 3 files, got %d: %v", len(cm.Data), cm.Data)
+				}
+				if cm.Data["config-0"] != "76561198012345678" {
+					t.Errorf("expected rendered adminlist, got %q", cm.Data["config-0"])
+				}
+				if cm.Data["config-1"] != "ServerName=Vikings" {
+					t.Errorf("expected rendered game.ini, got %q", cm.Data["config-1"])
+				}
+				if cm.Data["config-2"] != `{"name": "Vikings"}` {
+					t.Errorf("expected rendered configFile mapping, got %q", cm.Data["config-2"])
+				}
+			},
+		},
+		{
+			name: "config keys without a value render empty",
+			server: &boilerrv1alpha1.SteamServer{
+				ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+				Spec:       boilerrv1alpha1.SteamServerSpec{GameDefinition: "valheim"},
+			},
+			gameDef: &boilerrv1alpha1.GameDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+				Spec: boilerrv1alpha1.GameDefinitionSpec{
+					AppId: 896660,
+					ConfigFiles: []boilerrv1alpha1.ConfigFileTemplate{
+						{Path: "/data/saves/bannedlist.txt", Content: "// Banned\n{{.Config.banned}}\n"},
+					},
+				},
+			},
+			checks: func(t *testing.T, cm *corev1.ConfigMap) {
+				if cm.Data["config-0"] != "// Banned\n\n" {
+					t.Errorf("expected empty bannedlist, got %q", cm.Data["config-0"])
+				}
+			},
+		},
+		{
+			name: "server file wins on path collision",
+			server: &boilerrv1alpha1.SteamServer{
+				ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+				Spec: boilerrv1alpha1.SteamServerSpec{
+					GameDefinition: "valheim",
+					ConfigFiles: []boilerrv1alpha1.ConfigFile{
+						{Path: "/data/server/game.ini", Content: "custom"},
+					},
+				},
+			},
+			gameDef: &boilerrv1alpha1.GameDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+				Spec: boilerrv1alpha1.GameDefinitionSpec{
+					AppId:   896660,
+					Command: "./valheim_server.x86_64",
+					ConfigSchema: map[string]boilerrv1alpha1.ConfigSchemaEntry{
+						"admins":     {},
+						"serverName": {Default: "My Server"},
+						"settings": {
+							Default: "on",
+							MapTo: &boilerrv1alpha1.ConfigMapping{
+								Type:     "configFile",
+								Path:     "/data/server/settings.json",
+								Template: `{"name": "{{.Config.serverName}}"}`,
+							},
+						},
+					},
+					ConfigFiles: []boilerrv1alpha1.ConfigFileTemplate{
+						{Path: "/data/saves/adminlist.txt", Content: "{{.Config.admins}}"},
+						{Path: "/data/server/game.ini", Content: "ServerName={{.Config.serverName}}"},
+					},
+				},
+			},
+			checks: func(t *testing.T, cm *corev1.ConfigMap) {
+				if len(cm.Data) != 3 {
+					t.Fatalf("expected 3 files, got %d: %v", len(cm.Data), cm.Data)
+				}
+				if cm.Data["config-1"] != "custom" {
+					t.Errorf("expected server content to replace game.ini, got %q", cm.Data["config-1"])
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm, err := NewConfigMapBuilder(tt.server, tt.gameDef).Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			tt.checks(t, cm)
+		})
+	}
+}
+
+func TestConfigMapBuilder_BuildErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]boilerrv1alpha1.ConfigValue
+		gameDef *boilerrv1alpha1.GameDefinition
+		wantErr string
+	}{
+		{
+			name: "invalid template",
+			gameDef: &boilerrv1alpha1.GameDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+				Spec: boilerrv1alpha1.GameDefinitionSpec{
+					AppId: 896660,
+					ConfigFiles: []boilerrv1alpha1.ConfigFileTemplate{
+						{Path: "/data/server/broken.ini", Content: "{{.Config.serverName"},
+					},
+				},
+			},
+			wantErr: "broken.ini",
+		},
+		{
+			name: "secret value in a config file",
+			config: map[string]boilerrv1alpha1.ConfigValue{
+				"password": {SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "valheim-secrets"},
+					Key:                  "server-password",
+				}},
+			},
+			gameDef: &boilerrv1alpha1.GameDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+				Spec: boilerrv1alpha1.GameDefinitionSpec{
+					AppId: 896660,
+					ConfigSchema: map[string]boilerrv1alpha1.ConfigSchemaEntry{
+						"password": {Secret: true},
+					},
+					ConfigFiles: []boilerrv1alpha1.ConfigFileTemplate{
+						{Path: "/data/server/game.ini", Content: "Password={{.Config.password}}"},
+					},
+				},
+			},
+			wantErr: `secret config key "password"`,
+		},
+		{
+			name: "secret value in a configFile mapping",
+			config: map[string]boilerrv1alpha1.ConfigValue{
+				"rconPassword": {SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "secrets"},
+					Key:                  "rcon",
+				}},
+			},
+			gameDef: &boilerrv1alpha1.GameDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+				Spec: boilerrv1alpha1.GameDefinitionSpec{
+					AppId: 896660,
+					ConfigSchema: map[string]boilerrv1alpha1.ConfigSchemaEntry{
+						"rconPassword": {
+							Secret: true,
+							MapTo:  &boilerrv1alpha1.ConfigMapping{Type: "configFile", Path: "/data/server/rcon.txt"},
+						},
+					},
+				},
+			},
+			wantErr: `secret config key "rconPassword"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &boilerrv1alpha1.SteamServer{
+				ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+				Spec:       boilerrv1alpha1.SteamServerSpec{GameDefinition: "valheim", Config: tt.config},
+			}
+			_, err := NewConfigMapBuilder(server, tt.gameDef).Build()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Build() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigMapBuilder_BuildShippedValheim(t *testing.T) {
+	manifest, err := os.ReadFile(filepath.Join("..", "..", "gamedefinitions", "valheim.yaml"))
+	if err != nil {
+		t.Fatalf("reading valheim.yaml: %v", err)
+	}
+	docs := strings.Split(string(manifest), "\n---\n")
+	gameDef := &boilerrv1alpha1.GameDefinition{}
+	if err := yaml.Unmarshal([]byte(docs[0]), gameDef); err != nil {
+		t.Fatalf("decoding GameDefinition: %v", err)
+	}
+	server := &boilerrv1alpha1.SteamServer{}
+	if err := yaml.Unmarshal([]byte(docs[1]), server); err != nil {
+		t.Fatalf("decoding SteamServer: %v", err)
+	}
+
+	if err := config.ValidateConfig(server.Spec.Config, gameDef.Spec.ConfigSchema); err != nil {
+		t.Fatalf("example SteamServer config is invalid: %v", err)
+	}
+
+	cm, err := NewConfigMapBuilder(server, gameDef).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if len(cm.Data) != 3 {
+		t.Fatalf("expected 3 files, got %d: %v", len(cm.Data), cm.Data)
+	}
+	for key, content := range cm.Data {
+		if strings.Contains(content, "<no value>") {
+			t.Errorf("%s rendered a missing value: %q", key, content)
+		}
+	}
+	if !strings.Contains(cm.Data["config-0"], "76561198012345678") {
+		t.Errorf("expected the example admin in adminlist.txt, got %q", cm.Data["config-0"])
+	}
+}
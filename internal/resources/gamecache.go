@@ -0,0 +1,233 @@
+package resources
+
+import (
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/steamcmd"
+)
+
+const (
+	// GameCacheVolumeName is the volume name for the game cache.
+	GameCacheVolumeName = "gamecache"
+	// GameCacheMountPath is where the game cache is mounted, both when populating and reading it.
+	GameCacheMountPath = "/gamecache"
+	// GameCacheContainerName is the name of the init container that links the game cache into the install directory.
+	GameCacheContainerName = "game-cache"
+	// GameCacheComponent is the app.kubernetes.io/component label of game cache volumes and Jobs.
+	GameCacheComponent = "game-cache"
+	// AppIDLabel records the Steam App ID of server pods and game caches.
+	AppIDLabel = "boilerr.dev/app-id"
+	// BuildIDLabel records the Steam build held by a game cache.
+	BuildIDLabel = "boilerr.dev/build-id"
+)
+
+// GameCacheBuilder builds the volume holding one Steam build of an app, shared read-only by
+// the SteamServers of a namespace with spec.gameCache, and the Job that installs the build into it.
+type GameCacheBuilder struct {
+	server       *boilerrv1alpha1.SteamServer
+	gameDef      *boilerrv1alpha1.GameDefinition
+	buildID      string
+	storageClass string
+}
+
+// NewGameCacheBuilder creates a new GameCacheBuilder for the build a SteamServer installs.
+// gameDef can be nil for backwards compatibility (fallback mode).
+func NewGameCacheBuilder(server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition, buildID string) *GameCacheBuilder {
+	return &GameCacheBuilder{server: server, gameDef: gameDef, buildID: buildID}
+}
+
+// WithStorageClass sets the StorageClass of the game cache volume.
+func (b *GameCacheBuilder) WithStorageClass(name string) *GameCacheBuilder {
+	b.storageClass = name
+	return b
+}
+
+// Name returns the name of the game cache volume and Job.
+func (b *GameCacheBuilder) Name() string {
+	return GameCacheName(AppID(b.server, b.gameDef), b.buildID)
+}
+
+// BuildPVC creates the game cache PVC. The Job writes it once, then servers mount it read-only,
+// so its StorageClass must support both ReadWriteOnce and ReadOnlyMany.
+// Sized by GameDefinition.defaultStorage, since it holds only the game files.
+func (b *GameCacheBuilder) BuildPVC() *corev1.PersistentVolumeClaim {
+	var storageClass *string
+	if b.storageClass != "" {
+		storageClass = &b.storageClass
+	}
+
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      b.Name(),
+			Namespace: b.server.Namespace,
+			Labels:    b.labels(),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{
+				corev1.ReadWriteOnce,
+				corev1.ReadOnlyMany,
+			},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: b.size(),
+				},
+			},
+			StorageClassName: storageClass,
+		},
+	}
+}
+
+// BuildJob creates the Job that installs the build into the game cache with SteamCMD.
+// The Job fails if Steam serves a different build than the cache is named for,
+// so a cache never holds a build other than its own.
+func (b *GameCacheBuilder) BuildJob() *batchv1.Job {
+	sts := NewStatefulSetBuilder(b.server, b.gameDef)
+	appID := sts.getAppID()
+	args := steamcmd.NewCommandBuilder(steamcmd.CommandConfig{
+		AppID:        appID,
+		InstallDir:   GameCacheMountPath,
+		Anonymous:    sts.isAnonymous(),
+		Beta:         b.server.Spec.Beta,
+		BetaPassword: sts.hasBetaPassword(),
+		Validate:     true,
+	}).Build()
+
+	// Pass the SteamCMD arguments as positional parameters, with $0 naming the script
+	script := fmt.Sprintf(`steamcmd "$@" || exit $?; build=$(%s); `+
+		`if [ "$build" != %q ]; then echo "SteamCMD installed build ${build:-none} instead of %s" | tee %s; exit 1; fi`,
+		steamcmd.BuildIDScript(GameCacheMountPath, appID), b.buildID, b.buildID, corev1.TerminationMessagePathDefault)
+
+	labels := b.labels()
+	backoffLimit := int32(2)
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      b.Name(),
+			Namespace: b.server.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:    InitContainerName,
+							Image:   sts.getImage(),
+							Command: append([]string{"/bin/sh", "-c", script, "steamcmd"}, args...),
+							Env:     sts.buildInitEnvVars(),
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      GameCacheVolumeName,
+									MountPath: GameCacheMountPath,
+								},
+							},
+							TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: GameCacheVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: b.Name(),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// size returns the game cache volume size.
+// Fallback: GameDefinition.DefaultStorage -> DefaultStorageSize
+func (b *GameCacheBuilder) size() resource.Quantity {
+	if b.gameDef != nil && b.gameDef.Spec.DefaultStorage != "" {
+		if qty, err := resource.ParseQuantity(b.gameDef.Spec.DefaultStorage); err == nil {
+			return qty
+		}
+	}
+	return resource.MustParse(DefaultStorageSize)
+}
+
+// labels returns the labels of the game cache volume and Job.
+func (b *GameCacheBuilder) labels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       "steamserver",
+		"app.kubernetes.io/instance":   b.Name(),
+		"app.kubernetes.io/component":  GameCacheComponent,
+		"app.kubernetes.io/managed-by": "boilerr",
+		AppIDLabel:                     fmt.Sprintf("%d", AppID(b.server, b.gameDef)),
+		BuildIDLabel:                   b.buildID,
+	}
+}
+
+// GameCacheLabels returns the labels selecting all game cache volumes.
+func GameCacheLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/component":  GameCacheComponent,
+		"app.kubernetes.io/managed-by": "boilerr",
+	}
+}
+
+// GameCacheName returns the name of the game cache volume for a Steam build of an app.
+func GameCacheName(appID int32, buildID string) string {
+	return fmt.Sprintf("gamecache-%d-%s", appID, buildID)
+}
+
+// GameCacheClaim returns the game cache volume a pod template mounts, or "" if it has none.
+func GameCacheClaim(template *corev1.PodTemplateSpec) string {
+	for _, v := range template.Spec.Volumes {
+		if v.Name == GameCacheVolumeName && v.PersistentVolumeClaim != nil {
+			return v.PersistentVolumeClaim.ClaimName
+		}
+	}
+	return ""
+}
+
+// buildGameCacheContainer creates the init container that replaces SteamCMD when the server
+// installs from a game cache. It links every cached file into the install directory, so the
+// game finds a complete install while files it creates stay on the server volume.
+// Links left from a previous build are removed first.
+func (b *StatefulSetBuilder) buildGameCacheContainer() corev1.Container {
+	script := fmt.Sprintf(`set -e; mkdir -p %[1]q; find %[1]q -lname '%[2]s/*' -delete; `+
+		`cp -as --remove-destination %[2]s/. %[1]q/`,
+		b.getInstallDir(), GameCacheMountPath)
+
+	return corev1.Container{
+		Name:         GameCacheContainerName,
+		Image:        b.getImage(),
+		Command:      []string{"/bin/sh", "-c", script},
+		VolumeMounts: b.installVolumeMounts(),
+	}
+}
+
+// installVolumeMounts returns the mounts of the init containers that install or read the game files.
+// With a game cache, the cache is mounted read-only where the install directory links to it.
+func (b *StatefulSetBuilder) installVolumeMounts() []corev1.VolumeMount {
+	mounts := []corev1.VolumeMount{b.serverFilesVolumeMount()}
+	if b.gameCache != "" {
+		mounts = append(mounts, gameCacheVolumeMount())
+	}
+	return mounts
+}
+
+// gameCacheVolumeMount returns the read-only mount of the game cache.
+func gameCacheVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      GameCacheVolumeName,
+		MountPath: GameCacheMountPath,
+		ReadOnly:  true,
+	}
+}
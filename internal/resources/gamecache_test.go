@@ -0,0 +1,153 @@
+package resources
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+)
+
+func TestGameCacheBuilder_BuildPVC(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			GameDefinition: "valheim",
+			GameCache:      true,
+		},
+	}
+	gameDef := &boilerrv1alpha1.GameDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+		Spec: boilerrv1alpha1.GameDefinitionSpec{
+			AppId:          896660,
+			Command:        "/serverfiles/valheim_server.x86_64",
+			Ports:          []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+			DefaultStorage: "5Gi",
+		},
+	}
+
+	pvc := NewGameCacheBuilder(server, gameDef, "15632571").WithStorageClass("nfs").BuildPVC()
+	if pvc.Name != "gamecache-896660-15632571" || pvc.Namespace != testNamespace {
+		t.Errorf("expected PVC %s/gamecache-896660-15632571, got %s/%s", testNamespace, pvc.Namespace, pvc.Name)
+	}
+	if pvc.Labels[BuildIDLabel] != "15632571" || pvc.Labels[AppIDLabel] != "896660" {
+		t.Errorf("expected app and build labels, got %v", pvc.Labels)
+	}
+	for k, v := range GameCacheLabels() {
+		if pvc.Labels[k] != v {
+			t.Errorf("expected label %s=%s, got %q", k, v, pvc.Labels[k])
+		}
+	}
+	if len(pvc.Spec.AccessModes) != 2 || pvc.Spec.AccessModes[1] != corev1.ReadOnlyMany {
+		t.Errorf("expected ReadWriteOnce and ReadOnlyMany, got %v", pvc.Spec.AccessModes)
+	}
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName != "nfs" {
+		t.Errorf("expected StorageClass nfs, got %v", pvc.Spec.StorageClassName)
+	}
+	if size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; size.String() != "5Gi" {
+		t.Errorf("expected the GameDefinition default storage, got %s", size.String())
+	}
+}
+
+func TestGameCacheBuilder_BuildJob(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			GameDefinition: "valheim",
+			GameCache:      true,
+			Beta:           "public-test",
+		},
+	}
+	gameDef := &boilerrv1alpha1.GameDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+		Spec: boilerrv1alpha1.GameDefinitionSpec{
+			AppId:          896660,
+			Command:        "/serverfiles/valheim_server.x86_64",
+			Ports:          []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+			DefaultStorage: "5Gi",
+		},
+	}
+
+	job := NewGameCacheBuilder(server, gameDef, "15632571").BuildJob()
+	if job.Name != "gamecache-896660-15632571" {
+		t.Errorf("expected Job gamecache-896660-15632571, got %s", job.Name)
+	}
+
+	pod := job.Spec.Template.Spec
+	if pod.RestartPolicy != corev1.RestartPolicyNever {
+		t.Errorf("expected restart policy Never, got %s", pod.RestartPolicy)
+	}
+	command := strings.Join(pod.Containers[0].Command, " ")
+	for _, want := range []string{"+force_install_dir " + GameCacheMountPath, "+app_update 896660", "-beta public-test", `!= "15632571"`} {
+		if !strings.Contains(command, want) {
+			t.Errorf("expected command to contain %q, got %s", want, command)
+		}
+	}
+	if v := pod.Volumes[0]; v.PersistentVolumeClaim == nil || v.PersistentVolumeClaim.ClaimName != job.Name {
+		t.Errorf("expected the game cache volume, got %v", v)
+	}
+}
+
+func TestStatefulSetBuilder_GameCache(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			GameDefinition: "valheim",
+			GameCache:      true,
+		},
+	}
+	gameDef := &boilerrv1alpha1.GameDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+		Spec: boilerrv1alpha1.GameDefinitionSpec{
+			AppId:          896660,
+			Command:        "/serverfiles/valheim_server.x86_64",
+			Ports:          []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+			DefaultStorage: "5Gi",
+		},
+	}
+	cache := GameCacheName(896660, "15632571")
+
+	sts := NewStatefulSetBuilder(server, gameDef).WithTargetBuild("15632571").WithGameCache(cache).Build()
+	pod := sts.Spec.Template.Spec
+
+	if pod.InitContainers[0].Name != GameCacheContainerName {
+		t.Errorf("expected the game cache container to replace SteamCMD, got %s", pod.InitContainers[0].Name)
+	}
+	if GameCacheClaim(&sts.Spec.Template) != cache {
+		t.Errorf("expected game cache %s, got %q", cache, GameCacheClaim(&sts.Spec.Template))
+	}
+	for _, v := range pod.Volumes {
+		if v.Name == GameCacheVolumeName && !v.PersistentVolumeClaim.ReadOnly {
+			t.Error("expected the game cache volume to be read-only")
+		}
+	}
+	for _, c := range []corev1.Container{pod.InitContainers[0], pod.InitContainers[1], pod.Containers[0]} {
+		mounted := false
+		for _, m := range c.VolumeMounts {
+			if m.Name == GameCacheVolumeName && m.MountPath == GameCacheMountPath && m.ReadOnly {
+				mounted = true
+			}
+		}
+		if !mounted {
+			t.Errorf("expected container %s to mount the game cache read-only", c.Name)
+		}
+	}
+
+	// A new cached build changes what is installed
+	next := NewStatefulSetBuilder(server, gameDef).WithTargetBuild("15700000").
+		WithGameCache(GameCacheName(896660, "15700000")).Build()
+	if InstallHash(&sts.Spec.Template) == "" || InstallHash(&sts.Spec.Template) == InstallHash(&next.Spec.Template) {
+		t.Error("expected a new cached build to change the install hash")
+	}
+
+	// Without a game cache, SteamCMD installs the game
+	plain := NewStatefulSetBuilder(server, gameDef).Build()
+	if plain.Spec.Template.Spec.InitContainers[0].Name != InitContainerName {
+		t.Errorf("expected the SteamCMD container, got %s", plain.Spec.Template.Spec.InitContainers[0].Name)
+	}
+	if GameCacheClaim(&plain.Spec.Template) != "" {
+		t.Error("expected no game cache volume")
+	}
+}
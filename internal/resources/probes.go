@@ -0,0 +1,250 @@
+package resources
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+)
+
+const (
+	// ProbeInstallContainerName is the name of the init container that installs a2sprobe.
+	ProbeInstallContainerName = "a2sprobe-install"
+	// ProbeToolsVolumeName is the shared volume holding the a2sprobe binary.
+	ProbeToolsVolumeName = "boilerr-tools"
+	// ProbeToolsMountPath is where the a2sprobe binary volume is mounted.
+	ProbeToolsMountPath = "/boilerr/bin"
+	// ProbeBinaryPath is the path of a2sprobe in the operator image.
+	ProbeBinaryPath = "/a2sprobe"
+	// DefaultA2STimeoutSeconds is used when healthCheck.a2s omits timeoutSeconds.
+	DefaultA2STimeoutSeconds int32 = 3
+	// QueryPortName is the port name used for A2S queries when no A2S health check is defined.
+	QueryPortName = "query"
+)
+
+const (
+	// DefaultHealthCheckInitialDelaySeconds is used when GameDefinition.healthCheck omits initialDelaySeconds.
+	DefaultHealthCheckInitialDelaySeconds int32 = 120
+	// DefaultHealthCheckPeriodSeconds is used when GameDefinition.healthCheck omits periodSeconds.
+	DefaultHealthCheckPeriodSeconds int32 = 30
+	// DefaultHealthCheckFailureThreshold is the readiness/liveness failure threshold.
+	DefaultHealthCheckFailureThreshold int32 = 3
+	// DefaultStartupProbePeriodSeconds is the period between startup checks.
+	DefaultStartupProbePeriodSeconds int32 = 10
+	// DefaultStartupFailureThreshold allows 10 minutes of startup checks before a restart.
+	DefaultStartupFailureThreshold int32 = 60
+)
+
+// probeSet holds the probes for the game server container.
+type probeSet struct {
+	startup   *corev1.Probe
+	readiness *corev1.Probe
+	liveness  *corev1.Probe
+}
+
+// buildProbes creates the startup, readiness and liveness probes from GameDefinition.HealthCheck.
+// The startup probe absorbs long world loads so the liveness probe only applies once the server is up.
+// Returns an empty probeSet if no health check is defined or its port cannot be resolved, and for
+// A2S health checks without a probe image (the controller checks those itself).
+func (b *StatefulSetBuilder) buildProbes() probeSet {
+	handler := b.probeHandler()
+	if handler == nil {
+		return probeSet{}
+	}
+
+	initialDelay, period, failureThreshold, startupThreshold := b.probeThresholds()
+
+	// Exec probes must outlive the query timeout inside them
+	var timeout int32
+	if handler.Exec != nil {
+		timeout = a2sTimeoutSeconds(b.gameDef.Spec.HealthCheck.A2S) + 1
+	}
+
+	return probeSet{
+		startup: &corev1.Probe{
+			ProbeHandler:        *handler,
+			InitialDelaySeconds: initialDelay,
+			PeriodSeconds:       DefaultStartupProbePeriodSeconds,
+			TimeoutSeconds:      timeout,
+			FailureThreshold:    startupThreshold,
+		},
+		readiness: &corev1.Probe{
+			ProbeHandler:     *handler,
+			PeriodSeconds:    period,
+			TimeoutSeconds:   timeout,
+			FailureThreshold: failureThreshold,
+		},
+		liveness: &corev1.Probe{
+			ProbeHandler:     *handler,
+			PeriodSeconds:    period,
+			TimeoutSeconds:   timeout,
+			FailureThreshold: failureThreshold,
+		},
+	}
+}
+
+// usesA2SProbe returns whether the game server uses the a2sprobe exec probe.
+// Requires an A2S health check with a resolvable port and a configured probe image.
+func (b *StatefulSetBuilder) usesA2SProbe() bool {
+	if b.probeImage == "" {
+		return false
+	}
+	_, ok := b.a2sPort()
+	return ok
+}
+
+// a2sPort returns the container port for the A2S health check, if one is configured.
+func (b *StatefulSetBuilder) a2sPort() (int32, bool) {
+	if b.gameDef == nil || b.gameDef.Spec.HealthCheck == nil || b.gameDef.Spec.HealthCheck.A2S == nil {
+		return 0, false
+	}
+	port, ok := b.resolvePort(b.gameDef.Spec.HealthCheck.A2S.Port)
+	if !ok {
+		return 0, false
+	}
+	return port.IntVal, true
+}
+
+// buildProbeInstallContainer creates the init container that copies a2sprobe into the shared volume.
+func (b *StatefulSetBuilder) buildProbeInstallContainer() corev1.Container {
+	return corev1.Container{
+		Name:    ProbeInstallContainerName,
+		Image:   b.probeImage,
+		Command: []string{ProbeBinaryPath, "-install", ProbeToolsMountPath + "/a2sprobe"},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      ProbeToolsVolumeName,
+				MountPath: ProbeToolsMountPath,
+			},
+		},
+	}
+}
+
+// A2SHealthCheckPort returns the container port of the GameDefinition A2S health check.
+// Returns false if the GameDefinition has no A2S health check or its port cannot be resolved.
+func A2SHealthCheckPort(server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) (int32, bool) {
+	return NewStatefulSetBuilder(server, gameDef).a2sPort()
+}
+
+// A2SQueryPort returns the container port to send A2S queries to.
+// Uses the A2S health check port, falling back to a port named "query".
+func A2SQueryPort(server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) (int32, bool) {
+	b := NewStatefulSetBuilder(server, gameDef)
+	if port, ok := b.a2sPort(); ok {
+		return port, true
+	}
+	port, ok := b.resolvePort(intstr.FromString(QueryPortName))
+	if !ok {
+		return 0, false
+	}
+	return port.IntVal, true
+}
+
+// ResolvePort returns the container port for a port number or a port name from the server ports.
+func ResolvePort(server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition, port intstr.IntOrString) (int32, bool) {
+	resolved, ok := NewStatefulSetBuilder(server, gameDef).resolvePort(port)
+	if !ok {
+		return 0, false
+	}
+	return resolved.IntVal, true
+}
+
+// a2sTimeoutSeconds returns the A2S query timeout, applying the default.
+func a2sTimeoutSeconds(hc *boilerrv1alpha1.A2SHealthCheck) int32 {
+	if hc == nil || hc.TimeoutSeconds <= 0 {
+		return DefaultA2STimeoutSeconds
+	}
+	return hc.TimeoutSeconds
+}
+
+// probeHandler returns the probe action for the GameDefinition health check, or nil if none applies.
+func (b *StatefulSetBuilder) probeHandler() *corev1.ProbeHandler {
+	if b.gameDef == nil || b.gameDef.Spec.HealthCheck == nil {
+		return nil
+	}
+	hc := b.gameDef.Spec.HealthCheck
+
+	if hc.A2S != nil {
+		// Without the probe binary, the controller checks A2S through the server info poller
+		if !b.usesA2SProbe() {
+			return nil
+		}
+		port, _ := b.a2sPort()
+		timeout := a2sTimeoutSeconds(hc.A2S)
+		return &corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{
+					ProbeToolsMountPath + "/a2sprobe",
+					"-addr", fmt.Sprintf("127.0.0.1:%d", port),
+					"-timeout", fmt.Sprintf("%ds", timeout),
+				},
+			},
+		}
+	}
+
+	if hc.TCPSocket != nil {
+		port, ok := b.resolvePort(hc.TCPSocket.Port)
+		if !ok {
+			return nil
+		}
+		return &corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: port,
+				Host: hc.TCPSocket.Host,
+			},
+		}
+	}
+
+	return nil
+}
+
+// resolvePort resolves a named port against the server ports.
+// Numeric ports are returned unchanged.
+func (b *StatefulSetBuilder) resolvePort(port intstr.IntOrString) (intstr.IntOrString, bool) {
+	if port.Type == intstr.Int {
+		return port, port.IntVal > 0
+	}
+	for _, p := range b.getPorts() {
+		if p.Name == port.StrVal {
+			return intstr.FromInt32(p.ContainerPort), true
+		}
+	}
+	return port, false
+}
+
+// probeThresholds returns the initial delay, period, failure threshold and startup failure threshold.
+// Fallback: SteamServer.HealthCheck -> GameDefinition.HealthCheck -> defaults
+func (b *StatefulSetBuilder) probeThresholds() (initialDelay, period, failureThreshold, startupThreshold int32) {
+	initialDelay = DefaultHealthCheckInitialDelaySeconds
+	period = DefaultHealthCheckPeriodSeconds
+	failureThreshold = DefaultHealthCheckFailureThreshold
+	startupThreshold = DefaultStartupFailureThreshold
+
+	if hc := b.gameDef.Spec.HealthCheck; hc != nil {
+		if hc.InitialDelaySeconds > 0 {
+			initialDelay = hc.InitialDelaySeconds
+		}
+		if hc.PeriodSeconds > 0 {
+			period = hc.PeriodSeconds
+		}
+	}
+
+	if o := b.server.Spec.HealthCheck; o != nil {
+		if o.InitialDelaySeconds != nil {
+			initialDelay = *o.InitialDelaySeconds
+		}
+		if o.PeriodSeconds != nil {
+			period = *o.PeriodSeconds
+		}
+		if o.FailureThreshold != nil {
+			failureThreshold = *o.FailureThreshold
+		}
+		if o.StartupFailureThreshold != nil {
+			startupThreshold = *o.StartupFailureThreshold
+		}
+	}
+
+	return initialDelay, period, failureThreshold, startupThreshold
+}
@@ -0,0 +1,384 @@
+package resources
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+)
+
+func TestStatefulSetBuilder_Probes(t *testing.T) {
+	tests := []struct {
+		name    string
+		server  *boilerrv1alpha1.SteamServer
+		gameDef *boilerrv1alpha1.GameDefinition
+		checks  func(t *testing.T, c corev1.Container)
+	}{
+		{
+			name: "no health check means no probes",
+			server: &boilerrv1alpha1.SteamServer{
+				ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+				Spec:       boilerrv1alpha1.SteamServerSpec{GameDefinition: "valheim"},
+			},
+			gameDef: &boilerrv1alpha1.GameDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+				Spec: boilerrv1alpha1.GameDefinitionSpec{
+					AppId:   896660,
+					Command: "./valheim_server.x86_64",
+					Ports: []boilerrv1alpha1.ServerPort{
+						{Name: "game", ContainerPort: 2456},
+						{Name: "query", ContainerPort: 2457},
+					},
+				},
+			},
+			checks: func(t *testing.T, c corev1.Container) {
+				if c.StartupProbe != nil || c.ReadinessProbe != nil || c.LivenessProbe != nil {
+					t.Error("expected no probes")
+				}
+			},
+		},
+		{
+			name: "named port resolved against server ports",
+			server: &boilerrv1alpha1.SteamServer{
+				ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+				Spec:       boilerrv1alpha1.SteamServerSpec{GameDefinition: "valheim"},
+			},
+			gameDef: &boilerrv1alpha1.GameDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+				Spec: boilerrv1alpha1.GameDefinitionSpec{
+					AppId:   896660,
+					Command: "./valheim_server.x86_64",
+					Ports: []boilerrv1alpha1.ServerPort{
+						{Name: "game", ContainerPort: 2456},
+						{Name: "query", ContainerPort: 2457},
+					},
+					HealthCheck: &boilerrv1alpha1.HealthCheckSpec{
+						TCPSocket:           &corev1.TCPSocketAction{Port: intstr.FromString("query")},
+						InitialDelaySeconds: 180,
+						PeriodSeconds:       15,
+					},
+				},
+			},
+			checks: func(t *testing.T, c corev1.Container) {
+				for name, p := range map[string]*corev1.Probe{
+					"startup": c.StartupProbe, "readiness": c.ReadinessProbe, "liveness": c.LivenessProbe,
+				} {
+					if p == nil || p.TCPSocket == nil {
+						t.Fatalf("expected %s TCP probe", name)
+					}
+					if p.TCPSocket.Port != intstr.FromInt32(2457) {
+						t.Errorf("expected %s probe on port 2457, got %v", name, p.TCPSocket.Port)
+					}
+				}
+				if c.StartupProbe.InitialDelaySeconds != 180 {
+					t.Errorf("expected startup initial delay 180, got %d", c.StartupProbe.InitialDelaySeconds)
+				}
+				if c.StartupProbe.FailureThreshold != DefaultStartupFailureThreshold {
+					t.Errorf("expected startup failure threshold %d, got %d",
+						DefaultStartupFailureThreshold, c.StartupProbe.FailureThreshold)
+				}
+				if c.ReadinessProbe.PeriodSeconds != 15 {
+					t.Errorf("expected readiness period 15, got %d", c.ReadinessProbe.PeriodSeconds)
+				}
+				if c.LivenessProbe.FailureThreshold != DefaultHealthCheckFailureThreshold {
+					t.Errorf("expected liveness failure threshold %d, got %d",
+						DefaultHealthCheckFailureThreshold, c.LivenessProbe.FailureThreshold)
+				}
+			},
+		},
+		{
+			name: "defaults applied when thresholds omitted",
+			server: &boilerrv1alpha1.SteamServer{
+				ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+				Spec:       boilerrv1alpha1.SteamServerSpec{GameDefinition: "valheim"},
+			},
+			gameDef: &boilerrv1alpha1.GameDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+				Spec: boilerrv1alpha1.GameDefinitionSpec{
+					AppId:   896660,
+					Command: "./valheim_server.x86_64",
+					Ports: []boilerrv1alpha1.ServerPort{
+						{Name: "game", ContainerPort: 2456},
+						{Name: "query", ContainerPort: 2457},
+					},
+					HealthCheck: &boilerrv1alpha1.HealthCheckSpec{
+						TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(2456)},
+					},
+				},
+			},
+			checks: func(t *testing.T, c corev1.Container) {
+				if c.StartupProbe.InitialDelaySeconds != DefaultHealthCheckInitialDelaySeconds {
+					t.Errorf("expected default initial delay, got %d", c.StartupProbe.InitialDelaySeconds)
+				}
+				if c.LivenessProbe.PeriodSeconds != DefaultHealthCheckPeriodSeconds {
+					t.Errorf("expected default period, got %d", c.LivenessProbe.PeriodSeconds)
+				}
+			},
+		},
+		{
+			name: "unknown named port skips probes",
+			server: &boilerrv1alpha1.SteamServer{
+				ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+				Spec:       boilerrv1alpha1.SteamServerSpec{GameDefinition: "valheim"},
+			},
+			gameDef: &boilerrv1alpha1.GameDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+				Spec: boilerrv1alpha1.GameDefinitionSpec{
+					AppId:   896660,
+					Command: "./valheim_server.x86_64",
+					Ports: []boilerrv1alpha1.ServerPort{
+						{Name: "game", ContainerPort: 2456},
+						{Name: "query", ContainerPort: 2457},
+					},
+					HealthCheck: &boilerrv1alpha1.HealthCheckSpec{
+						TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromString("rcon")},
+					},
+				},
+			},
+			checks: func(t *testing.T, c corev1.Container) {
+				if c.StartupProbe != nil || c.ReadinessProbe != nil || c.LivenessProbe != nil {
+					t.Error("expected no probes for unresolvable port")
+				}
+			},
+		},
+		{
+			name: "SteamServer overrides thresholds",
+			server: &boilerrv1alpha1.SteamServer{
+				ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+				Spec: boilerrv1alpha1.SteamServerSpec{
+					GameDefinition: "valheim",
+					HealthCheck: &boilerrv1alpha1.HealthCheckOverride{
+						InitialDelaySeconds:     int32Ptr(30),
+						PeriodSeconds:           int32Ptr(5),
+						FailureThreshold:        int32Ptr(6),
+						StartupFailureThreshold: int32Ptr(120),
+					},
+				},
+			},
+			gameDef: &boilerrv1alpha1.GameDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+				Spec: boilerrv1alpha1.GameDefinitionSpec{
+					AppId:   896660,
+					Command: "./valheim_server.x86_64",
+					Ports: []boilerrv1alpha1.ServerPort{
+						{Name: "game", ContainerPort: 2456},
+						{Name: "query", ContainerPort: 2457},
+					},
+					HealthCheck: &boilerrv1alpha1.HealthCheckSpec{
+						TCPSocket:           &corev1.TCPSocketAction{Port: intstr.FromString("game")},
+						InitialDelaySeconds: 180,
+						PeriodSeconds:       30,
+					},
+				},
+			},
+			checks: func(t *testing.T, c corev1.Container) {
+				if c.StartupProbe.InitialDelaySeconds != 30 {
+					t.Errorf("expected startup initial delay 30, got %d", c.StartupProbe.InitialDelaySeconds)
+				}
+				if c.StartupProbe.FailureThreshold != 120 {
+					t.Errorf("expected startup failure threshold 120, got %d", c.StartupProbe.FailureThreshold)
+				}
+				if c.ReadinessProbe.PeriodSeconds != 5 {
+					t.Errorf("expected readiness period 5, got %d", c.ReadinessProbe.PeriodSeconds)
+				}
+				if c.LivenessProbe.FailureThreshold != 6 {
+					t.Errorf("expected liveness failure threshold 6, got %d", c.LivenessProbe.FailureThreshold)
+				}
+			},
+		},
+		{
+			name: "fallback mode has no probes",
+			server: &boilerrv1alpha1.SteamServer{
+				ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+				Spec: boilerrv1alpha1.SteamServerSpec{
+					AppId: int32Ptr(123456),
+					Ports: []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 27015}},
+				},
+			},
+			checks: func(t *testing.T, c corev1.Container) {
+				if c.StartupProbe != nil || c.ReadinessProbe != nil || c.LivenessProbe != nil {
+					t.Error("expected no probes in fallback mode")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sts := NewStatefulSetBuilder(tt.server, tt.gameDef).Build()
+			tt.checks(t, sts.Spec.Template.Spec.Containers[0])
+		})
+	}
+}
+
+func TestStatefulSetBuilder_A2SProbe(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec:       boilerrv1alpha1.SteamServerSpec{GameDefinition: "valheim"},
+	}
+	gameDef := &boilerrv1alpha1.GameDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+		Spec: boilerrv1alpha1.GameDefinitionSpec{
+			AppId:   896660,
+			Command: "./valheim_server.x86_64",
+			Ports: []boilerrv1alpha1.ServerPort{
+				{Name: "game", ContainerPort: 2456},
+				{Name: "query", ContainerPort: 2457},
+			},
+			HealthCheck: &boilerrv1alpha1.HealthCheckSpec{
+				A2S:       &boilerrv1alpha1.A2SHealthCheck{Port: intstr.FromString("query"), TimeoutSeconds: 5},
+				TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromString("game")},
+			},
+		},
+	}
+
+	t.Run("exec probe with probe image", func(t *testing.T) {
+		sts := NewStatefulSetBuilder(server, gameDef).WithProbeImage("ghcr.io/craightonh/boilerr:v1").Build()
+		podSpec := sts.Spec.Template.Spec
+		c := podSpec.Containers[0]
+
+		for name, p := range map[string]*corev1.Probe{
+			"startup": c.StartupProbe, "readiness": c.ReadinessProbe, "liveness": c.LivenessProbe,
+		} {
+			if p == nil || p.Exec == nil {
+				t.Fatalf("expected %s exec probe", name)
+			}
+			if p.TCPSocket != nil {
+				t.Errorf("expected a2s to take precedence over tcpSocket for %s probe", name)
+			}
+			if p.TimeoutSeconds != 6 {
+				t.Errorf("expected %s probe timeout 6, got %d", name, p.TimeoutSeconds)
+			}
+		}
+		want := []string{ProbeToolsMountPath + "/a2sprobe", "-addr", "127.0.0.1:2457", "-timeout", "5s"}
+		if len(c.ReadinessProbe.Exec.Command) != len(want) {
+			t.Fatalf("expected command %v, got %v", want, c.ReadinessProbe.Exec.Command)
+		}
+		for i := range want {
+			if c.ReadinessProbe.Exec.Command[i] != want[i] {
+				t.Errorf("expected command %v, got %v", want, c.ReadinessProbe.Exec.Command)
+				break
+			}
+		}
+
+		last := podSpec.InitContainers[len(podSpec.InitContainers)-1]
+		if last.Name != ProbeInstallContainerName {
+			t.Fatalf("expected %s init container last, got %s", ProbeInstallContainerName, last.Name)
+		}
+		if last.Image != "ghcr.io/craightonh/boilerr:v1" {
+			t.Errorf("expected probe install image, got %s", last.Image)
+		}
+
+		foundVolume := false
+		for _, v := range podSpec.Volumes {
+			if v.Name == ProbeToolsVolumeName && v.EmptyDir != nil {
+				foundVolume = true
+			}
+		}
+		if !foundVolume {
+			t.Error("expected emptyDir probe tools volume")
+		}
+		foundMount := false
+		for _, m := range c.VolumeMounts {
+			if m.Name == ProbeToolsVolumeName && m.MountPath == ProbeToolsMountPath {
+				foundMount = true
+			}
+		}
+		if !foundMount {
+			t.Error("expected probe tools mount on game server container")
+		}
+	})
+
+	t.Run("no probes without probe image", func(t *testing.T) {
+		sts := NewStatefulSetBuilder(server, gameDef).Build()
+		podSpec := sts.Spec.Template.Spec
+		c := podSpec.Containers[0]
+
+		if c.StartupProbe != nil || c.ReadinessProbe != nil || c.LivenessProbe != nil {
+			t.Error("expected no probes when the controller checks A2S")
+		}
+		for _, ic := range podSpec.InitContainers {
+			if ic.Name == ProbeInstallContainerName {
+				t.Errorf("expected no %s init container", ProbeInstallContainerName)
+			}
+		}
+	})
+
+	t.Run("A2SHealthCheckPort resolves named port", func(t *testing.T) {
+		port, ok := A2SHealthCheckPort(server, gameDef)
+		if !ok || port != 2457 {
+			t.Errorf("expected port 2457, got %d (ok=%v)", port, ok)
+		}
+	})
+}
+
+func TestA2SQueryPort(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec:       boilerrv1alpha1.SteamServerSpec{GameDefinition: "valheim"},
+	}
+
+	tests := []struct {
+		name     string
+		gameDef  *boilerrv1alpha1.GameDefinition
+		wantPort int32
+		wantOK   bool
+	}{
+		{
+			name: "a2s health check port",
+			gameDef: &boilerrv1alpha1.GameDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+				Spec: boilerrv1alpha1.GameDefinitionSpec{
+					AppId:   896660,
+					Command: "./valheim_server.x86_64",
+					Ports: []boilerrv1alpha1.ServerPort{
+						{Name: "game", ContainerPort: 2456},
+						{Name: "query", ContainerPort: 2457},
+					},
+					HealthCheck: &boilerrv1alpha1.HealthCheckSpec{
+						A2S: &boilerrv1alpha1.A2SHealthCheck{Port: intstr.FromString("game")},
+					},
+				},
+			},
+			wantPort: 2456,
+			wantOK:   true,
+		},
+		{
+			name: "falls back to query port",
+			gameDef: &boilerrv1alpha1.GameDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+				Spec: boilerrv1alpha1.GameDefinitionSpec{
+					AppId:   896660,
+					Command: "./valheim_server.x86_64",
+					Ports: []boilerrv1alpha1.ServerPort{
+						{Name: "game", ContainerPort: 2456},
+						{Name: "query", ContainerPort: 2457},
+					},
+				},
+			},
+			wantPort: 2457,
+			wantOK:   true,
+		},
+		{
+			name: "no query port",
+			gameDef: &boilerrv1alpha1.GameDefinition{
+				Spec: boilerrv1alpha1.GameDefinitionSpec{
+					Ports: []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+				},
+			},
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			port, ok := A2SQueryPort(server, tt.gameDef)
+			if ok != tt.wantOK || port != tt.wantPort {
+				t.Errorf("A2SQueryPort() = %d, %v, want %d, %v", port, ok, tt.wantPort, tt.wantOK)
+			}
+		})
+	}
+}
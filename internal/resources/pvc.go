@@ -56,9 +56,43 @@ func (b *PVCBuilder) Build() *corev1.PersistentVolumeClaim {
 		pvc.Spec.StorageClassName = b.server.Spec.Storage.StorageClassName
 	}
 
+	// Restore the volume contents from a VolumeSnapshot if requested
+	if b.server.Spec.Storage != nil {
+		pvc.Spec.DataSource = snapshotDataSource(b.server.Spec.Storage.RestoreFromSnapshot)
+	}
+
 	return pvc
 }
 
+// BuildSaves creates the save data PVC for the SteamServer.
+// Returns nil if no saveStorage is configured.
+func (b *PVCBuilder) BuildSaves() *corev1.PersistentVolumeClaim {
+	spec := b.server.Spec.SaveStorage
+	if spec == nil {
+		return nil
+	}
+
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SavePVCName(b.server.Name),
+			Namespace: b.server.Namespace,
+			Labels:    b.labels(),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{
+				corev1.ReadWriteOnce,
+			},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: spec.Size,
+				},
+			},
+			StorageClassName: spec.StorageClassName,
+			DataSource:       snapshotDataSource(spec.RestoreFromSnapshot),
+		},
+	}
+}
+
 // getStorageSize returns the storage size.
 // Fallback: SteamServer.Storage.Size -> GameDefinition.DefaultStorage -> DefaultStorageSize
 func (b *PVCBuilder) getStorageSize() resource.Quantity {
@@ -375,3 +375,59 @@ func TestPVCBuilder_StorageSizeFormats(t *testing.T) {
 		})
 	}
 }
+
+func TestPVCBuilder_RestoreFromSnapshot(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: "default"},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			GameDefinition: "test-game",
+			Storage: &boilerrv1alpha1.StorageSpec{
+				Size: resource.MustParse("10Gi"),
+			},
+		},
+	}
+
+	if pvc := NewPVCBuilder(server, nil).Build(); pvc.Spec.DataSource != nil {
+		t.Errorf("expected no data source, got %v", pvc.Spec.DataSource)
+	}
+
+	server.Spec.Storage.RestoreFromSnapshot = "test-server-20260101-040000"
+	pvc := NewPVCBuilder(server, nil).Build()
+	ds := pvc.Spec.DataSource
+	if ds == nil || ds.Kind != "VolumeSnapshot" || ds.APIGroup == nil || *ds.APIGroup != "snapshot.storage.k8s.io" ||
+		ds.Name != "test-server-20260101-040000" {
+		t.Errorf("expected the VolumeSnapshot data source, got %v", ds)
+	}
+}
+
+func TestPVCBuilder_BuildSaves(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: "default"},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			GameDefinition: "test-game",
+		},
+	}
+
+	if pvc := NewPVCBuilder(server, nil).BuildSaves(); pvc != nil {
+		t.Errorf("expected no save PVC without saveStorage, got %v", pvc)
+	}
+
+	server.Spec.SaveStorage = &boilerrv1alpha1.SaveStorageSpec{
+		Size:                resource.MustParse("1Gi"),
+		StorageClassName:    stringPtr("fast-ssd"),
+		RestoreFromSnapshot: "test-server-20260101-040000",
+	}
+	pvc := NewPVCBuilder(server, nil).BuildSaves()
+	if pvc.Name != "test-server-saves" || pvc.Namespace != "default" {
+		t.Errorf("expected PVC default/test-server-saves, got %s/%s", pvc.Namespace, pvc.Name)
+	}
+	if size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; size.String() != "1Gi" {
+		t.Errorf("expected size 1Gi, got %s", size.String())
+	}
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName != "fast-ssd" {
+		t.Errorf("expected StorageClass fast-ssd, got %v", pvc.Spec.StorageClassName)
+	}
+	if ds := pvc.Spec.DataSource; ds == nil || ds.Name != "test-server-20260101-040000" {
+		t.Errorf("expected the VolumeSnapshot data source, got %v", ds)
+	}
+}
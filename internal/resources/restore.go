@@ -0,0 +1,180 @@
+package resources
+
+import (
+	"fmt"
+	"path"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+)
+
+const (
+	// RestoreContainerName is the name of the restore container.
+	RestoreContainerName = "restore"
+	// RestoreSourceVolumeName is the volume name of the source PVC.
+	RestoreSourceVolumeName = "restore-source"
+	// RestoreSourceMountPath is where the source PVC is mounted.
+	RestoreSourceMountPath = "/restore-source"
+)
+
+// RestoreJobBuilder builds the Job that extracts a SteamServerRestore archive into the server volume.
+type RestoreJobBuilder struct {
+	restore   *boilerrv1alpha1.SteamServerRestore
+	image     string
+	claimName string
+}
+
+// NewRestoreJobBuilder creates a new RestoreJobBuilder.
+func NewRestoreJobBuilder(restore *boilerrv1alpha1.SteamServerRestore) *RestoreJobBuilder {
+	return &RestoreJobBuilder{restore: restore}
+}
+
+// WithImage sets the operator image that provides the backup binary.
+func (b *RestoreJobBuilder) WithImage(image string) *RestoreJobBuilder {
+	b.image = image
+	return b
+}
+
+// WithClaimName sets the PVC to restore into, such as the server's save volume.
+// Defaults to the server volume.
+func (b *RestoreJobBuilder) WithClaimName(claimName string) *RestoreJobBuilder {
+	b.claimName = claimName
+	return b
+}
+
+// Build creates the restore Job.
+// Returns an error if the source is not exactly one of persistentVolumeClaim or s3.
+func (b *RestoreJobBuilder) Build() (*batchv1.Job, error) {
+	source := b.restore.Spec.Source
+	if (source.PersistentVolumeClaim == nil) == (source.S3 == nil) {
+		return nil, fmt.Errorf("source must set exactly one of persistentVolumeClaim or s3")
+	}
+
+	labels := RestoreLabels(b.restore)
+	// A failed extraction leaves the volume untouched, so retrying would fail the same way
+	backoffLimit := int32(0)
+	rootUser := int64(0)
+	runAsNonRoot := false
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      RestoreJobName(b.restore.Name),
+			Namespace: b.restore.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					SecurityContext: &corev1.PodSecurityContext{
+						// Restored files keep the owners recorded in the archive, which requires root
+						RunAsUser:    &rootUser,
+						RunAsNonRoot: &runAsNonRoot,
+					},
+					Containers: []corev1.Container{b.buildContainer()},
+					Volumes:    b.buildVolumes(),
+				},
+			},
+		},
+	}, nil
+}
+
+// buildContainer creates the restore container.
+func (b *RestoreJobBuilder) buildContainer() corev1.Container {
+	source := b.restore.Spec.Source
+	command := []string{BackupBinaryPath, "restore", "-root", ServerFilesMountPath}
+	mounts := []corev1.VolumeMount{
+		{Name: ServerFilesVolumeName, MountPath: ServerFilesMountPath},
+	}
+	var env []corev1.EnvVar
+
+	if pvc := source.PersistentVolumeClaim; pvc != nil {
+		command = append(command, "-file", path.Join(RestoreSourceMountPath, path.Clean("/"+pvc.Path)))
+		mounts = append(mounts, corev1.VolumeMount{Name: RestoreSourceVolumeName, MountPath: RestoreSourceMountPath, ReadOnly: true})
+	}
+	if s3 := source.S3; s3 != nil {
+		command = append(command,
+			"-s3-endpoint", s3.Endpoint,
+			"-s3-bucket", s3.Bucket,
+			"-s3-key", s3.Key,
+		)
+		if s3.Region != "" {
+			command = append(command, "-s3-region", s3.Region)
+		}
+		env = s3CredentialsEnv(s3.CredentialsSecretRef)
+	}
+
+	return corev1.Container{
+		Name:         RestoreContainerName,
+		Image:        b.image,
+		Command:      command,
+		Env:          env,
+		VolumeMounts: mounts,
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("50m"),
+				corev1.ResourceMemory: resource.MustParse("32Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("256Mi"),
+			},
+		},
+	}
+}
+
+// buildVolumes creates the server volume and source PVC volumes.
+func (b *RestoreJobBuilder) buildVolumes() []corev1.Volume {
+	volumes := []corev1.Volume{
+		{
+			Name: ServerFilesVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: b.targetClaimName(),
+				},
+			},
+		},
+	}
+	if pvc := b.restore.Spec.Source.PersistentVolumeClaim; pvc != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: RestoreSourceVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: pvc.ClaimName,
+					ReadOnly:  true,
+				},
+			},
+		})
+	}
+	return volumes
+}
+
+// targetClaimName returns the PVC to restore into.
+func (b *RestoreJobBuilder) targetClaimName() string {
+	if b.claimName != "" {
+		return b.claimName
+	}
+	return PVCName(b.restore.Spec.ServerName)
+}
+
+// RestoreJobName returns the restore Job name for a SteamServerRestore.
+func RestoreJobName(restoreName string) string {
+	return restoreName + "-restore"
+}
+
+// RestoreLabels returns the labels of the restore Job and its pod.
+func RestoreLabels(restore *boilerrv1alpha1.SteamServerRestore) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       "restore",
+		"app.kubernetes.io/instance":   restore.Spec.ServerName,
+		"app.kubernetes.io/managed-by": "boilerr",
+		"boilerr.dev/restore":          restore.Name,
+	}
+}
@@ -0,0 +1,187 @@
+package resources
+
+import (
+	"slices"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+)
+
+func TestRestoreJobBuilder_Build(t *testing.T) {
+	tests := []struct {
+		name      string
+		restore   *boilerrv1alpha1.SteamServerRestore
+		claimName string
+		checks    func(t *testing.T, job *batchv1.Job)
+	}{
+		{
+			name: "pvc source",
+			restore: &boilerrv1alpha1.SteamServerRestore{
+				ObjectMeta: metav1.ObjectMeta{Name: "rollback", Namespace: testNamespace},
+				Spec: boilerrv1alpha1.SteamServerRestoreSpec{
+					ServerName: testServerName,
+					Source: boilerrv1alpha1.RestoreSource{
+						PersistentVolumeClaim: &boilerrv1alpha1.PVCRestoreSource{
+							ClaimName: "backups",
+							Path:      testServerName + "/" + testServerName + "-20260101T040000Z.tar.gz",
+						},
+					},
+				},
+			},
+			checks: func(t *testing.T, job *batchv1.Job) {
+				if job.Name != RestoreJobName("rollback") {
+					t.Errorf("expected name %s, got %s", RestoreJobName("rollback"), job.Name)
+				}
+				if job.Spec.BackoffLimit == nil || *job.Spec.BackoffLimit != 0 {
+					t.Errorf("expected no retries, got %v", job.Spec.BackoffLimit)
+				}
+
+				pod := job.Spec.Template.Spec
+				c := pod.Containers[0]
+				expected := []string{
+					BackupBinaryPath, "restore",
+					"-root", ServerFilesMountPath,
+					"-file", RestoreSourceMountPath + "/" + testServerName + "/" + testServerName + "-20260101T040000Z.tar.gz",
+				}
+				if !slices.Equal(c.Command, expected) {
+					t.Errorf("expected command %v, got %v", expected, c.Command)
+				}
+
+				var serverVolume, sourceVolume *corev1.Volume
+				for i := range pod.Volumes {
+					switch pod.Volumes[i].Name {
+					case ServerFilesVolumeName:
+						serverVolume = &pod.Volumes[i]
+					case RestoreSourceVolumeName:
+						sourceVolume = &pod.Volumes[i]
+					}
+				}
+				if serverVolume == nil || serverVolume.PersistentVolumeClaim.ClaimName != PVCName(testServerName) ||
+					serverVolume.PersistentVolumeClaim.ReadOnly {
+					t.Errorf("expected the server PVC mounted read-write, got %v", serverVolume)
+				}
+				if sourceVolume == nil || sourceVolume.PersistentVolumeClaim.ClaimName != "backups" ||
+					!sourceVolume.PersistentVolumeClaim.ReadOnly {
+					t.Errorf("expected the backup PVC mounted read-only, got %v", sourceVolume)
+				}
+			},
+		},
+		{
+			name: "restore into the save volume",
+			restore: &boilerrv1alpha1.SteamServerRestore{
+				ObjectMeta: metav1.ObjectMeta{Name: "rollback", Namespace: testNamespace},
+				Spec: boilerrv1alpha1.SteamServerRestoreSpec{
+					ServerName: testServerName,
+					Source: boilerrv1alpha1.RestoreSource{
+						PersistentVolumeClaim: &boilerrv1alpha1.PVCRestoreSource{ClaimName: "backups", Path: "latest.tar.gz"},
+					},
+				},
+			},
+			claimName: SavePVCName(testServerName),
+			checks: func(t *testing.T, job *batchv1.Job) {
+				for _, v := range job.Spec.Template.Spec.Volumes {
+					if v.Name == ServerFilesVolumeName && v.PersistentVolumeClaim.ClaimName != SavePVCName(testServerName) {
+						t.Errorf("expected the restore to target %s, got %s", SavePVCName(testServerName), v.PersistentVolumeClaim.ClaimName)
+					}
+				}
+			},
+		},
+		{
+			name: "archive path kept inside the source volume",
+			restore: &boilerrv1alpha1.SteamServerRestore{
+				ObjectMeta: metav1.ObjectMeta{Name: "rollback", Namespace: testNamespace},
+				Spec: boilerrv1alpha1.SteamServerRestoreSpec{
+					ServerName: testServerName,
+					Source: boilerrv1alpha1.RestoreSource{
+						PersistentVolumeClaim: &boilerrv1alpha1.PVCRestoreSource{ClaimName: "backups", Path: "../../etc/shadow"},
+					},
+				},
+			},
+			checks: func(t *testing.T, job *batchv1.Job) {
+				if file := job.Spec.Template.Spec.Containers[0].Command[5]; file != RestoreSourceMountPath+"/etc/shadow" {
+					t.Errorf("expected the archive path to stay inside the source volume, got %s", file)
+				}
+			},
+		},
+		{
+			name: "s3 source",
+			restore: &boilerrv1alpha1.SteamServerRestore{
+				ObjectMeta: metav1.ObjectMeta{Name: "rollback", Namespace: testNamespace},
+				Spec: boilerrv1alpha1.SteamServerRestoreSpec{
+					ServerName: testServerName,
+					Source: boilerrv1alpha1.RestoreSource{
+						S3: &boilerrv1alpha1.S3RestoreSource{
+							Endpoint:             "http://minio.minio:9000",
+							Bucket:               "saves",
+							Key:                  "valheim/" + testServerName + "-20260101T040000Z.tar.gz",
+							Region:               "us-west-2",
+							CredentialsSecretRef: corev1.LocalObjectReference{Name: "minio-creds"},
+						},
+					},
+				},
+			},
+			checks: func(t *testing.T, job *batchv1.Job) {
+				c := job.Spec.Template.Spec.Containers[0]
+				for _, arg := range []string{"-s3-endpoint", "-s3-bucket", "saves", "-s3-key", "-s3-region", "us-west-2"} {
+					if !slices.Contains(c.Command, arg) {
+						t.Errorf("expected %q in command %v", arg, c.Command)
+					}
+				}
+				if len(c.Env) != 2 || c.Env[0].ValueFrom.SecretKeyRef.Name != "minio-creds" {
+					t.Errorf("expected credentials from the Secret, got %v", c.Env)
+				}
+				for _, v := range job.Spec.Template.Spec.Volumes {
+					if v.Name == RestoreSourceVolumeName {
+						t.Error("expected no source PVC volume for s3")
+					}
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewRestoreJobBuilder(tt.restore).WithImage("ghcr.io/craightonh/boilerr:v1")
+			if tt.claimName != "" {
+				builder = builder.WithClaimName(tt.claimName)
+			}
+			job, err := builder.Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			tt.checks(t, job)
+		})
+	}
+}
+
+func TestRestoreJobBuilder_InvalidSource(t *testing.T) {
+	tests := []struct {
+		name   string
+		source boilerrv1alpha1.RestoreSource
+	}{
+		{name: "none", source: boilerrv1alpha1.RestoreSource{}},
+		{name: "both", source: boilerrv1alpha1.RestoreSource{
+			PersistentVolumeClaim: &boilerrv1alpha1.PVCRestoreSource{ClaimName: "backups", Path: "a.tar.gz"},
+			S3:                    &boilerrv1alpha1.S3RestoreSource{Endpoint: "http://minio:9000", Bucket: "saves", Key: "a.tar.gz"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restore := &boilerrv1alpha1.SteamServerRestore{
+				ObjectMeta: metav1.ObjectMeta{Name: "rollback", Namespace: testNamespace},
+				Spec: boilerrv1alpha1.SteamServerRestoreSpec{
+					ServerName: testServerName,
+					Source:     tt.source,
+				},
+			}
+			if _, err := NewRestoreJobBuilder(restore).Build(); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
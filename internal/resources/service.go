@@ -10,8 +10,9 @@ import (
 
 // ServiceBuilder builds a Service for a SteamServer.
 type ServiceBuilder struct {
-	server  *boilerrv1alpha1.SteamServer
-	gameDef *boilerrv1alpha1.GameDefinition
+	server    *boilerrv1alpha1.SteamServer
+	gameDef   *boilerrv1alpha1.GameDefinition
+	wakeProxy bool
 }
 
 // NewServiceBuilder creates a new ServiceBuilder.
@@ -20,9 +21,19 @@ func NewServiceBuilder(server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1al
 	return &ServiceBuilder{server: server, gameDef: gameDef}
 }
 
+// WithWakeProxy routes the Service to the wake proxy instead of the game server pod.
+func (b *ServiceBuilder) WithWakeProxy(enabled bool) *ServiceBuilder {
+	b.wakeProxy = enabled
+	return b
+}
+
 // Build creates the Service for the SteamServer.
 func (b *ServiceBuilder) Build() *corev1.Service {
 	labels := b.labels()
+	selector := labels
+	if b.wakeProxy {
+		selector = WakeProxyLabels(b.server)
+	}
 
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
@@ -32,7 +43,7 @@ func (b *ServiceBuilder) Build() *corev1.Service {
 		},
 		Spec: corev1.ServiceSpec{
 			Type:     b.getServiceType(),
-			Selector: labels,
+			Selector: selector,
 			Ports:    b.buildServicePorts(),
 		},
 	}
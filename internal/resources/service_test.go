@@ -342,6 +342,24 @@ func TestServiceBuilder_Build(t *testing.T) {
 	}
 }
 
+func TestServiceBuilder_WakeProxy(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: "default"},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			GameDefinition: "test-game",
+			Ports:          []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+		},
+	}
+
+	svc := NewServiceBuilder(server, nil).WithWakeProxy(true).Build()
+	if svc.Spec.Selector["app.kubernetes.io/name"] != "wakeproxy" {
+		t.Errorf("expected selector to target the wake proxy, got %v", svc.Spec.Selector)
+	}
+	if svc.Labels["app.kubernetes.io/name"] != "steamserver" {
+		t.Errorf("expected Service labels to stay unchanged, got %v", svc.Labels)
+	}
+}
+
 func TestServiceName(t *testing.T) {
 	tests := []struct {
 		serverName string
@@ -0,0 +1,127 @@
+package resources
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+)
+
+const (
+	// VolumeSnapshotGroup is the API group of CSI VolumeSnapshots.
+	VolumeSnapshotGroup = "snapshot.storage.k8s.io"
+	// VolumeSnapshotKind is the kind of CSI VolumeSnapshots.
+	VolumeSnapshotKind = "VolumeSnapshot"
+	// InstallHashAnnotation is the VolumeSnapshot annotation recording the install the snapshot was taken before.
+	InstallHashAnnotation = "boilerr.dev/install-hash"
+	// DefaultSnapshotRetention is used when snapshots.retention is unset.
+	DefaultSnapshotRetention int32 = 3
+
+	snapshotTimestampFormat = "20060102-150405"
+)
+
+// VolumeSnapshotGVK is the GroupVersionKind of CSI VolumeSnapshots.
+// The snapshot API is an optional CRD, so VolumeSnapshots are handled as unstructured objects.
+var VolumeSnapshotGVK = schema.GroupVersionKind{Group: VolumeSnapshotGroup, Version: "v1", Kind: VolumeSnapshotKind}
+
+// SnapshotBuilder builds a pre-update VolumeSnapshot of the volume holding a SteamServer's save data.
+type SnapshotBuilder struct {
+	server      *boilerrv1alpha1.SteamServer
+	installHash string
+	time        time.Time
+}
+
+// NewSnapshotBuilder creates a new SnapshotBuilder for the install identified by installHash, taken at t.
+func NewSnapshotBuilder(server *boilerrv1alpha1.SteamServer, installHash string, t time.Time) *SnapshotBuilder {
+	return &SnapshotBuilder{server: server, installHash: installHash, time: t}
+}
+
+// Build creates the VolumeSnapshot of the save volume, or the server volume without saveStorage.
+func (b *SnapshotBuilder) Build() *unstructured.Unstructured {
+	spec := map[string]any{
+		"source": map[string]any{
+			"persistentVolumeClaimName": SaveClaimName(b.server),
+		},
+	}
+	if s := b.server.Spec.Snapshots; s != nil && s.VolumeSnapshotClassName != nil {
+		spec["volumeSnapshotClassName"] = *s.VolumeSnapshotClassName
+	}
+
+	snapshot := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	snapshot.SetGroupVersionKind(VolumeSnapshotGVK)
+	snapshot.SetName(SnapshotName(b.server.Name, b.time))
+	snapshot.SetNamespace(b.server.Namespace)
+	snapshot.SetLabels(SnapshotLabels(b.server))
+	snapshot.SetAnnotations(map[string]string{InstallHashAnnotation: b.installHash})
+	return snapshot
+}
+
+// SnapshotName returns the name of a VolumeSnapshot of a SteamServer volume taken at t.
+func SnapshotName(serverName string, t time.Time) string {
+	return serverName + "-" + t.UTC().Format(snapshotTimestampFormat)
+}
+
+// SnapshotLabels returns the labels of the pre-update VolumeSnapshots of a SteamServer.
+func SnapshotLabels(server *boilerrv1alpha1.SteamServer) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       "snapshot",
+		"app.kubernetes.io/instance":   server.Name,
+		"app.kubernetes.io/managed-by": "boilerr",
+		"boilerr.dev/game":             server.Spec.GameDefinition,
+	}
+}
+
+// SnapshotRetention returns the number of pre-update snapshots to keep, applying the default.
+func SnapshotRetention(server *boilerrv1alpha1.SteamServer) int {
+	if s := server.Spec.Snapshots; s != nil && s.Retention > 0 {
+		return int(s.Retention)
+	}
+	return int(DefaultSnapshotRetention)
+}
+
+// snapshotDataSource returns the PVC data source that restores the named VolumeSnapshot, or nil if name is empty.
+func snapshotDataSource(name string) *corev1.TypedLocalObjectReference {
+	if name == "" {
+		return nil
+	}
+	group := VolumeSnapshotGroup
+	return &corev1.TypedLocalObjectReference{
+		APIGroup: &group,
+		Kind:     VolumeSnapshotKind,
+		Name:     name,
+	}
+}
+
+// InstallHash returns a hash of the pod template fields that change what SteamCMD installs:
+// the SteamCMD image and arguments (app ID, beta, login) and the target build.
+// For a server installing from a game cache, the target build is the cached build.
+// Returns an empty string for a template without a SteamCMD or game cache container.
+func InstallHash(template *corev1.PodTemplateSpec) string {
+	for _, c := range template.Spec.InitContainers {
+		if c.Name != InitContainerName && c.Name != GameCacheContainerName {
+			continue
+		}
+		data, err := json.Marshal([]any{c.Image, c.Args, template.Annotations[TargetBuildAnnotation]})
+		if err != nil {
+			return ""
+		}
+		h := fnv.New64a()
+		_, _ = h.Write(data)
+		return strconv.FormatUint(h.Sum64(), 16)
+	}
+	return ""
+}
+
+// Restoring returns whether a server volume is being restored, by a SteamServerRestore
+// or from a restoreFromSnapshot field.
+func Restoring(server *boilerrv1alpha1.SteamServer) bool {
+	return server.Annotations[boilerrv1alpha1.RestoreLockAnnotation] != "" ||
+		meta.IsStatusConditionTrue(server.Status.Conditions, boilerrv1alpha1.ConditionRestoringSnapshot)
+}
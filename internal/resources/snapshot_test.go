@@ -0,0 +1,114 @@
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+)
+
+func TestSnapshotBuilder_Build(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			GameDefinition: "valheim",
+			AppId:          int32Ptr(896660),
+			Ports:          []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+			Snapshots:      &boilerrv1alpha1.SnapshotSpec{VolumeSnapshotClassName: stringPtr("csi-hostpath-snapclass")},
+		},
+	}
+	at := time.Date(2026, 1, 1, 4, 0, 0, 0, time.UTC)
+
+	snapshot := NewSnapshotBuilder(server, "abc123", at).Build()
+
+	if snapshot.GroupVersionKind() != VolumeSnapshotGVK {
+		t.Errorf("expected %v, got %v", VolumeSnapshotGVK, snapshot.GroupVersionKind())
+	}
+	if snapshot.GetName() != testServerName+"-20260101-040000" {
+		t.Errorf("expected name %s-20260101-040000, got %s", testServerName, snapshot.GetName())
+	}
+	if snapshot.GetAnnotations()[InstallHashAnnotation] != "abc123" {
+		t.Errorf("expected install hash annotation, got %v", snapshot.GetAnnotations())
+	}
+	if snapshot.GetLabels()["app.kubernetes.io/instance"] != testServerName {
+		t.Errorf("expected snapshot to be labeled with the server, got %v", snapshot.GetLabels())
+	}
+	if claim, _, _ := unstructured.NestedString(snapshot.Object, "spec", "source", "persistentVolumeClaimName"); claim != PVCName(testServerName) {
+		t.Errorf("expected source PVC %s, got %q", PVCName(testServerName), claim)
+	}
+	if class, _, _ := unstructured.NestedString(snapshot.Object, "spec", "volumeSnapshotClassName"); class != "csi-hostpath-snapclass" {
+		t.Errorf("expected snapshot class csi-hostpath-snapclass, got %q", class)
+	}
+}
+
+func TestInstallHash(t *testing.T) {
+	base := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			GameDefinition: "valheim",
+			AppId:          int32Ptr(896660),
+			Ports:          []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+			Snapshots:      &boilerrv1alpha1.SnapshotSpec{},
+		},
+	}
+	hash := func(server *boilerrv1alpha1.SteamServer, targetBuild string) string {
+		sts := NewStatefulSetBuilder(server, nil).WithTargetBuild(targetBuild).Build()
+		return InstallHash(&sts.Spec.Template)
+	}
+	baseHash := hash(base, "")
+	if baseHash == "" {
+		t.Fatal("expected a hash for a template with a SteamCMD container")
+	}
+
+	tests := []struct {
+		name        string
+		mutate      func(*boilerrv1alpha1.SteamServer)
+		targetBuild string
+		changed     bool
+	}{
+		{name: "beta", mutate: func(s *boilerrv1alpha1.SteamServer) { s.Spec.Beta = "public-test" }, changed: true},
+		{name: "image", mutate: func(s *boilerrv1alpha1.SteamServer) { s.Spec.Image = "steamcmd/steamcmd:debian" }, changed: true},
+		{name: "app ID", mutate: func(s *boilerrv1alpha1.SteamServer) { s.Spec.AppId = int32Ptr(892970) }, changed: true},
+		{name: "target build", mutate: func(*boilerrv1alpha1.SteamServer) {}, targetBuild: "15632571", changed: true},
+		{name: "args", mutate: func(s *boilerrv1alpha1.SteamServer) { s.Spec.Args = []string{"-nographics"} }},
+		{name: "suspended", mutate: func(s *boilerrv1alpha1.SteamServer) { s.Spec.Suspended = true }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := base.DeepCopy()
+			tt.mutate(server)
+			if got := hash(server, tt.targetBuild) != baseHash; got != tt.changed {
+				t.Errorf("expected install hash changed = %v, got %v", tt.changed, got)
+			}
+		})
+	}
+}
+
+func TestRestoring(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			GameDefinition: "valheim",
+			AppId:          int32Ptr(896660),
+			Ports:          []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+			Snapshots:      &boilerrv1alpha1.SnapshotSpec{},
+		},
+	}
+	if Restoring(server) {
+		t.Error("expected no restore")
+	}
+
+	meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:   boilerrv1alpha1.ConditionRestoringSnapshot,
+		Status: metav1.ConditionTrue,
+		Reason: "Stopping",
+	})
+	if !Restoring(server) || !ScaledDown(server) {
+		t.Error("expected a snapshot restore to scale the server down")
+	}
+}
@@ -1,7 +1,14 @@
 package resources
 
 import (
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -15,20 +22,51 @@ import (
 const (
 	// ServerFilesVolumeName is the volume name for game server files.
 	ServerFilesVolumeName = "serverfiles"
-	// ServerFilesMountPath is the mount path for game server files.
+	// ServerFilesMountPath is the install directory without a GameDefinition, and where
+	// backup and restore Jobs mount the server volume. Game server pods mount it at the install directory.
 	ServerFilesMountPath = "/serverfiles"
+	// SaveFilesVolumeName is the volume name for the save data volume.
+	SaveFilesVolumeName = "savefiles"
+	// SaveDirsSubPath is the directory holding saveDirs on the save volume, or the server volume without one.
+	// Each save directory is kept at SaveDirsSubPath/<absolute path>, such as saves/data/saves.
+	SaveDirsSubPath = "saves"
+	// SaveFilesMountPath is where the save migration init container mounts the save volume.
+	SaveFilesMountPath = "/savefiles"
+	// SaveMigrationContainerName is the name of the init container that moves save data out of the install directory.
+	SaveMigrationContainerName = "save-dirs"
 	// InitContainerName is the name of the SteamCMD init container.
 	InitContainerName = "steamcmd"
+	// BuildInfoContainerName is the name of the init container that reports the installed build ID.
+	BuildInfoContainerName = "build-info"
 	// GameServerContainerName is the name of the main game server container.
 	GameServerContainerName = "gameserver"
+	// DefaultInstallRetries is how many times a failed SteamCMD run is retried when spec.install omits retries.
+	DefaultInstallRetries int32 = 3
+	// DefaultInstallBackoff is the wait before the first retry when spec.install omits backoff.
+	DefaultInstallBackoff = 30 * time.Second
+	// MaxInstallBackoff caps the wait between SteamCMD retries.
+	MaxInstallBackoff = 5 * time.Minute
 	// DefaultImage is the default container image.
 	DefaultImage = "steamcmd/steamcmd:ubuntu-22"
+	// TargetBuildAnnotation is the pod template annotation recording the Steam build the pod should install.
+	// Changing it restarts the pod, so SteamCMD installs the new build.
+	TargetBuildAnnotation = "boilerr.dev/target-build"
+	// PodTemplateHashAnnotation is the StatefulSet annotation recording the hash of the applied pod template.
+	PodTemplateHashAnnotation = "boilerr.dev/pod-template-hash"
+	// SpecHashAnnotation records the hash of the spec an object was last updated to.
+	SpecHashAnnotation = "boilerr.dev/spec-hash"
+	// RestartedAtAnnotation is the pod template annotation recording status.lastScheduledRestart.
+	// Changing it restarts the pod.
+	RestartedAtAnnotation = "boilerr.dev/restarted-at"
 )
 
 // StatefulSetBuilder builds a StatefulSet for a SteamServer.
 type StatefulSetBuilder struct {
-	server  *boilerrv1alpha1.SteamServer
-	gameDef *boilerrv1alpha1.GameDefinition
+	server      *boilerrv1alpha1.SteamServer
+	gameDef     *boilerrv1alpha1.GameDefinition
+	probeImage  string
+	targetBuild string
+	gameCache   string
 }
 
 // NewStatefulSetBuilder creates a new StatefulSetBuilder.
@@ -37,10 +75,45 @@ func NewStatefulSetBuilder(server *boilerrv1alpha1.SteamServer, gameDef *boilerr
 	return &StatefulSetBuilder{server: server, gameDef: gameDef}
 }
 
+// WithProbeImage sets the operator image that provides the a2sprobe and steamguard binaries.
+// Without it, A2S health checks are left to the controller instead of exec probes, and SteamCMD
+// logs in without Steam Guard codes.
+func (b *StatefulSetBuilder) WithProbeImage(image string) *StatefulSetBuilder {
+	b.probeImage = image
+	return b
+}
+
+// WithTargetBuild sets the Steam build ID recorded in the pod template.
+// A new value restarts the pod so SteamCMD installs the update.
+func (b *StatefulSetBuilder) WithTargetBuild(buildID string) *StatefulSetBuilder {
+	b.targetBuild = buildID
+	return b
+}
+
+// WithGameCache sets the game cache volume the server installs from instead of running SteamCMD.
+func (b *StatefulSetBuilder) WithGameCache(name string) *StatefulSetBuilder {
+	b.gameCache = name
+	return b
+}
+
 // Build creates the StatefulSet for the SteamServer.
 func (b *StatefulSetBuilder) Build() *appsv1.StatefulSet {
 	labels := b.labels()
 	replicas := int32(1)
+	if ScaledDown(b.server) {
+		replicas = 0
+	}
+
+	annotations := map[string]string{}
+	if b.targetBuild != "" {
+		annotations[TargetBuildAnnotation] = b.targetBuild
+	}
+	if restarted := b.server.Status.LastScheduledRestart; restarted != nil {
+		annotations[RestartedAtAnnotation] = restarted.UTC().Format(time.RFC3339)
+	}
+	if len(annotations) == 0 {
+		annotations = nil
+	}
 
 	return &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -56,12 +129,11 @@ func (b *StatefulSetBuilder) Build() *appsv1.StatefulSet {
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
+					Labels:      labels,
+					Annotations: annotations,
 				},
 				Spec: corev1.PodSpec{
-					InitContainers: []corev1.Container{
-						b.buildInitContainer(),
-					},
+					InitContainers: b.buildInitContainers(),
 					Containers: []corev1.Container{
 						b.buildMainContainer(),
 					},
@@ -82,25 +154,76 @@ func (b *StatefulSetBuilder) labels() map[string]string {
 	}
 	appID := b.getAppID()
 	if appID > 0 {
-		labels["boilerr.dev/app-id"] = fmt.Sprintf("%d", appID)
+		labels[AppIDLabel] = fmt.Sprintf("%d", appID)
 	}
 	return labels
 }
 
+// buildInitContainers creates the init containers: the steamguard installer if needed, SteamCMD or the
+// game cache linker, the Workshop mod installer if the server has mods, the save migration if a save
+// directory is inside the install directory, the build info reporter, plus the probe installer if needed.
+func (b *StatefulSetBuilder) buildInitContainers() []corev1.Container {
+	var containers []corev1.Container
+	if b.usesSteamGuard() {
+		containers = append(containers, b.buildSteamGuardInstallContainer())
+	}
+	install := b.buildInitContainer()
+	if b.gameCache != "" {
+		install = b.buildGameCacheContainer()
+	}
+	containers = append(containers, install)
+	if b.gameCache == "" && workshop(b.server, b.gameDef) != nil {
+		containers = append(containers, b.buildWorkshopContainer())
+	}
+	if migrate, ok := b.buildSaveMigrationContainer(); ok {
+		containers = append(containers, migrate)
+	}
+	containers = append(containers, b.buildBuildInfoContainer())
+	if b.usesA2SProbe() {
+		containers = append(containers, b.buildProbeInstallContainer())
+	}
+	return containers
+}
+
 // buildInitContainer creates the SteamCMD init container.
+// SteamCMD runs in a retry loop, so a failed download is retried before the pod's install fails.
 func (b *StatefulSetBuilder) buildInitContainer() corev1.Container {
+	script := steamcmd.RetryScript(steamcmd.RetryConfig{
+		InstallDir:     b.getInstallDir(),
+		Retries:        b.installRetries(),
+		Backoff:        b.installBackoff(),
+		MaxBackoff:     MaxInstallBackoff,
+		TerminationLog: corev1.TerminationMessagePathDefault,
+	})
+
 	return corev1.Container{
-		Name:    InitContainerName,
-		Image:   b.getImage(),
-		Command: []string{"steamcmd"},
-		Args:    b.buildSteamCMDArgs(),
-		VolumeMounts: []corev1.VolumeMount{
-			{
-				Name:      ServerFilesVolumeName,
-				MountPath: ServerFilesMountPath,
-			},
-		},
-		Env: b.buildInitEnvVars(),
+		Name:         InitContainerName,
+		Image:        b.getImage(),
+		Command:      append([]string{"/bin/sh", "-c", script, "steamcmd"}, b.steamCMDCommand()...),
+		Args:         b.buildSteamCMDArgs(),
+		VolumeMounts: append([]corev1.VolumeMount{b.serverFilesVolumeMount()}, b.steamCMDLoginVolumeMounts()...),
+		Env:          append(b.buildInitEnvVars(), b.steamCMDLoginEnvVars()...),
+		// A failed install reports the end of the SteamCMD output, which the controller parses for the error.
+		// The retry loop writes it itself; the logs are the fallback if the loop is killed.
+		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+}
+
+// buildBuildInfoContainer creates the init container that reports the installed build ID.
+// It reads the app manifest written by SteamCMD and writes the build ID as its termination message,
+// which the controller surfaces in status.appBuildId.
+func (b *StatefulSetBuilder) buildBuildInfoContainer() corev1.Container {
+	script := fmt.Sprintf("%s > %s",
+		steamcmd.BuildIDScript(b.getInstallDir(), b.getAppID()), corev1.TerminationMessagePathDefault)
+
+	return corev1.Container{
+		Name:                     BuildInfoContainerName,
+		Image:                    b.getImage(),
+		Command:                  []string{"/bin/sh", "-c", script},
+		VolumeMounts:             b.installVolumeMounts(),
+		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
 	}
 }
 
@@ -112,43 +235,53 @@ func (b *StatefulSetBuilder) buildMainContainer() corev1.Container {
 	// Interpolate args with config values
 	args := b.getInterpolatedArgs(configValues)
 
-	// Build env vars: GameDefinition defaults + SteamServer overrides + config secret refs
-	env := b.buildMainEnvVars(configEnvVars)
+	// Build env vars: GameDefinition defaults + config env mappings + SteamServer overrides + config secret refs
+	env := b.buildMainEnvVars(configValues, configEnvVars)
+
+	// Probes from GameDefinition.HealthCheck
+	probes := b.buildProbes()
 
 	return corev1.Container{
-		Name:         GameServerContainerName,
-		Image:        b.getImage(),
-		Command:      b.getCommand(),
-		Args:         args,
-		Ports:        b.buildContainerPorts(),
-		Env:          env,
-		Resources:    b.getResources(),
-		VolumeMounts: b.buildVolumeMounts(),
+		Name:           GameServerContainerName,
+		Image:          b.getImage(),
+		Command:        b.getCommand(),
+		Args:           args,
+		WorkingDir:     b.getInstallDir(),
+		Ports:          b.buildContainerPorts(),
+		Env:            env,
+		Resources:      b.getResources(),
+		VolumeMounts:   b.buildVolumeMounts(),
+		StartupProbe:   probes.startup,
+		ReadinessProbe: probes.readiness,
+		LivenessProbe:  probes.liveness,
 	}
 }
 
 // resolveConfigValues resolves config values from SteamServer.Config against GameDefinition.ConfigSchema.
 func (b *StatefulSetBuilder) resolveConfigValues() (map[string]string, []corev1.EnvVar) {
-	schema := make(map[string]boilerrv1alpha1.ConfigSchemaEntry)
-	if b.gameDef != nil {
-		schema = b.gameDef.Spec.ConfigSchema
-	}
-	return config.ResolveConfigValues(b.server.Spec.Config, schema)
+	return config.ResolveConfigValues(b.server.Spec.Config, b.configSchema())
+}
+
+// configSchema returns the GameDefinition config schema, or an empty schema in fallback mode.
+func (b *StatefulSetBuilder) configSchema() map[string]boilerrv1alpha1.ConfigSchemaEntry {
+	return gameDefConfigSchema(b.gameDef)
 }
 
-// getInterpolatedArgs returns args with config values interpolated.
+// getInterpolatedArgs returns args with config values interpolated,
+// followed by any flags from configSchema "arg" mappings.
 func (b *StatefulSetBuilder) getInterpolatedArgs(configValues map[string]string) []string {
 	args := b.getArgs()
-	if len(args) == 0 {
+	mappedArgs := config.ResolveArgMappings(b.configSchema(), configValues)
+	if len(args) == 0 && len(mappedArgs) == 0 {
 		return nil
 	}
 
 	interpolated, err := config.InterpolateArgs(args, configValues)
 	if err != nil {
-		// Fall back to raw args on error
-		return args
+		// Fall back to raw args on error, copied so mapped args aren't appended into the spec
+		interpolated = slices.Clone(args)
 	}
-	return interpolated
+	return append(interpolated, mappedArgs...)
 }
 
 // getResources returns the resource requirements.
@@ -176,10 +309,15 @@ func (b *StatefulSetBuilder) getImage() string {
 }
 
 // getInstallDir returns the install directory for SteamCMD.
-// Fallback: GameDefinition.InstallDir -> ServerFilesMountPath
 func (b *StatefulSetBuilder) getInstallDir() string {
-	if b.gameDef != nil && b.gameDef.Spec.InstallDir != "" {
-		return b.gameDef.Spec.InstallDir
+	return InstallDir(b.gameDef)
+}
+
+// InstallDir returns the directory a game is installed to, where the server volume is mounted.
+// Fallback: GameDefinition.InstallDir -> ServerFilesMountPath
+func InstallDir(gameDef *boilerrv1alpha1.GameDefinition) string {
+	if gameDef != nil && gameDef.Spec.InstallDir != "" {
+		return gameDef.Spec.InstallDir
 	}
 	return ServerFilesMountPath
 }
@@ -211,11 +349,16 @@ func (b *StatefulSetBuilder) getArgs() []string {
 // buildSteamCMDArgs generates the SteamCMD arguments using the steamcmd package.
 func (b *StatefulSetBuilder) buildSteamCMDArgs() []string {
 	cmdConfig := steamcmd.CommandConfig{
-		AppID:      b.getAppID(),
-		InstallDir: b.getInstallDir(),
-		Anonymous:  b.isAnonymous(),
-		Beta:       b.server.Spec.Beta,
-		Validate:   b.shouldValidate(),
+		AppID:        b.getAppID(),
+		InstallDir:   b.getInstallDir(),
+		Anonymous:    b.isAnonymous(),
+		Beta:         b.server.Spec.Beta,
+		BetaPassword: b.hasBetaPassword(),
+		Validate:     b.shouldValidate(),
+	}
+	if ws := workshop(b.server, b.gameDef); ws != nil {
+		cmdConfig.WorkshopAppID = ws.AppId
+		cmdConfig.WorkshopItems = workshopItems(b.server)
 	}
 
 	builder := steamcmd.NewCommandBuilder(cmdConfig)
@@ -234,6 +377,12 @@ func (b *StatefulSetBuilder) getAppID() int32 {
 	return 0
 }
 
+// AppID returns the Steam App ID a SteamServer installs.
+// Fallback: SteamServer.AppId -> GameDefinition.AppId -> 0
+func AppID(server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) int32 {
+	return NewStatefulSetBuilder(server, gameDef).getAppID()
+}
+
 // isAnonymous returns whether to use anonymous Steam login.
 func (b *StatefulSetBuilder) isAnonymous() bool {
 	if b.server.Spec.Anonymous == nil {
@@ -242,6 +391,29 @@ func (b *StatefulSetBuilder) isAnonymous() bool {
 	return *b.server.Spec.Anonymous
 }
 
+// hasBetaPassword returns whether SteamCMD passes a password for the beta branch.
+func (b *StatefulSetBuilder) hasBetaPassword() bool {
+	return b.server.Spec.Beta != "" && b.server.Spec.BetaPasswordSecretRef != nil
+}
+
+// installRetries returns how many times a failed SteamCMD run is retried.
+// Fallback: SteamServer.Install.Retries -> DefaultInstallRetries
+func (b *StatefulSetBuilder) installRetries() int32 {
+	if install := b.server.Spec.Install; install != nil && install.Retries != nil {
+		return *install.Retries
+	}
+	return DefaultInstallRetries
+}
+
+// installBackoff returns the wait before the first SteamCMD retry.
+// Fallback: SteamServer.Install.Backoff -> DefaultInstallBackoff
+func (b *StatefulSetBuilder) installBackoff() time.Duration {
+	if install := b.server.Spec.Install; install != nil && install.Backoff != nil {
+		return install.Backoff.Duration
+	}
+	return DefaultInstallBackoff
+}
+
 // shouldValidate returns whether to validate game files.
 func (b *StatefulSetBuilder) shouldValidate() bool {
 	if b.server.Spec.Validate == nil {
@@ -263,7 +435,7 @@ func (b *StatefulSetBuilder) buildInitEnvVars() []corev1.EnvVar {
 	if !b.isAnonymous() && b.server.Spec.SteamCredentialsSecret != "" {
 		envVars = append(envVars,
 			corev1.EnvVar{
-				Name: "STEAM_USERNAME",
+				Name: steamcmd.UsernameEnvVar,
 				ValueFrom: &corev1.EnvVarSource{
 					SecretKeyRef: &corev1.SecretKeySelector{
 						LocalObjectReference: corev1.LocalObjectReference{
@@ -274,7 +446,7 @@ func (b *StatefulSetBuilder) buildInitEnvVars() []corev1.EnvVar {
 				},
 			},
 			corev1.EnvVar{
-				Name: "STEAM_PASSWORD",
+				Name: steamcmd.PasswordEnvVar,
 				ValueFrom: &corev1.EnvVarSource{
 					SecretKeyRef: &corev1.SecretKeySelector{
 						LocalObjectReference: corev1.LocalObjectReference{
@@ -287,17 +459,28 @@ func (b *StatefulSetBuilder) buildInitEnvVars() []corev1.EnvVar {
 		)
 	}
 
+	// Add the private beta branch password from its secret
+	if b.hasBetaPassword() {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: steamcmd.BetaPasswordEnvVar,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: b.server.Spec.BetaPasswordSecretRef,
+			},
+		})
+	}
+
 	return envVars
 }
 
 // buildMainEnvVars creates environment variables for the main container.
-// Merges: GameDefinition.Env + SteamServer.Env + config secret refs
-func (b *StatefulSetBuilder) buildMainEnvVars(configEnvVars []corev1.EnvVar) []corev1.EnvVar {
+// Merges: GameDefinition.Env + config env mappings + SteamServer.Env + config secret refs
+func (b *StatefulSetBuilder) buildMainEnvVars(configValues map[string]string, configEnvVars []corev1.EnvVar) []corev1.EnvVar {
 	var gameDefEnv []corev1.EnvVar
 	if b.gameDef != nil {
 		gameDefEnv = b.gameDef.Spec.Env
 	}
-	return config.MergeEnvVars(gameDefEnv, b.server.Spec.Env, configEnvVars)
+	mappedEnv := config.ResolveEnvMappings(b.server.Spec.Config, b.configSchema(), configValues)
+	return config.MergeEnvVars(gameDefEnv, mappedEnv, b.server.Spec.Env, configEnvVars)
 }
 
 // getPorts returns the ports to expose.
@@ -346,10 +529,45 @@ func (b *StatefulSetBuilder) buildVolumes() []corev1.Volume {
 		},
 	}
 
+	// Add the game cache, read-only as other servers share it
+	if b.gameCache != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: GameCacheVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: b.gameCache,
+					ReadOnly:  true,
+				},
+			},
+		})
+	}
+
+	// Add the save data volume
+	if b.server.Spec.SaveStorage != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: SaveFilesVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: SavePVCName(b.server.Name),
+				},
+			},
+		})
+	}
+
+	// Add a shared volume for the a2sprobe and steamguard binaries
+	if b.usesA2SProbe() || b.usesSteamGuard() {
+		volumes = append(volumes, corev1.Volume{
+			Name: ProbeToolsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+
 	// Add config file volumes if specified
-	if len(b.server.Spec.ConfigFiles) > 0 {
+	if len(mergeConfigFiles(b.server, b.gameDef)) > 0 {
 		volumes = append(volumes, corev1.Volume{
-			Name: "config-files",
+			Name: ConfigFilesVolumeName,
 			VolumeSource: corev1.VolumeSource{
 				ConfigMap: &corev1.ConfigMapVolumeSource{
 					LocalObjectReference: corev1.LocalObjectReference{
@@ -365,19 +583,37 @@ func (b *StatefulSetBuilder) buildVolumes() []corev1.Volume {
 
 // buildVolumeMounts creates the volume mounts for the main container.
 func (b *StatefulSetBuilder) buildVolumeMounts() []corev1.VolumeMount {
-	mounts := []corev1.VolumeMount{
-		{
-			Name:      ServerFilesVolumeName,
-			MountPath: ServerFilesMountPath,
-		},
+	mounts := []corev1.VolumeMount{b.serverFilesVolumeMount()}
+
+	// The install directory links to the game cache
+	if b.gameCache != "" {
+		mounts = append(mounts, gameCacheVolumeMount())
+	}
+
+	// Keep save directories under SaveDirsSubPath of the save volume, or the server volume without one
+	for _, dir := range b.saveDirs() {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      b.saveVolumeName(),
+			MountPath: dir,
+			SubPath:   saveDirSubPath(dir),
+		})
 	}
 
-	// Add individual config file mounts
-	for i, cf := range b.server.Spec.ConfigFiles {
+	// Mount the a2sprobe binary for exec probes
+	if b.usesA2SProbe() {
 		mounts = append(mounts, corev1.VolumeMount{
-			Name:      "config-files",
-			MountPath: cf.Path,
-			SubPath:   fmt.Sprintf("config-%d", i),
+			Name:      ProbeToolsVolumeName,
+			MountPath: ProbeToolsMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	// Add individual config file mounts (GameDefinition templates + SteamServer files)
+	for i, cf := range mergeConfigFiles(b.server, b.gameDef) {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      ConfigFilesVolumeName,
+			MountPath: cf.path,
+			SubPath:   configFileKey(i),
 			ReadOnly:  true,
 		})
 	}
@@ -385,11 +621,120 @@ func (b *StatefulSetBuilder) buildVolumeMounts() []corev1.VolumeMount {
 	return mounts
 }
 
+// serverFilesVolumeMount mounts the server volume at the install directory,
+// so SteamCMD installs onto the volume and the game server finds the files there.
+func (b *StatefulSetBuilder) serverFilesVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      ServerFilesVolumeName,
+		MountPath: b.getInstallDir(),
+	}
+}
+
+// saveDirs returns the GameDefinition save directories.
+func (b *StatefulSetBuilder) saveDirs() []string {
+	if b.gameDef == nil {
+		return nil
+	}
+	return b.gameDef.Spec.SaveDirs
+}
+
+// saveVolumeName returns the volume holding the save directories.
+func (b *StatefulSetBuilder) saveVolumeName() string {
+	if b.server.Spec.SaveStorage != nil {
+		return SaveFilesVolumeName
+	}
+	return ServerFilesVolumeName
+}
+
+// saveDirSubPath returns the volume subpath of a save directory: SaveDirsSubPath and its absolute path,
+// so each save directory keeps a distinct, recognizable location that is the same on either volume.
+func saveDirSubPath(dir string) string {
+	return path.Join(SaveDirsSubPath, path.Clean("/"+dir))
+}
+
+// buildSaveMigrationContainer creates the init container that moves save data written inside the
+// install directory before the save directory was mounted over it. Data is only moved into an empty
+// save directory, so it runs once. Returns false if no save directory is inside the install directory.
+func (b *StatefulSetBuilder) buildSaveMigrationContainer() (corev1.Container, bool) {
+	installDir := path.Clean(b.getInstallDir())
+	saveRoot := installDir
+	mounts := []corev1.VolumeMount{b.serverFilesVolumeMount()}
+	if b.server.Spec.SaveStorage != nil {
+		saveRoot = SaveFilesMountPath
+		mounts = append(mounts, corev1.VolumeMount{Name: SaveFilesVolumeName, MountPath: SaveFilesMountPath})
+	}
+
+	var moves []string
+	for _, dir := range b.saveDirs() {
+		rel, ok := strings.CutPrefix(path.Clean(dir), installDir+"/")
+		if !ok {
+			continue
+		}
+		moves = append(moves, fmt.Sprintf(
+			`if [ -n "$(ls -A %[1]q 2>/dev/null)" ] && [ -z "$(ls -A %[2]q 2>/dev/null)" ]; then `+
+				`mkdir -p %[2]q && cp -a %[1]q/. %[2]q/ && rm -rf %[1]q; fi`,
+			path.Join(installDir, rel), path.Join(saveRoot, saveDirSubPath(dir))))
+	}
+	if len(moves) == 0 {
+		return corev1.Container{}, false
+	}
+
+	return corev1.Container{
+		Name:         SaveMigrationContainerName,
+		Image:        b.getImage(),
+		Command:      []string{"/bin/sh", "-c", "set -e; " + strings.Join(moves, "; ")},
+		VolumeMounts: mounts,
+	}, true
+}
+
+// PodTemplateHash returns a hash of a pod template, used to detect changes that restart the pod.
+func PodTemplateHash(template *corev1.PodTemplateSpec) string {
+	return SpecHash(template)
+}
+
+// SpecHash returns a hash of an object spec, used to skip updates that would change nothing.
+// Comparing specs directly doesn't work, since the API server fills in defaults.
+func SpecHash(spec any) string {
+	// Struct fields marshal in a fixed order and map keys are sorted, so the encoding is stable
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return ""
+	}
+	h := fnv.New64a()
+	_, _ = h.Write(data)
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+// ScaledDown returns whether the game server pod should not run:
+// the server is suspended, or its volume is being restored.
+func ScaledDown(server *boilerrv1alpha1.SteamServer) bool {
+	return Suspended(server) || Restoring(server)
+}
+
+// Suspended returns whether the server is suspended and hasn't been woken by a connecting player.
+func Suspended(server *boilerrv1alpha1.SteamServer) bool {
+	return server.Spec.Suspended && server.Status.WokenAt == nil
+}
+
 // PVCName returns the PVC name for a SteamServer.
 func PVCName(serverName string) string {
 	return serverName + "-data"
 }
 
+// SavePVCName returns the save data PVC name for a SteamServer.
+func SavePVCName(serverName string) string {
+	return serverName + "-saves"
+}
+
+// SaveClaimName returns the PVC holding a SteamServer's save data: the save volume if
+// saveStorage is set, otherwise the server volume. Backups, restores and snapshots use it.
+func SaveClaimName(server *boilerrv1alpha1.SteamServer) string {
+	if server.Spec.SaveStorage != nil {
+		return SavePVCName(server.Name)
+	}
+	return PVCName(server.Name)
+}
+
 // ConfigMapName returns the ConfigMap name for a SteamServer's config files.
 func ConfigMapName(serverName string) string {
 	return serverName + "-config"
@@ -1,8 +1,14 @@
 package resources
 
 import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -489,8 +495,8 @@ func TestStatefulSetBuilder_SteamCMDArgs(t *testing.T) {
 				"validate",
 			},
 			shouldNotContain: []string{
-				"$STEAM_USERNAME",
-				"$STEAM_PASSWORD",
+				"$(STEAM_USERNAME)",
+				"$(STEAM_PASSWORD)",
 			},
 		},
 		{
@@ -538,8 +544,8 @@ func TestStatefulSetBuilder_SteamCMDArgs(t *testing.T) {
 				},
 			},
 			shouldContain: []string{
-				"$STEAM_USERNAME",
-				"$STEAM_PASSWORD",
+				"$(STEAM_USERNAME)",
+				"$(STEAM_PASSWORD)",
 			},
 			shouldNotContain: []string{
 				"anonymous",
@@ -729,7 +735,7 @@ func TestStatefulSetBuilder_InitEnvVars(t *testing.T) {
 					},
 				},
 			},
-			expectedEnvCount:   3, // APP_ID + STEAM_USERNAME + STEAM_PASSWORD
+			expectedEnvCount:   4, // APP_ID + STEAM_USERNAME + STEAM_PASSWORD + HOME
 			checkCredentialEnv: true,
 		},
 	}
@@ -774,6 +780,686 @@ func TestStatefulSetBuilder_InitEnvVars(t *testing.T) {
 	}
 }
 
+func TestStatefulSetBuilder_ConfigEnvMappings(t *testing.T) {
+	gameDef := &boilerrv1alpha1.GameDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+		Spec: boilerrv1alpha1.GameDefinitionSpec{
+			AppId:   896660,
+			Command: "./valheim_server.x86_64",
+			Ports:   []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+			ConfigSchema: map[string]boilerrv1alpha1.ConfigSchemaEntry{
+				"maxPlayers": {
+					Default: "10",
+					MapTo:   &boilerrv1alpha1.ConfigMapping{Type: "env", Value: "MAX_PLAYERS"},
+				},
+				"password": {
+					Secret: true,
+					MapTo:  &boilerrv1alpha1.ConfigMapping{Type: "env", Value: "SERVER_PASSWORD"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		server *boilerrv1alpha1.SteamServer
+		checks func(t *testing.T, env map[string]corev1.EnvVar)
+	}{
+		{
+			name: "literal and secret values mapped to env",
+			server: &boilerrv1alpha1.SteamServer{
+				ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+				Spec: boilerrv1alpha1.SteamServerSpec{
+					GameDefinition: "valheim",
+					Config: map[string]boilerrv1alpha1.ConfigValue{
+						"maxPlayers": {Value: "20"},
+						"password": {SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "valheim-secrets"},
+							Key:                  "password",
+						}},
+					},
+				},
+			},
+			checks: func(t *testing.T, env map[string]corev1.EnvVar) {
+				if env["MAX_PLAYERS"].Value != "20" {
+					t.Errorf("expected MAX_PLAYERS=20, got %q", env["MAX_PLAYERS"].Value)
+				}
+				pw, ok := env["SERVER_PASSWORD"]
+				if !ok {
+					t.Fatal("SERVER_PASSWORD env var not found")
+				}
+				if pw.Value != "" {
+					t.Errorf("expected no literal value for SERVER_PASSWORD, got %q", pw.Value)
+				}
+				if pw.ValueFrom == nil || pw.ValueFrom.SecretKeyRef == nil {
+					t.Fatal("SERVER_PASSWORD should reference a secret")
+				}
+				if pw.ValueFrom.SecretKeyRef.Name != "valheim-secrets" {
+					t.Errorf("expected secret name 'valheim-secrets', got %s", pw.ValueFrom.SecretKeyRef.Name)
+				}
+			},
+		},
+		{
+			name: "schema default mapped when not configured",
+			server: &boilerrv1alpha1.SteamServer{
+				ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+				Spec:       boilerrv1alpha1.SteamServerSpec{GameDefinition: "valheim"},
+			},
+			checks: func(t *testing.T, env map[string]corev1.EnvVar) {
+				if env["MAX_PLAYERS"].Value != "10" {
+					t.Errorf("expected MAX_PLAYERS=10, got %q", env["MAX_PLAYERS"].Value)
+				}
+				if _, ok := env["SERVER_PASSWORD"]; ok {
+					t.Error("expected SERVER_PASSWORD to be absent when not configured")
+				}
+			},
+		},
+		{
+			name: "SteamServer env overrides mapped env",
+			server: &boilerrv1alpha1.SteamServer{
+				ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+				Spec: boilerrv1alpha1.SteamServerSpec{
+					GameDefinition: "valheim",
+					Config:         map[string]boilerrv1alpha1.ConfigValue{"maxPlayers": {Value: "20"}},
+					Env:            []corev1.EnvVar{{Name: "MAX_PLAYERS", Value: "64"}},
+				},
+			},
+			checks: func(t *testing.T, env map[string]corev1.EnvVar) {
+				if env["MAX_PLAYERS"].Value != "64" {
+					t.Errorf("expected MAX_PLAYERS=64, got %q", env["MAX_PLAYERS"].Value)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sts := NewStatefulSetBuilder(tt.server, gameDef).Build()
+			env := make(map[string]corev1.EnvVar)
+			for _, e := range sts.Spec.Template.Spec.Containers[0].Env {
+				env[e.Name] = e
+			}
+			tt.checks(t, env)
+		})
+	}
+}
+
+func TestStatefulSetBuilder_ConfigArgMappings(t *testing.T) {
+	gameDef := &boilerrv1alpha1.GameDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+		Spec: boilerrv1alpha1.GameDefinitionSpec{
+			AppId:   896660,
+			Command: "./valheim_server.x86_64",
+			Args:    []string{"-name", "{{.Config.serverName}}"},
+			Ports:   []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+			ConfigSchema: map[string]boilerrv1alpha1.ConfigSchemaEntry{
+				"serverName": {Default: "My Server"},
+				"crossplay": {
+					Default: "false",
+					MapTo:   &boilerrv1alpha1.ConfigMapping{Type: "arg", Value: "-crossplay", Condition: "true"},
+				},
+			},
+		},
+	}
+
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			GameDefinition: "valheim",
+			Config:         map[string]boilerrv1alpha1.ConfigValue{"crossplay": {Value: "true"}},
+		},
+	}
+
+	sts := NewStatefulSetBuilder(server, gameDef).Build()
+	args := sts.Spec.Template.Spec.Containers[0].Args
+	expected := []string{"-name", "My Server", "-crossplay"}
+	if len(args) != len(expected) {
+		t.Fatalf("expected args %v, got %v", expected, args)
+	}
+	for i := range expected {
+		if args[i] != expected[i] {
+			t.Errorf("args[%d] = %q, want %q", i, args[i], expected[i])
+		}
+	}
+}
+
+func TestStatefulSetBuilder_ConfigArgMappingsInvalidArgs(t *testing.T) {
+	// Spare capacity, as in a decoded list, must not receive the mapped args
+	specArgs := make([]string, 2, 4)
+	copy(specArgs, []string{"-name", "{{.Config.serverName"})
+	gameDef := &boilerrv1alpha1.GameDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+		Spec: boilerrv1alpha1.GameDefinitionSpec{
+			AppId:   896660,
+			Command: "./valheim_server.x86_64",
+			Args:    specArgs,
+			Ports:   []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+			ConfigSchema: map[string]boilerrv1alpha1.ConfigSchemaEntry{
+				"crossplay": {
+					Default: "true",
+					MapTo:   &boilerrv1alpha1.ConfigMapping{Type: "arg", Value: "-crossplay", Condition: "true"},
+				},
+			},
+		},
+	}
+
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec:       boilerrv1alpha1.SteamServerSpec{GameDefinition: "valheim"},
+	}
+
+	sts := NewStatefulSetBuilder(server, gameDef).Build()
+	args := sts.Spec.Template.Spec.Containers[0].Args
+	if len(args) != 3 || args[1] != "{{.Config.serverName" || args[2] != "-crossplay" {
+		t.Errorf("expected the raw args and mapped args, got %v", args)
+	}
+
+	args[0] = "-changed"
+	if spare := specArgs[:cap(specArgs)]; spare[0] != "-name" || spare[2] != "" {
+		t.Errorf("expected the GameDefinition args to be left untouched, got %v", spare)
+	}
+}
+
+func TestStatefulSetBuilder_GameDefinitionConfigFileMounts(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			GameDefinition: "valheim",
+			ConfigFiles: []boilerrv1alpha1.ConfigFile{
+				{Path: "/data/server/game.ini", Content: "custom"},
+				{Path: "/config/extra.cfg", Content: "extra"},
+			},
+		},
+	}
+
+	gameDef := &boilerrv1alpha1.GameDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+		Spec: boilerrv1alpha1.GameDefinitionSpec{
+			AppId:   896660,
+			Command: "./valheim_server.x86_64",
+			ConfigSchema: map[string]boilerrv1alpha1.ConfigSchemaEntry{
+				"settings": {
+					Default: "on",
+					MapTo:   &boilerrv1alpha1.ConfigMapping{Type: "configFile", Path: "/data/server/settings.json"},
+				},
+			},
+			ConfigFiles: []boilerrv1alpha1.ConfigFileTemplate{
+				{Path: "/data/saves/adminlist.txt", Content: "{{.Config.admins}}"},
+				{Path: "/data/server/game.ini", Content: "ServerName=Vikings"},
+			},
+		},
+	}
+
+	sts := NewStatefulSetBuilder(server, gameDef).Build()
+
+	foundVolume := false
+	for _, v := range sts.Spec.Template.Spec.Volumes {
+		if v.Name == ConfigFilesVolumeName {
+			foundVolume = true
+		}
+	}
+	if !foundVolume {
+		t.Error("expected config-files volume")
+	}
+
+	expected := map[string]string{
+		"/data/saves/adminlist.txt":  "config-0",
+		"/data/server/game.ini":      "config-1",
+		"/data/server/settings.json": "config-2",
+		"/config/extra.cfg":          "config-3",
+	}
+	found := 0
+	for _, m := range sts.Spec.Template.Spec.Containers[0].VolumeMounts {
+		if m.Name != ConfigFilesVolumeName {
+			continue
+		}
+		found++
+		if expected[m.MountPath] != m.SubPath {
+			t.Errorf("mount %s: expected subPath %q, got %q", m.MountPath, expected[m.MountPath], m.SubPath)
+		}
+	}
+	if found != len(expected) {
+		t.Errorf("expected %d config file mounts, got %d", len(expected), found)
+	}
+}
+
+func TestStatefulSetBuilder_BuildInfoContainer(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			AppId: int32Ptr(896660),
+			Ports: []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+		},
+	}
+
+	sts := NewStatefulSetBuilder(server, nil).Build()
+	initContainers := sts.Spec.Template.Spec.InitContainers
+	if len(initContainers) < 2 || initContainers[1].Name != BuildInfoContainerName {
+		t.Fatalf("expected %s init container after steamcmd", BuildInfoContainerName)
+	}
+
+	c := initContainers[1]
+	if c.TerminationMessagePath != corev1.TerminationMessagePathDefault {
+		t.Errorf("expected termination message path %s, got %s", corev1.TerminationMessagePathDefault, c.TerminationMessagePath)
+	}
+	script := c.Command[len(c.Command)-1]
+	if !strings.Contains(script, ServerFilesMountPath+"/steamapps/appmanifest_896660.acf") {
+		t.Errorf("expected script to read the app manifest, got %q", script)
+	}
+	if !strings.HasSuffix(script, "> "+corev1.TerminationMessagePathDefault) {
+		t.Errorf("expected script to write the termination message, got %q", script)
+	}
+}
+
+func TestStatefulSetBuilder_TargetBuild(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			AppId: int32Ptr(896660),
+			Ports: []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+		},
+	}
+
+	sts := NewStatefulSetBuilder(server, nil).Build()
+	if _, ok := sts.Spec.Template.Annotations[TargetBuildAnnotation]; ok {
+		t.Error("expected no target build annotation by default")
+	}
+
+	sts = NewStatefulSetBuilder(server, nil).WithTargetBuild("15632571").Build()
+	if got := sts.Spec.Template.Annotations[TargetBuildAnnotation]; got != "15632571" {
+		t.Errorf("expected target build annotation 15632571, got %q", got)
+	}
+}
+
+func TestStatefulSetBuilder_Suspended(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			AppId: int32Ptr(896660),
+			Ports: []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+		},
+	}
+
+	sts := NewStatefulSetBuilder(server, nil).Build()
+	if *sts.Spec.Replicas != 1 {
+		t.Errorf("expected 1 replica, got %d", *sts.Spec.Replicas)
+	}
+
+	server.Spec.Suspended = true
+	suspended := NewStatefulSetBuilder(server, nil).Build()
+	if *suspended.Spec.Replicas != 0 {
+		t.Errorf("expected 0 replicas when suspended, got %d", *suspended.Spec.Replicas)
+	}
+	if PodTemplateHash(&sts.Spec.Template) != PodTemplateHash(&suspended.Spec.Template) {
+		t.Error("expected suspending to leave the pod template unchanged")
+	}
+
+	server.Status.WokenAt = &metav1.Time{}
+	woken := NewStatefulSetBuilder(server, nil).Build()
+	if *woken.Spec.Replicas != 1 {
+		t.Errorf("expected 1 replica once woken, got %d", *woken.Spec.Replicas)
+	}
+
+	server.Spec.Suspended = false
+	server.Status.WokenAt = nil
+	server.Annotations = map[string]string{boilerrv1alpha1.RestoreLockAnnotation: "restore-1"}
+	locked := NewStatefulSetBuilder(server, nil).Build()
+	if *locked.Spec.Replicas != 0 {
+		t.Errorf("expected 0 replicas while a restore holds the lock, got %d", *locked.Spec.Replicas)
+	}
+}
+
+func TestStatefulSetBuilder_ScheduledRestart(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			AppId: int32Ptr(896660),
+			Ports: []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+		},
+	}
+
+	sts := NewStatefulSetBuilder(server, nil).Build()
+	if sts.Spec.Template.Annotations != nil {
+		t.Errorf("expected no pod template annotations, got %v", sts.Spec.Template.Annotations)
+	}
+
+	restarted := metav1.NewTime(time.Date(2026, 1, 1, 4, 0, 0, 0, time.UTC))
+	server.Status.LastScheduledRestart = &restarted
+	restartedSTS := NewStatefulSetBuilder(server, nil).Build()
+	if got := restartedSTS.Spec.Template.Annotations[RestartedAtAnnotation]; got != "2026-01-01T04:00:00Z" {
+		t.Errorf("expected restarted-at annotation 2026-01-01T04:00:00Z, got %q", got)
+	}
+	if PodTemplateHash(&sts.Spec.Template) == PodTemplateHash(&restartedSTS.Spec.Template) {
+		t.Error("expected a scheduled restart to change the pod template")
+	}
+}
+
+func TestStatefulSetBuilder_SaveDirs(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			GameDefinition: "valheim",
+		},
+	}
+	gameDef := &boilerrv1alpha1.GameDefinition{
+		Spec: boilerrv1alpha1.GameDefinitionSpec{
+			AppId:    896660,
+			Command:  "/serverfiles/valheim_server.x86_64",
+			Ports:    []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+			SaveDirs: []string{"/data/saves/", "/home/steam/.config/unity3d"},
+		},
+	}
+
+	findMount := func(sts *appsv1.StatefulSet, mountPath string) *corev1.VolumeMount {
+		for _, m := range sts.Spec.Template.Spec.Containers[0].VolumeMounts {
+			if m.MountPath == mountPath {
+				return &m
+			}
+		}
+		return nil
+	}
+
+	// Without saveStorage, save directories are kept on the server volume
+	sts := NewStatefulSetBuilder(server, gameDef).Build()
+	m := findMount(sts, "/home/steam/.config/unity3d")
+	if m == nil || m.Name != ServerFilesVolumeName || m.SubPath != "saves/home/steam/.config/unity3d" {
+		t.Errorf("expected the save directory on the server volume, got %v", m)
+	}
+	for _, v := range sts.Spec.Template.Spec.Volumes {
+		if v.Name == SaveFilesVolumeName {
+			t.Error("expected no save volume without saveStorage")
+		}
+	}
+
+	server.Spec.SaveStorage = &boilerrv1alpha1.SaveStorageSpec{Size: resource.MustParse("1Gi")}
+	sts = NewStatefulSetBuilder(server, gameDef).Build()
+	m = findMount(sts, "/data/saves/")
+	if m == nil || m.Name != SaveFilesVolumeName || m.SubPath != "saves/data/saves" {
+		t.Errorf("expected the save directory at the same subpath of the save volume, got %v", m)
+	}
+	var saveVolume *corev1.Volume
+	for i, v := range sts.Spec.Template.Spec.Volumes {
+		if v.Name == SaveFilesVolumeName {
+			saveVolume = &sts.Spec.Template.Spec.Volumes[i]
+		}
+	}
+	if saveVolume == nil || saveVolume.PersistentVolumeClaim.ClaimName != SavePVCName(testServerName) {
+		t.Errorf("expected the save PVC volume, got %v", saveVolume)
+	}
+	for _, c := range sts.Spec.Template.Spec.InitContainers {
+		if c.Name == SaveMigrationContainerName {
+			t.Error("expected no save migration without save directories in the install directory")
+		}
+	}
+}
+
+func TestStatefulSetBuilder_SaveMigration(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	installDir := t.TempDir()
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			GameDefinition: "valheim",
+		},
+	}
+	gameDef := &boilerrv1alpha1.GameDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+		Spec: boilerrv1alpha1.GameDefinitionSpec{
+			AppId:      896660,
+			InstallDir: installDir,
+			Command:    "./valheim_server.x86_64",
+			Ports:      []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+			SaveDirs:   []string{installDir + "/worlds", "/home/steam/.config/unity3d"},
+		},
+	}
+
+	var migrate *corev1.Container
+	sts := NewStatefulSetBuilder(server, gameDef).Build()
+	for i, c := range sts.Spec.Template.Spec.InitContainers {
+		if c.Name == SaveMigrationContainerName {
+			migrate = &sts.Spec.Template.Spec.InitContainers[i]
+		}
+	}
+	if migrate == nil {
+		t.Fatal("expected a save migration container for a save directory in the install directory")
+	}
+	if strings.Contains(migrate.Command[2], "unity3d") {
+		t.Errorf("expected only save directories in the install directory to be migrated, got %s", migrate.Command[2])
+	}
+
+	// World data written before the save directory was mounted moves to its subpath
+	old := filepath.Join(installDir, "worlds")
+	if err := os.MkdirAll(old, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(old, "world.db"), []byte("world"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		if out, err := exec.Command("sh", "-c", migrate.Command[2]).CombinedOutput(); err != nil {
+			t.Fatalf("migration failed: %v: %s", err, out)
+		}
+	}
+	moved := filepath.Join(installDir, saveDirSubPath(installDir+"/worlds"), "world.db")
+	if data, err := os.ReadFile(moved); err != nil || string(data) != "world" {
+		t.Errorf("expected the world at %s, got %q, %v", moved, data, err)
+	}
+	if _, err := os.Stat(old); !os.IsNotExist(err) {
+		t.Error("expected the old save directory to be removed")
+	}
+}
+
+func TestStatefulSetBuilder_BetaPassword(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			AppId: int32Ptr(896660),
+			Ports: []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+			Beta:  "private",
+			BetaPasswordSecretRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "beta"},
+				Key:                  "password",
+			},
+		},
+	}
+
+	steamcmd := NewStatefulSetBuilder(server, nil).Build().Spec.Template.Spec.InitContainers[0]
+	if !strings.Contains(strings.Join(steamcmd.Args, " "), "-beta private -betapassword $(STEAM_BETA_PASSWORD)") {
+		t.Errorf("expected the beta password as an env var reference, got %v", steamcmd.Args)
+	}
+	var env *corev1.EnvVar
+	for i := range steamcmd.Env {
+		if steamcmd.Env[i].Name == "STEAM_BETA_PASSWORD" {
+			env = &steamcmd.Env[i]
+		}
+	}
+	if env == nil || env.ValueFrom == nil || env.ValueFrom.SecretKeyRef.Name != "beta" || env.ValueFrom.SecretKeyRef.Key != "password" {
+		t.Errorf("expected STEAM_BETA_PASSWORD from the secret, got %v", env)
+	}
+
+	// Without a beta branch the password is not passed
+	server.Spec.Beta = ""
+	steamcmd = NewStatefulSetBuilder(server, nil).Build().Spec.Template.Spec.InitContainers[0]
+	if strings.Contains(strings.Join(steamcmd.Args, " "), "-betapassword") || len(steamcmd.Env) != 1 {
+		t.Errorf("expected no beta password without beta, got args %v, env %v", steamcmd.Args, steamcmd.Env)
+	}
+}
+
+func TestStatefulSetBuilder_SteamGuard(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			AppId:                  int32Ptr(896660),
+			Ports:                  []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+			Anonymous:              boolPtr(false),
+			SteamCredentialsSecret: "steam-account",
+			SteamGuard: &boilerrv1alpha1.SteamGuardSpec{
+				SharedSecretRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "steam-account"},
+					Key:                  "shared_secret",
+				},
+			},
+		},
+	}
+
+	pod := NewStatefulSetBuilder(server, nil).WithProbeImage("boilerr:test").Build().Spec.Template.Spec
+	if len(pod.InitContainers) != 3 || pod.InitContainers[0].Name != SteamGuardInstallContainerName {
+		t.Fatalf("expected the steamguard installer before SteamCMD, got %d init containers", len(pod.InitContainers))
+	}
+	steamcmd := pod.InitContainers[1]
+	if got := strings.Join(steamcmd.Command[4:], " "); got != ProbeToolsMountPath+"/steamguard steamcmd" {
+		t.Errorf("expected SteamCMD run by the steamguard helper, got %v", steamcmd.Command)
+	}
+	if !strings.Contains(strings.Join(steamcmd.Args, " "), "+login $(STEAM_USERNAME) $(STEAM_PASSWORD)") {
+		t.Errorf("expected the credentials as env var references, got %v", steamcmd.Args)
+	}
+
+	env := map[string]corev1.EnvVar{}
+	for _, e := range steamcmd.Env {
+		env[e.Name] = e
+	}
+	if secret := env["STEAM_GUARD_SHARED_SECRET"]; secret.ValueFrom == nil || secret.ValueFrom.SecretKeyRef.Key != "shared_secret" {
+		t.Errorf("expected STEAM_GUARD_SHARED_SECRET from the secret, got %v", secret)
+	}
+	if home := env["HOME"]; home.Value != SteamCMDHomeDir {
+		t.Errorf("expected HOME %s, got %q", SteamCMDHomeDir, home.Value)
+	}
+
+	var homeMount, toolsMount bool
+	for _, m := range steamcmd.VolumeMounts {
+		homeMount = homeMount || (m.Name == ServerFilesVolumeName && m.MountPath == SteamCMDHomeDir && m.SubPath == SteamCMDHomeSubPath)
+		toolsMount = toolsMount || m.Name == ProbeToolsVolumeName
+	}
+	if !homeMount || !toolsMount {
+		t.Errorf("expected the SteamCMD home on the server volume and the tools volume, got %v", steamcmd.VolumeMounts)
+	}
+	hasToolsVolume := false
+	for _, v := range pod.Volumes {
+		hasToolsVolume = hasToolsVolume || v.Name == ProbeToolsVolumeName
+	}
+	if !hasToolsVolume {
+		t.Error("expected the tools volume for the steamguard helper")
+	}
+
+	// Without the probe image, SteamCMD runs directly and still keeps its login token
+	steamcmd = NewStatefulSetBuilder(server, nil).Build().Spec.Template.Spec.InitContainers[0]
+	if strings.Join(steamcmd.Command[4:], " ") != "steamcmd" || len(steamcmd.VolumeMounts) != 2 {
+		t.Errorf("expected SteamCMD without the helper, got command %v, mounts %v", steamcmd.Command, steamcmd.VolumeMounts)
+	}
+
+	// Anonymous logins keep the image's home directory
+	server.Spec.Anonymous = nil
+	steamcmd = NewStatefulSetBuilder(server, nil).WithProbeImage("boilerr:test").Build().Spec.Template.Spec.InitContainers[0]
+	if steamcmd.Name != InitContainerName || len(steamcmd.Env) != 1 || len(steamcmd.VolumeMounts) != 1 {
+		t.Errorf("expected an anonymous SteamCMD container, got env %v, mounts %v", steamcmd.Env, steamcmd.VolumeMounts)
+	}
+}
+
+func TestStatefulSetBuilder_InstallRetries(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			AppId: int32Ptr(896660),
+			Ports: []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+		},
+	}
+
+	steamcmd := NewStatefulSetBuilder(server, nil).Build().Spec.Template.Spec.InitContainers[0]
+	if len(steamcmd.Command) != 5 || steamcmd.Command[0] != "/bin/sh" || steamcmd.Command[4] != "steamcmd" {
+		t.Fatalf("expected SteamCMD run by the retry loop, got %v", steamcmd.Command)
+	}
+	if script := steamcmd.Command[2]; !strings.Contains(script, "attempts=4; delay=30;") {
+		t.Errorf("expected 3 retries after 30s by default, got %s", script)
+	}
+	if !strings.Contains(strings.Join(steamcmd.Args, " "), "+app_update 896660") {
+		t.Errorf("expected the SteamCMD arguments unchanged, got %v", steamcmd.Args)
+	}
+
+	retries := int32(0)
+	server.Spec.Install = &boilerrv1alpha1.InstallSpec{
+		Retries: &retries,
+		Backoff: &metav1.Duration{Duration: time.Minute},
+	}
+	steamcmd = NewStatefulSetBuilder(server, nil).Build().Spec.Template.Spec.InitContainers[0]
+	if script := steamcmd.Command[2]; !strings.Contains(script, "attempts=1; delay=60;") {
+		t.Errorf("expected a single attempt, got %s", script)
+	}
+}
+
+func TestStatefulSetBuilder_InstallDir(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			GameDefinition: "valheim",
+		},
+	}
+	gameDef := &boilerrv1alpha1.GameDefinition{
+		Spec: boilerrv1alpha1.GameDefinitionSpec{
+			AppId:      896660,
+			InstallDir: "/data/server",
+			Command:    "./valheim_server.x86_64",
+			Ports:      []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		gameDef    *boilerrv1alpha1.GameDefinition
+		installDir string
+	}{
+		{name: "GameDefinition installDir", gameDef: gameDef, installDir: "/data/server"},
+		{name: "fallback mode", gameDef: nil, installDir: ServerFilesMountPath},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStatefulSetBuilder(server, tt.gameDef).Build()
+			podSpec := s.Spec.Template.Spec
+
+			steamcmd := podSpec.InitContainers[0]
+			if m := steamcmd.VolumeMounts[0]; m.Name != ServerFilesVolumeName || m.MountPath != tt.installDir {
+				t.Errorf("expected SteamCMD to mount the server volume at %s, got %v", tt.installDir, m)
+			}
+			if !strings.Contains(strings.Join(steamcmd.Args, " "), "+force_install_dir "+tt.installDir) {
+				t.Errorf("expected SteamCMD to install into %s, got %v", tt.installDir, steamcmd.Args)
+			}
+
+			main := podSpec.Containers[0]
+			if m := main.VolumeMounts[0]; m.Name != ServerFilesVolumeName || m.MountPath != tt.installDir {
+				t.Errorf("expected the game server to mount the server volume at %s, got %v", tt.installDir, m)
+			}
+			if main.WorkingDir != tt.installDir {
+				t.Errorf("expected workingDir %s, got %q", tt.installDir, main.WorkingDir)
+			}
+		})
+	}
+}
+
+func TestPodTemplateHash(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			AppId: int32Ptr(896660),
+			Ports: []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+		},
+	}
+
+	first := NewStatefulSetBuilder(server, nil).Build()
+	second := NewStatefulSetBuilder(server, nil).Build()
+	if PodTemplateHash(&first.Spec.Template) != PodTemplateHash(&second.Spec.Template) {
+		t.Error("expected identical templates to hash the same")
+	}
+
+	server.Spec.Image = "custom/steamcmd:v2"
+	changed := NewStatefulSetBuilder(server, nil).Build()
+	if PodTemplateHash(&first.Spec.Template) == PodTemplateHash(&changed.Spec.Template) {
+		t.Error("expected a changed template to hash differently")
+	}
+}
+
 func TestPVCName(t *testing.T) {
 	tests := []struct {
 		serverName string
@@ -794,6 +1480,18 @@ func TestPVCName(t *testing.T) {
 	}
 }
 
+func TestSaveClaimName(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{ObjectMeta: metav1.ObjectMeta{Name: "valheim"}}
+	if got := SaveClaimName(server); got != "valheim-data" {
+		t.Errorf("expected the server PVC without saveStorage, got %s", got)
+	}
+
+	server.Spec.SaveStorage = &boilerrv1alpha1.SaveStorageSpec{Size: resource.MustParse("1Gi")}
+	if got := SaveClaimName(server); got != "valheim-saves" {
+		t.Errorf("expected the save PVC with saveStorage, got %s", got)
+	}
+}
+
 func TestConfigMapName(t *testing.T) {
 	tests := []struct {
 		serverName string
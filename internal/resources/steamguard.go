@@ -0,0 +1,95 @@
+package resources
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/CraightonH/boilerr/internal/steamcmd"
+)
+
+const (
+	// SteamGuardInstallContainerName is the name of the init container that installs the steamguard helper.
+	SteamGuardInstallContainerName = "steamguard-install"
+	// SteamGuardBinaryPath is the path of the steamguard helper in the operator image.
+	SteamGuardBinaryPath = "/steamguard"
+	// SteamCMDHomeDir is the home directory of SteamCMD for authenticated logins.
+	// SteamCMD keeps the login token of the account in config.vdf below it.
+	SteamCMDHomeDir = "/boilerr/steamcmd"
+	// SteamCMDHomeSubPath is the directory of the server volume mounted at SteamCMDHomeDir,
+	// so the login token survives pod restarts and later logins skip Steam Guard.
+	SteamCMDHomeSubPath = ".steamcmd"
+)
+
+// authenticated returns whether SteamCMD logs in with the credentials of a Steam account.
+func (b *StatefulSetBuilder) authenticated() bool {
+	return !b.isAnonymous() && b.server.Spec.SteamCredentialsSecret != ""
+}
+
+// usesSteamGuard returns whether SteamCMD runs through the steamguard helper, which passes it a Steam Guard code.
+// Requires an authenticated login with a shared secret and a configured probe image, which provides the helper.
+func (b *StatefulSetBuilder) usesSteamGuard() bool {
+	return b.authenticated() && b.server.Spec.SteamGuard != nil && b.probeImage != "" && b.gameCache == ""
+}
+
+// steamCMDCommand returns the command the SteamCMD init container runs on each attempt.
+// With Steam Guard, the helper computes a fresh code and then runs SteamCMD, so the secret never reaches a shell.
+func (b *StatefulSetBuilder) steamCMDCommand() []string {
+	if b.usesSteamGuard() {
+		return []string{ProbeToolsMountPath + "/steamguard", "steamcmd"}
+	}
+	return []string{"steamcmd"}
+}
+
+// steamCMDLoginEnvVars returns the environment variables of authenticated SteamCMD logins:
+// the persistent home directory and the Steam Guard shared secret.
+func (b *StatefulSetBuilder) steamCMDLoginEnvVars() []corev1.EnvVar {
+	if !b.authenticated() {
+		return nil
+	}
+	envVars := []corev1.EnvVar{{Name: "HOME", Value: SteamCMDHomeDir}}
+	if b.usesSteamGuard() {
+		secretRef := b.server.Spec.SteamGuard.SharedSecretRef
+		envVars = append(envVars, corev1.EnvVar{
+			Name:      steamcmd.GuardSharedSecretEnvVar,
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &secretRef},
+		})
+	}
+	return envVars
+}
+
+// steamCMDLoginVolumeMounts returns the volume mounts of authenticated SteamCMD logins:
+// the home directory on the server volume, and the steamguard helper.
+func (b *StatefulSetBuilder) steamCMDLoginVolumeMounts() []corev1.VolumeMount {
+	if !b.authenticated() {
+		return nil
+	}
+	mounts := []corev1.VolumeMount{
+		{
+			Name:      ServerFilesVolumeName,
+			MountPath: SteamCMDHomeDir,
+			SubPath:   SteamCMDHomeSubPath,
+		},
+	}
+	if b.usesSteamGuard() {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      ProbeToolsVolumeName,
+			MountPath: ProbeToolsMountPath,
+			ReadOnly:  true,
+		})
+	}
+	return mounts
+}
+
+// buildSteamGuardInstallContainer creates the init container that copies the steamguard helper into the shared volume.
+func (b *StatefulSetBuilder) buildSteamGuardInstallContainer() corev1.Container {
+	return corev1.Container{
+		Name:    SteamGuardInstallContainerName,
+		Image:   b.probeImage,
+		Command: []string{SteamGuardBinaryPath, "-install", ProbeToolsMountPath + "/steamguard"},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      ProbeToolsVolumeName,
+				MountPath: ProbeToolsMountPath,
+			},
+		},
+	}
+}
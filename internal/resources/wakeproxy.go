@@ -0,0 +1,173 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+)
+
+const (
+	// WakeProxyContainerName is the name of the wake-on-connect proxy container.
+	WakeProxyContainerName = "wakeproxy"
+	// WakeProxyBinaryPath is the path of wakeproxy in the operator image.
+	WakeProxyBinaryPath = "/wakeproxy"
+	// WakeTokenKey is the key of the wake token in the wake token Secret.
+	WakeTokenKey = "token"
+	// WakeTokenEnvVar is the environment variable the wake proxy reads its token from.
+	WakeTokenEnvVar = "WAKE_TOKEN"
+)
+
+// WakeProxyBuilder builds the wake-on-connect proxy Deployment for a suspended SteamServer.
+// The proxy listens on the server's container ports and takes over the Service while the server is stopped.
+type WakeProxyBuilder struct {
+	server  *boilerrv1alpha1.SteamServer
+	gameDef *boilerrv1alpha1.GameDefinition
+	image   string
+	wakeURL string
+}
+
+// NewWakeProxyBuilder creates a new WakeProxyBuilder.
+// gameDef can be nil for backwards compatibility (fallback mode).
+func NewWakeProxyBuilder(server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) *WakeProxyBuilder {
+	return &WakeProxyBuilder{server: server, gameDef: gameDef}
+}
+
+// WithImage sets the operator image that provides the wakeproxy binary.
+func (b *WakeProxyBuilder) WithImage(image string) *WakeProxyBuilder {
+	b.image = image
+	return b
+}
+
+// WithWakeURL sets the URL the proxy posts to when a player connects.
+func (b *WakeProxyBuilder) WithWakeURL(url string) *WakeProxyBuilder {
+	b.wakeURL = url
+	return b
+}
+
+// Build creates the wake proxy Deployment.
+func (b *WakeProxyBuilder) Build() *appsv1.Deployment {
+	labels := WakeProxyLabels(b.server)
+	replicas := int32(1)
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      WakeProxyName(b.server.Name),
+			Namespace: b.server.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{b.buildContainer()},
+				},
+			},
+		},
+	}
+}
+
+// BuildTokenSecret creates the Secret holding the token the proxy sends with wake requests.
+func (b *WakeProxyBuilder) BuildTokenSecret(token string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      WakeTokenSecretName(b.server.Name),
+			Namespace: b.server.Namespace,
+			Labels:    WakeProxyLabels(b.server),
+		},
+		StringData: map[string]string{WakeTokenKey: token},
+	}
+}
+
+// buildContainer creates the wakeproxy container.
+func (b *WakeProxyBuilder) buildContainer() corev1.Container {
+	ports := b.getPorts()
+	containerPorts := make([]corev1.ContainerPort, len(ports))
+	portArgs := make([]string, len(ports))
+	for i, port := range ports {
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolUDP
+		}
+		containerPorts[i] = corev1.ContainerPort{
+			Name:          port.Name,
+			ContainerPort: port.ContainerPort,
+			Protocol:      protocol,
+		}
+		portArgs[i] = fmt.Sprintf("%d/%s", port.ContainerPort, protocol)
+	}
+
+	return corev1.Container{
+		Name:  WakeProxyContainerName,
+		Image: b.image,
+		Command: []string{
+			WakeProxyBinaryPath,
+			"-ports", strings.Join(portArgs, ","),
+			"-name", fmt.Sprintf("%s (stopped, join to start)", b.server.Name),
+			"-wake-url", b.wakeURL,
+		},
+		Env: []corev1.EnvVar{{
+			Name: WakeTokenEnvVar,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: WakeTokenSecretName(b.server.Name)},
+					Key:                  WakeTokenKey,
+				},
+			},
+		}},
+		Ports: containerPorts,
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("10m"),
+				corev1.ResourceMemory: resource.MustParse("16Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("64Mi"),
+			},
+		},
+	}
+}
+
+// getPorts returns the ports to listen on.
+// Fallback: SteamServer.Ports -> GameDefinition.Ports -> empty
+func (b *WakeProxyBuilder) getPorts() []boilerrv1alpha1.ServerPort {
+	if len(b.server.Spec.Ports) > 0 {
+		return b.server.Spec.Ports
+	}
+	if b.gameDef != nil {
+		return b.gameDef.Spec.Ports
+	}
+	return nil
+}
+
+// WakeProxyName returns the wake proxy Deployment name for a SteamServer.
+func WakeProxyName(serverName string) string {
+	return serverName + "-wakeproxy"
+}
+
+// WakeTokenSecretName returns the name of the Secret holding a SteamServer's wake token.
+func WakeTokenSecretName(serverName string) string {
+	return serverName + "-wake"
+}
+
+// WakeProxyLabels returns the labels of the wake proxy pods.
+// They differ from the game server pod labels so the Service can select one or the other.
+func WakeProxyLabels(server *boilerrv1alpha1.SteamServer) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       "wakeproxy",
+		"app.kubernetes.io/instance":   server.Name,
+		"app.kubernetes.io/managed-by": "boilerr",
+		"boilerr.dev/game":             server.Spec.GameDefinition,
+	}
+}
@@ -0,0 +1,77 @@
+package resources
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+)
+
+func TestWakeProxyBuilder_Build(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			GameDefinition: "valheim",
+		},
+	}
+	gameDef := &boilerrv1alpha1.GameDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+		Spec: boilerrv1alpha1.GameDefinitionSpec{
+			Ports: []boilerrv1alpha1.ServerPort{
+				{Name: "game", ContainerPort: 2456},
+				{Name: "rcon", ContainerPort: 25575, Protocol: corev1.ProtocolTCP},
+			},
+		},
+	}
+
+	deploy := NewWakeProxyBuilder(server, gameDef).
+		WithImage("ghcr.io/craightonh/boilerr:v1").
+		WithWakeURL("http://boilerr-wake:8082/wake/default/test-server").
+		Build()
+
+	if deploy.Name != WakeProxyName(testServerName) {
+		t.Errorf("expected name %s, got %s", WakeProxyName(testServerName), deploy.Name)
+	}
+	if deploy.Spec.Template.Labels["app.kubernetes.io/instance"] != testServerName {
+		t.Errorf("expected instance label %s, got %v", testServerName, deploy.Spec.Template.Labels)
+	}
+	if deploy.Spec.Template.Labels["app.kubernetes.io/name"] == "steamserver" {
+		t.Error("expected wake proxy pods not to match the game server selector")
+	}
+
+	c := deploy.Spec.Template.Spec.Containers[0]
+	if c.Image != "ghcr.io/craightonh/boilerr:v1" {
+		t.Errorf("expected operator image, got %s", c.Image)
+	}
+	expected := []string{
+		WakeProxyBinaryPath,
+		"-ports", "2456/UDP,25575/TCP",
+		"-name", testServerName + " (stopped, join to start)",
+		"-wake-url", "http://boilerr-wake:8082/wake/default/test-server",
+	}
+	if len(c.Command) != len(expected) {
+		t.Fatalf("expected command %v, got %v", expected, c.Command)
+	}
+	for i := range expected {
+		if c.Command[i] != expected[i] {
+			t.Errorf("command[%d] = %q, want %q", i, c.Command[i], expected[i])
+		}
+	}
+	if len(c.Ports) != 2 || c.Ports[1].Protocol != corev1.ProtocolTCP {
+		t.Errorf("expected container ports to mirror the server ports, got %v", c.Ports)
+	}
+	if len(c.Env) != 1 || c.Env[0].Name != WakeTokenEnvVar || c.Env[0].ValueFrom == nil ||
+		c.Env[0].ValueFrom.SecretKeyRef.Name != WakeTokenSecretName(testServerName) {
+		t.Errorf("expected the wake token from the wake Secret, got %v", c.Env)
+	}
+
+	secret := NewWakeProxyBuilder(server, gameDef).BuildTokenSecret("s3cret")
+	if secret.Name != WakeTokenSecretName(testServerName) || secret.Namespace != testNamespace {
+		t.Errorf("expected Secret %s/%s, got %s/%s", testNamespace, WakeTokenSecretName(testServerName), secret.Namespace, secret.Name)
+	}
+	if secret.StringData[WakeTokenKey] != "s3cret" {
+		t.Errorf("expected token in key %s, got %v", WakeTokenKey, secret.StringData)
+	}
+}
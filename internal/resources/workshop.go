@@ -0,0 +1,172 @@
+package resources
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/steamcmd"
+)
+
+const (
+	// WorkshopContainerName is the name of the init container that places Workshop mods in the
+	// GameDefinition modDir and reports the installed versions.
+	WorkshopContainerName = "workshop"
+	// workshopCopyMarker marks the mod directories copied into modDir, so mods removed from
+	// SteamServer.mods are cleaned up without touching the game's own files.
+	workshopCopyMarker = ".boilerr-workshop"
+)
+
+// modListData is the data of a GameDefinition modList template.
+type modListData struct {
+	Mods []modListEntry
+}
+
+// modListEntry is an enabled mod in a modList template.
+type modListEntry struct {
+	// ID is the Workshop item ID.
+	ID int64
+	// Path is where the game finds the mod.
+	Path string
+}
+
+// EnabledMods returns the Workshop mods of a SteamServer to install, in spec order.
+func EnabledMods(server *boilerrv1alpha1.SteamServer) []boilerrv1alpha1.WorkshopMod {
+	var mods []boilerrv1alpha1.WorkshopMod
+	for _, mod := range server.Spec.Mods {
+		if mod.Enabled == nil || *mod.Enabled {
+			mods = append(mods, mod)
+		}
+	}
+	return mods
+}
+
+// workshop returns the GameDefinition workshop block if the server installs any mods.
+func workshop(server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) *boilerrv1alpha1.WorkshopSpec {
+	if gameDef == nil || gameDef.Spec.Workshop == nil || len(EnabledMods(server)) == 0 {
+		return nil
+	}
+	return gameDef.Spec.Workshop
+}
+
+// workshopItems returns the Workshop item IDs SteamCMD downloads.
+func workshopItems(server *boilerrv1alpha1.SteamServer) []int64 {
+	var ids []int64
+	for _, mod := range EnabledMods(server) {
+		ids = append(ids, mod.ID)
+	}
+	return ids
+}
+
+// installPath resolves a GameDefinition path relative to the install directory.
+func installPath(gameDef *boilerrv1alpha1.GameDefinition, p string) string {
+	if path.IsAbs(p) {
+		return path.Clean(p)
+	}
+	return path.Join(InstallDir(gameDef), p)
+}
+
+// modDir returns the directory mods are placed in, or "" if they are loaded from where SteamCMD downloads them.
+func modDir(gameDef *boilerrv1alpha1.GameDefinition) string {
+	ws := gameDef.Spec.Workshop
+	if ws.ModDir == "" {
+		return ""
+	}
+	dir := installPath(gameDef, ws.ModDir)
+	if dir == steamcmd.WorkshopContentDir(InstallDir(gameDef), ws.AppId) {
+		return ""
+	}
+	return dir
+}
+
+// modListEntries returns the enabled mods with where the game finds them.
+func modListEntries(server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) []modListEntry {
+	dir := modDir(gameDef)
+	if dir == "" {
+		dir = steamcmd.WorkshopContentDir(InstallDir(gameDef), gameDef.Spec.Workshop.AppId)
+	}
+
+	var entries []modListEntry
+	for _, id := range workshopItems(server) {
+		entries = append(entries, modListEntry{ID: id, Path: path.Join(dir, fmt.Sprintf("%d", id))})
+	}
+	return entries
+}
+
+// modListFile returns the mod list file of a server that installs mods, or nil if the game has none.
+func modListFile(server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) *configFileSource {
+	ws := workshop(server, gameDef)
+	if ws == nil || ws.ModList == nil {
+		return nil
+	}
+	return &configFileSource{path: installPath(gameDef, ws.ModList.Path), content: ws.ModList.Template, modList: true}
+}
+
+// renderModList renders a modList template for the enabled mods.
+func renderModList(content string, server *boilerrv1alpha1.SteamServer, gameDef *boilerrv1alpha1.GameDefinition) (string, error) {
+	tmpl, err := template.New("modList").Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, modListData{Mods: modListEntries(server, gameDef)}); err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// buildWorkshopContainer creates the init container that runs after SteamCMD has downloaded the mods.
+// It links or copies each mod into modDir, replacing the mods placed by an earlier install, and fails
+// if a mod was not downloaded. The installed versions are written as its termination message, which
+// the controller surfaces in status.mods and compares with the pinned versions.
+func (b *StatefulSetBuilder) buildWorkshopContainer() corev1.Container {
+	ws := b.gameDef.Spec.Workshop
+	installDir := b.getInstallDir()
+	content := steamcmd.WorkshopContentDir(installDir, ws.AppId)
+	dir := modDir(b.gameDef)
+
+	var script strings.Builder
+	fmt.Fprintf(&script, `set -e; fail() { echo "$1" | tee %s; exit 1; }; content=%q; `,
+		corev1.TerminationMessagePathDefault, content)
+
+	if dir != "" {
+		fmt.Fprintf(&script, `dir=%q; mkdir -p "$dir"; `, dir)
+		if ws.Method == boilerrv1alpha1.WorkshopMethodCopy {
+			fmt.Fprintf(&script, `for marker in "$dir"/*/%s; do if [ -e "$marker" ]; then rm -rf "${marker%%/*}"; fi; done; `,
+				workshopCopyMarker)
+		} else {
+			script.WriteString(`find "$dir" -maxdepth 1 -lname "$content/*" -delete; `)
+		}
+	}
+
+	mods := EnabledMods(b.server)
+	for _, mod := range mods {
+		fmt.Fprintf(&script, `[ -d "$content/%[1]d" ] || fail "Workshop item %[1]d was not downloaded"; `, mod.ID)
+		switch {
+		case dir == "":
+		case ws.Method == boilerrv1alpha1.WorkshopMethodCopy:
+			fmt.Fprintf(&script, `rm -rf "$dir/%[1]d"; cp -a "$content/%[1]d" "$dir/%[1]d"; touch "$dir/%[1]d/%[2]s"; `,
+				mod.ID, workshopCopyMarker)
+		default:
+			fmt.Fprintf(&script, `rm -rf "$dir/%[1]d"; ln -s "$content/%[1]d" "$dir/%[1]d"; `, mod.ID)
+		}
+	}
+
+	fmt.Fprintf(&script, `items=$(%s); `, steamcmd.WorkshopItemsScript(installDir, ws.AppId, workshopItems(b.server)))
+	fmt.Fprintf(&script, `echo "$items" > %s`, corev1.TerminationMessagePathDefault)
+
+	return corev1.Container{
+		Name:                     WorkshopContainerName,
+		Image:                    b.getImage(),
+		Command:                  []string{"/bin/sh", "-c", script.String()},
+		VolumeMounts:             b.installVolumeMounts(),
+		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+	}
+}
@@ -0,0 +1,272 @@
+package resources
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	boilerrv1alpha1 "github.com/CraightonH/boilerr/api/v1alpha1"
+	"github.com/CraightonH/boilerr/internal/steamcmd"
+)
+
+func TestStatefulSetBuilder_Workshop(t *testing.T) {
+	server := &boilerrv1alpha1.SteamServer{
+		ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+		Spec: boilerrv1alpha1.SteamServerSpec{
+			GameDefinition: "valheim",
+			Mods: []boilerrv1alpha1.WorkshopMod{
+				{ID: 2875458925},
+				{ID: 1111111111, Enabled: boolPtr(false)},
+				{ID: 2964470125, Version: "42"},
+			},
+		},
+	}
+	gameDef := &boilerrv1alpha1.GameDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+		Spec: boilerrv1alpha1.GameDefinitionSpec{
+			AppId:      896660,
+			InstallDir: "/data/server",
+			Command:    "./valheim_server.x86_64",
+			Ports:      []boilerrv1alpha1.ServerPort{{Name: "game", ContainerPort: 2456}},
+			Workshop: &boilerrv1alpha1.WorkshopSpec{
+				AppId:  892970,
+				ModDir: "BepInEx/plugins",
+				Method: boilerrv1alpha1.WorkshopMethodLink,
+				ModList: &boilerrv1alpha1.ModListFile{
+					Path:     "mods.txt",
+					Template: "{{range .Mods}}{{.ID}} {{.Path}}\n{{end}}",
+				},
+			},
+		},
+	}
+
+	sts := NewStatefulSetBuilder(server, gameDef).Build()
+	initContainers := sts.Spec.Template.Spec.InitContainers
+	if len(initContainers) != 3 || initContainers[1].Name != WorkshopContainerName {
+		t.Fatalf("expected the workshop container between SteamCMD and build-info, got %d init containers", len(initContainers))
+	}
+
+	args := strings.Join(initContainers[0].Args, " ")
+	for _, want := range []string{"+workshop_download_item 892970 2875458925", "+workshop_download_item 892970 2964470125"} {
+		if !strings.Contains(args, want) {
+			t.Errorf("expected SteamCMD args to contain %q, got %s", want, args)
+		}
+	}
+	if strings.Contains(args, "1111111111") {
+		t.Errorf("expected the disabled mod not to be downloaded, got %s", args)
+	}
+
+	mount := corev1.VolumeMount{}
+	for _, m := range sts.Spec.Template.Spec.Containers[0].VolumeMounts {
+		if m.Name == ConfigFilesVolumeName {
+			mount = m
+		}
+	}
+	if mount.MountPath != "/data/server/mods.txt" {
+		t.Errorf("expected the mod list mounted at /data/server/mods.txt, got %q", mount.MountPath)
+	}
+
+	// Without enabled mods, the workshop container is left out
+	server.Spec.Mods = []boilerrv1alpha1.WorkshopMod{{ID: 1111111111, Enabled: boolPtr(false)}}
+	sts = NewStatefulSetBuilder(server, gameDef).Build()
+	for _, c := range sts.Spec.Template.Spec.InitContainers {
+		if c.Name == WorkshopContainerName {
+			t.Error("expected no workshop container without enabled mods")
+		}
+	}
+}
+
+func TestConfigMapBuilder_ModList(t *testing.T) {
+	tests := []struct {
+		name   string
+		modDir string
+		want   string
+	}{
+		{
+			name:   "mods in modDir",
+			modDir: "BepInEx/plugins",
+			want: "2875458925 /data/server/BepInEx/plugins/2875458925\n" +
+				"2964470125 /data/server/BepInEx/plugins/2964470125\n",
+		},
+		{
+			// Without modDir, mods are loaded from the SteamCMD download directory
+			name: "mods in the download directory",
+			want: "2875458925 /data/server/steamapps/workshop/content/892970/2875458925\n" +
+				"2964470125 /data/server/steamapps/workshop/content/892970/2964470125\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &boilerrv1alpha1.SteamServer{
+				ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+				Spec: boilerrv1alpha1.SteamServerSpec{
+					GameDefinition: "valheim",
+					Mods: []boilerrv1alpha1.WorkshopMod{
+						{ID: 2875458925},
+						{ID: 1111111111, Enabled: boolPtr(false)},
+						{ID: 2964470125},
+					},
+				},
+			}
+			gameDef := &boilerrv1alpha1.GameDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+				Spec: boilerrv1alpha1.GameDefinitionSpec{
+					AppId:      896660,
+					InstallDir: "/data/server",
+					Command:    "./valheim_server.x86_64",
+					Workshop: &boilerrv1alpha1.WorkshopSpec{
+						AppId:  892970,
+						ModDir: tt.modDir,
+						ModList: &boilerrv1alpha1.ModListFile{
+							Path:     "mods.txt",
+							Template: "{{range .Mods}}{{.ID}} {{.Path}}\n{{end}}",
+						},
+					},
+				},
+			}
+
+			cm, err := NewConfigMapBuilder(server, gameDef).Build()
+			if err != nil {
+				t.Fatalf("Build() error: %v", err)
+			}
+			if got := cm.Data[configFileKey(0)]; got != tt.want {
+				t.Errorf("expected mod list %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestStatefulSetBuilder_WorkshopScript(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	const manifest = `"AppWorkshop"
+{
+	"WorkshopItemsInstalled"
+	{
+		"2875458925"
+		{
+			"timeupdated"		"1700000000"
+			"manifest"		"41"
+		}
+		"2964470125"
+		{
+			"timeupdated"		"1700000001"
+			"manifest"		"42"
+		}
+	}
+}
+`
+
+	tests := []struct {
+		name   string
+		method boilerrv1alpha1.WorkshopMethod
+		pinned string
+		checks func(t *testing.T, modDir, message string)
+	}{
+		{
+			name:   "links mods and reports them",
+			method: boilerrv1alpha1.WorkshopMethodLink,
+			pinned: "42",
+			checks: func(t *testing.T, modDir, message string) {
+				if target, err := os.Readlink(filepath.Join(modDir, "2875458925")); err != nil || !strings.HasSuffix(target, "/892970/2875458925") {
+					t.Errorf("expected a link to the downloaded mod, got %q, %v", target, err)
+				}
+				if _, err := os.Lstat(filepath.Join(modDir, "999")); !os.IsNotExist(err) {
+					t.Error("expected the link of a removed mod to be deleted")
+				}
+				items, ok := steamcmd.ParseWorkshopItems(message)
+				if !ok || len(items) != 2 {
+					t.Errorf("expected 2 installed items in the termination message, got %q", message)
+				}
+			},
+		},
+		{
+			name:   "copies mods",
+			method: boilerrv1alpha1.WorkshopMethodCopy,
+			checks: func(t *testing.T, modDir, message string) {
+				info, err := os.Lstat(filepath.Join(modDir, "2875458925"))
+				if err != nil || !info.IsDir() {
+					t.Errorf("expected a copy of the downloaded mod, got %v, %v", info, err)
+				}
+			},
+		},
+		{
+			name:   "installs a pinned mod that was updated",
+			method: boilerrv1alpha1.WorkshopMethodLink,
+			pinned: "40",
+			checks: func(t *testing.T, modDir, message string) {
+				items, ok := steamcmd.ParseWorkshopItems(message)
+				if !ok || len(items) != 2 || items[1].Manifest != "42" {
+					t.Errorf("expected the updated version in the termination message, got %q", message)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installDir := t.TempDir()
+			server := &boilerrv1alpha1.SteamServer{
+				ObjectMeta: metav1.ObjectMeta{Name: testServerName, Namespace: testNamespace},
+				Spec: boilerrv1alpha1.SteamServerSpec{
+					GameDefinition: "valheim",
+					Mods: []boilerrv1alpha1.WorkshopMod{
+						{ID: 2875458925},
+						{ID: 1111111111, Enabled: boolPtr(false)},
+						{ID: 2964470125, Version: tt.pinned},
+					},
+				},
+			}
+			gameDef := &boilerrv1alpha1.GameDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "valheim"},
+				Spec: boilerrv1alpha1.GameDefinitionSpec{
+					AppId:      896660,
+					InstallDir: installDir,
+					Command:    "./valheim_server.x86_64",
+					Workshop: &boilerrv1alpha1.WorkshopSpec{
+						AppId:  892970,
+						ModDir: "BepInEx/plugins",
+						Method: tt.method,
+					},
+				},
+			}
+
+			// Install the downloaded mods into a temporary install directory
+			content := steamcmd.WorkshopContentDir(installDir, 892970)
+			for _, id := range []string{"2875458925", "2964470125"} {
+				if err := os.MkdirAll(filepath.Join(content, id), 0o755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := os.WriteFile(steamcmd.WorkshopManifestPath(installDir, 892970), []byte(manifest), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			// A mod removed from the list since the last install
+			modDir := filepath.Join(installDir, "BepInEx", "plugins")
+			if err := os.MkdirAll(modDir, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Symlink(filepath.Join(content, "999"), filepath.Join(modDir, "999")); err != nil {
+				t.Fatal(err)
+			}
+
+			terminationLog := filepath.Join(t.TempDir(), "termination-log")
+			container := NewStatefulSetBuilder(server, gameDef).buildWorkshopContainer()
+			script := strings.ReplaceAll(container.Command[2], corev1.TerminationMessagePathDefault, terminationLog)
+			runErr := exec.Command("sh", "-c", script).Run()
+			message, _ := os.ReadFile(terminationLog)
+			if runErr != nil {
+				t.Fatalf("script failed: %v: %s", runErr, message)
+			}
+			tt.checks(t, modDir, string(message))
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * FOO *",
+	} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) expected an error", expr)
+		}
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{
+			name: "daily",
+			expr: "0 5 * * *",
+			from: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 1, 2, 5, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "strictly after",
+			expr: "0 5 * * *",
+			from: time.Date(2026, 1, 1, 5, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 1, 2, 5, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "seconds are ignored",
+			expr: "* * * * *",
+			from: time.Date(2026, 1, 1, 5, 0, 30, 0, time.UTC),
+			want: time.Date(2026, 1, 1, 5, 1, 0, 0, time.UTC),
+		},
+		{
+			name: "step",
+			expr: "*/15 * * * *",
+			from: time.Date(2026, 1, 1, 5, 16, 0, 0, time.UTC),
+			want: time.Date(2026, 1, 1, 5, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "list and range",
+			expr: "30 2,14 * * MON-FRI",
+			from: time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC), // Friday
+			want: time.Date(2026, 1, 5, 2, 30, 0, 0, time.UTC), // Monday
+		},
+		{
+			name: "sunday as 7",
+			expr: "0 0 * * 7",
+			from: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), // Thursday
+			want: time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "day of month or day of week",
+			expr: "0 0 15 * SUN",
+			from: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 1, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "month name",
+			expr: "0 0 1 MAR *",
+			from: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "macro",
+			expr: "@weekly",
+			from: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "time zone",
+			expr: "0 5 * * *",
+			from: time.Date(2026, 1, 1, 12, 0, 0, 0, denver),
+			want: time.Date(2026, 1, 2, 5, 0, 0, 0, denver),
+		},
+		{
+			name: "skips the hour lost to daylight saving time",
+			expr: "30 2 * * *",
+			from: time.Date(2026, 3, 8, 0, 0, 0, 0, denver),
+			want: time.Date(2026, 3, 9, 2, 30, 0, 0, denver),
+		},
+		{
+			name: "never",
+			expr: "0 0 30 2 *",
+			from: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Time{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.expr, err)
+			}
+			if got := s.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, want %v", tt.from, got, tt.want)
+			}
+		})
+	}
+}
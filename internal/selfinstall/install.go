@@ -0,0 +1,30 @@
+// Package selfinstall copies helper binaries shipped in the operator image into game server pods.
+package selfinstall
+
+import (
+	"io"
+	"os"
+)
+
+// Install copies the running executable to path so it can be used from another container.
+func Install(path string) error {
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	src, err := os.Open(self)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+
+	dst, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o755)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	return dst.Close()
+}
@@ -0,0 +1,45 @@
+package selfinstall
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInstall(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "helper")
+	if err := Install(path); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile(self)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("expected the installed binary to match the running executable")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0o111 == 0 {
+		t.Errorf("expected the installed binary to be executable, got mode %v", info.Mode())
+	}
+}
+
+func TestInstall_MissingDirectory(t *testing.T) {
+	if err := Install(filepath.Join(t.TempDir(), "missing", "helper")); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,101 @@
+// Package steamapi looks up published Steam app builds.
+//
+// Steam does not expose build IDs through its public Web API, so the Client reads app info
+// (the same data as `steamcmd +app_info_print`) from a SteamCMD app info JSON API.
+package steamapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultBaseURL is the default app info endpoint.
+const DefaultBaseURL = "https://api.steamcmd.net/v1/info"
+
+// DefaultBranch is the branch installed when no beta is selected.
+const DefaultBranch = "public"
+
+// Client queries a SteamCMD app info API for the latest build of an app.
+type Client struct {
+	// BaseURL of the app info API. The app ID is appended as a path segment.
+	// Defaults to DefaultBaseURL if empty.
+	BaseURL string
+
+	// HTTPClient used for requests. Defaults to a client with a 30 second timeout.
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client for the given base URL.
+func NewClient(baseURL string) *Client {
+	return &Client{
+		BaseURL:    baseURL,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// appInfoResponse is the subset of the app info response used to find build IDs.
+type appInfoResponse struct {
+	Status string `json:"status"`
+	Data   map[string]struct {
+		Depots struct {
+			Branches map[string]struct {
+				BuildID string `json:"buildid"`
+			} `json:"branches"`
+		} `json:"depots"`
+	} `json:"data"`
+}
+
+// LatestBuildID returns the latest build ID published for the branch of an app.
+// An empty branch means DefaultBranch.
+func (c *Client) LatestBuildID(ctx context.Context, appID int32, branch string) (string, error) {
+	if branch == "" {
+		branch = DefaultBranch
+	}
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	appKey := strconv.Itoa(int(appID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/"+appKey, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("steamapi: app %d: %w", appID, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("steamapi: app %d: unexpected status %s", appID, resp.Status)
+	}
+
+	var info appInfoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return "", fmt.Errorf("steamapi: app %d: decode response: %w", appID, err)
+	}
+	if info.Status != "success" {
+		return "", fmt.Errorf("steamapi: app %d: request status %q", appID, info.Status)
+	}
+
+	app, ok := info.Data[appKey]
+	if !ok {
+		return "", fmt.Errorf("steamapi: app %d not found", appID)
+	}
+	b, ok := app.Depots.Branches[branch]
+	if !ok || b.BuildID == "" {
+		return "", fmt.Errorf("steamapi: app %d has no branch %q", appID, branch)
+	}
+	return b.BuildID, nil
+}
@@ -0,0 +1,71 @@
+package steamapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testAppInfo = `{
+  "data": {
+    "896660": {
+      "depots": {
+        "branches": {
+          "public": {"buildid": "15632571", "timeupdated": "1700000000"},
+          "public-test": {"buildid": "15700000", "timeupdated": "1700100000", "pwdrequired": "1"}
+        }
+      }
+    }
+  },
+  "status": "success"
+}`
+
+func TestClient_LatestBuildID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/896660" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(testAppInfo))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+
+	tests := []struct {
+		name    string
+		appID   int32
+		branch  string
+		want    string
+		wantErr bool
+	}{
+		{name: "default branch is public", appID: 896660, want: "15632571"},
+		{name: "beta branch", appID: 896660, branch: "public-test", want: "15700000"},
+		{name: "unknown branch", appID: 896660, branch: "nightly", wantErr: true},
+		{name: "unknown app", appID: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.LatestBuildID(context.Background(), tt.appID, tt.branch)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LatestBuildID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("LatestBuildID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_LatestBuildIDFailedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data": {}, "status": "failed"}`))
+	}))
+	defer server.Close()
+
+	if _, err := NewClient(server.URL).LatestBuildID(context.Background(), 896660, ""); err == nil {
+		t.Error("expected error")
+	}
+}
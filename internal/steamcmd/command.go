@@ -8,6 +8,16 @@ import (
 // DefaultInstallDir is the default installation directory for game server files.
 const DefaultInstallDir = "/data/server"
 
+// Environment variables the SteamCMD arguments read secrets from.
+const (
+	// UsernameEnvVar holds the Steam account name for authenticated logins.
+	UsernameEnvVar = "STEAM_USERNAME"
+	// PasswordEnvVar holds the Steam account password for authenticated logins.
+	PasswordEnvVar = "STEAM_PASSWORD"
+	// BetaPasswordEnvVar holds the password of a private beta branch.
+	BetaPasswordEnvVar = "STEAM_BETA_PASSWORD"
+)
+
 // CommandConfig holds configuration for building SteamCMD arguments.
 type CommandConfig struct {
 	// AppID is the Steam application ID for the dedicated server.
@@ -25,14 +35,22 @@ type CommandConfig struct {
 	// Leave empty for the default/stable branch.
 	Beta string
 
-	// BetaPassword is an optional password for accessing private beta branches.
+	// BetaPassword passes the password of a private beta branch from BetaPasswordEnvVar.
 	// Some games require a password to access certain beta branches.
-	BetaPassword string
+	BetaPassword bool
 
 	// Validate enables file validation after download.
 	// This verifies all game files and re-downloads corrupted ones.
 	// Recommended for production use but adds time to startup.
 	Validate bool
+
+	// WorkshopAppID is the Steam application ID that WorkshopItems belong to,
+	// usually the game client rather than the dedicated server.
+	WorkshopAppID int32
+
+	// WorkshopItems are the Steam Workshop item IDs to download after the app update,
+	// into the steamapps/workshop directory of InstallDir.
+	WorkshopItems []int64
 }
 
 // CommandBuilder builds SteamCMD command arguments.
@@ -67,7 +85,7 @@ func (b *CommandBuilder) Build() []string {
 	} else {
 		// Credentials are expected in environment variables for security.
 		// STEAM_USERNAME and STEAM_PASSWORD should be injected from a Kubernetes Secret.
-		args = append(args, "+login", "$STEAM_USERNAME", "$STEAM_PASSWORD")
+		args = append(args, "+login", EnvRef(UsernameEnvVar), EnvRef(PasswordEnvVar))
 	}
 
 	// App update command with optional beta branch and validation
@@ -75,9 +93,9 @@ func (b *CommandBuilder) Build() []string {
 
 	if b.config.Beta != "" {
 		args = append(args, "-beta", b.config.Beta)
-		if b.config.BetaPassword != "" {
+		if b.config.BetaPassword {
 			// Beta password is also expected in an environment variable for security
-			args = append(args, "-betapassword", "$STEAM_BETA_PASSWORD")
+			args = append(args, "-betapassword", EnvRef(BetaPasswordEnvVar))
 		}
 	}
 
@@ -85,6 +103,11 @@ func (b *CommandBuilder) Build() []string {
 		args = append(args, "validate")
 	}
 
+	// Workshop items are downloaded with the same login
+	for _, id := range b.config.WorkshopItems {
+		args = append(args, "+workshop_download_item", fmt.Sprintf("%d", b.config.WorkshopAppID), fmt.Sprintf("%d", id))
+	}
+
 	// Quit
 	args = append(args, "+quit")
 
@@ -98,5 +121,11 @@ func (b *CommandBuilder) RequiresCredentials() bool {
 
 // RequiresBetaPassword returns true if the command requires a beta password.
 func (b *CommandBuilder) RequiresBetaPassword() bool {
-	return b.config.Beta != "" && b.config.BetaPassword != ""
+	return b.config.Beta != "" && b.config.BetaPassword
+}
+
+// EnvRef returns a reference to an environment variable in container commands and args.
+// The kubelet expands it before the retry script passes the args to SteamCMD through "$@".
+func EnvRef(name string) string {
+	return "$(" + name + ")"
 }
@@ -26,8 +26,8 @@ func TestCommandBuilder_Build(t *testing.T) {
 				"+quit",
 			},
 			shouldNotContain: []string{
-				"$STEAM_USERNAME",
-				"$STEAM_PASSWORD",
+				"$(STEAM_USERNAME)",
+				"$(STEAM_PASSWORD)",
 				"-beta",
 			},
 		},
@@ -39,8 +39,8 @@ func TestCommandBuilder_Build(t *testing.T) {
 				Validate:  true,
 			},
 			shouldContain: []string{
-				"$STEAM_USERNAME",
-				"$STEAM_PASSWORD",
+				"$(STEAM_USERNAME)",
+				"$(STEAM_PASSWORD)",
 				"+login",
 			},
 			shouldNotContain: []string{},
@@ -78,12 +78,12 @@ func TestCommandBuilder_Build(t *testing.T) {
 				AppID:        123456,
 				Anonymous:    true,
 				Beta:         "private-beta",
-				BetaPassword: "secret123",
+				BetaPassword: true,
 				Validate:     true,
 			},
 			shouldContain: []string{
 				"-beta", "private-beta",
-				"-betapassword", "$STEAM_BETA_PASSWORD",
+				"-betapassword", "$(STEAM_BETA_PASSWORD)",
 			},
 		},
 		{
@@ -101,6 +101,19 @@ func TestCommandBuilder_Build(t *testing.T) {
 				"/data/server",
 			},
 		},
+		{
+			name: "workshop items",
+			config: CommandConfig{
+				AppID:         896660,
+				Anonymous:     true,
+				WorkshopAppID: 892970,
+				WorkshopItems: []int64{2875458925, 2964470125},
+			},
+			shouldContain: []string{
+				"+workshop_download_item 892970 2875458925",
+				"+workshop_download_item 892970 2964470125 +quit",
+			},
+		},
 		{
 			name: "default install directory when empty",
 			config: CommandConfig{
@@ -222,7 +235,7 @@ func TestCommandBuilder_RequiresBetaPassword(t *testing.T) {
 			config: CommandConfig{
 				AppID:        123456,
 				Beta:         "private",
-				BetaPassword: "secret",
+				BetaPassword: true,
 			},
 			expected: true,
 		},
@@ -230,7 +243,7 @@ func TestCommandBuilder_RequiresBetaPassword(t *testing.T) {
 			name: "password without beta is false",
 			config: CommandConfig{
 				AppID:        123456,
-				BetaPassword: "secret",
+				BetaPassword: true,
 			},
 			expected: false,
 		},
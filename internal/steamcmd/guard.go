@@ -0,0 +1,51 @@
+package steamcmd
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// GuardSharedSecretEnvVar holds the base64 shared secret of a Steam Guard mobile authenticator.
+const GuardSharedSecretEnvVar = "STEAM_GUARD_SHARED_SECRET"
+
+// GuardCodeCommand is the SteamCMD command that sets the Steam Guard code of the next login.
+const GuardCodeCommand = "+set_steam_guard_code"
+
+// guardCodePeriod is how long a Steam Guard code is valid.
+const guardCodePeriod = 30 * time.Second
+
+// guardCodeChars is the alphabet of Steam Guard codes.
+const guardCodeChars = "23456789BCDFGHJKMNPQRTVWXY"
+
+// GuardCode returns the Steam Guard mobile authenticator code at t for a base64 shared secret.
+// Codes are a TOTP over 30 second periods, encoded as five characters of the Steam Guard alphabet.
+func GuardCode(sharedSecret string, t time.Time) (string, error) {
+	secret, err := base64.StdEncoding.DecodeString(strings.TrimSpace(sharedSecret))
+	if err != nil {
+		return "", fmt.Errorf("invalid shared secret: %w", err)
+	}
+	if len(secret) == 0 {
+		return "", fmt.Errorf("invalid shared secret: empty")
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(t.Unix()/int64(guardCodePeriod/time.Second)))
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	code := make([]byte, 5)
+	for i := range code {
+		code[i] = guardCodeChars[value%uint32(len(guardCodeChars))]
+		value /= uint32(len(guardCodeChars))
+	}
+	return string(code), nil
+}
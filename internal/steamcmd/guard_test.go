@@ -0,0 +1,40 @@
+package steamcmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGuardCode(t *testing.T) {
+	const secret = "cnOgv/KdpLoP6Nbh0GMkXkPXALQ="
+
+	tests := []struct {
+		name string
+		unix int64
+		want string
+	}{
+		{name: "start of period", unix: 1699999980, want: "X45RP"},
+		{name: "end of period", unix: 1700000009, want: "X45RP"},
+		{name: "next period", unix: 1700000010, want: "YWH3Q"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GuardCode(secret, time.Unix(tt.unix, 0))
+			if err != nil {
+				t.Fatalf("GuardCode() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GuardCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGuardCode_InvalidSecret(t *testing.T) {
+	for _, secret := range []string{"", "not base64!"} {
+		if _, err := GuardCode(secret, time.Unix(1700000000, 0)); err == nil {
+			t.Errorf("GuardCode(%q) expected an error", secret)
+		}
+	}
+}
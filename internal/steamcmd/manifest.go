@@ -0,0 +1,38 @@
+package steamcmd
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// AppManifestPath returns the path of the app manifest SteamCMD writes for an installed app.
+func AppManifestPath(installDir string, appID int32) string {
+	if installDir == "" {
+		installDir = DefaultInstallDir
+	}
+	return path.Join(installDir, "steamapps", fmt.Sprintf("appmanifest_%d.acf", appID))
+}
+
+// BuildIDScript returns a shell script that prints the installed build ID from the app manifest.
+// It prints nothing if the manifest does not exist, so a missing install never fails the pod.
+func BuildIDScript(installDir string, appID int32) string {
+	return fmt.Sprintf(`manifest=%q; if [ -f "$manifest" ]; then `+
+		`sed -n 's/^[[:space:]]*"buildid"[[:space:]]*"\([0-9]*\)".*/\1/p' "$manifest" | head -n 1; fi`,
+		AppManifestPath(installDir, appID))
+}
+
+// ParseBuildID validates the output of BuildIDScript and returns the build ID.
+// Returns false if the output is empty or not a numeric build ID.
+func ParseBuildID(output string) (string, bool) {
+	buildID := strings.TrimSpace(output)
+	if buildID == "" {
+		return "", false
+	}
+	for _, c := range buildID {
+		if c < '0' || c > '9' {
+			return "", false
+		}
+	}
+	return buildID, true
+}
@@ -0,0 +1,85 @@
+package steamcmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+const testManifest = `"AppState"
+{
+	"appid"		"896660"
+	"name"		"Valheim Dedicated Server"
+	"StateFlags"		"4"
+	"installdir"		"Valheim dedicated server"
+	"buildid"		"15632571"
+	"LastOwner"		"0"
+	"UserConfig"
+	{
+		"BetaKey"		"public"
+	}
+}
+`
+
+func TestAppManifestPath(t *testing.T) {
+	if got := AppManifestPath("/data/server", 896660); got != "/data/server/steamapps/appmanifest_896660.acf" {
+		t.Errorf("AppManifestPath() = %q", got)
+	}
+	if got := AppManifestPath("", 1); got != DefaultInstallDir+"/steamapps/appmanifest_1.acf" {
+		t.Errorf("AppManifestPath() with default dir = %q", got)
+	}
+}
+
+func TestBuildIDScript(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	t.Run("prints build ID from manifest", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.MkdirAll(filepath.Join(dir, "steamapps"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(AppManifestPath(dir, 896660), []byte(testManifest), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := exec.Command("sh", "-c", BuildIDScript(dir, 896660)).Output()
+		if err != nil {
+			t.Fatalf("script failed: %v", err)
+		}
+		if buildID, ok := ParseBuildID(string(out)); !ok || buildID != "15632571" {
+			t.Errorf("got build ID %q (ok=%v), want 15632571", buildID, ok)
+		}
+	})
+
+	t.Run("missing manifest prints nothing", func(t *testing.T) {
+		out, err := exec.Command("sh", "-c", BuildIDScript(t.TempDir(), 896660)).Output()
+		if err != nil {
+			t.Fatalf("script failed: %v", err)
+		}
+		if len(out) != 0 {
+			t.Errorf("expected no output, got %q", out)
+		}
+	})
+}
+
+func TestParseBuildID(t *testing.T) {
+	tests := []struct {
+		output string
+		want   string
+		wantOK bool
+	}{
+		{output: "15632571\n", want: "15632571", wantOK: true},
+		{output: "", wantOK: false},
+		{output: "Error: no such file", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseBuildID(tt.output)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("ParseBuildID(%q) = %q, %v, want %q, %v", tt.output, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
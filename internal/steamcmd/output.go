@@ -0,0 +1,119 @@
+package steamcmd
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrorReason is the failure reason of SteamCMD errors without a more specific reason.
+const ErrorReason = "SteamCMDError"
+
+var (
+	// attemptPattern matches the attempt lines of RetryScript, e.g. "SteamCMD attempt 2 of 4".
+	attemptPattern = regexp.MustCompile(`^SteamCMD attempt ([0-9]+) of ([0-9]+)$`)
+	// progressPattern matches progress lines, e.g.
+	// " Update state (0x61) downloading, progress: 45.23 (1234567 / 2729000000)".
+	progressPattern = regexp.MustCompile(`Update state \(0x[0-9a-fA-F]+\) ([a-z ]+), progress: ([0-9.]+) \(([0-9]+) / ([0-9]+)\)`)
+	// successPattern matches the end of a successful install, e.g. "Success! App '896660' fully installed."
+	successPattern = regexp.MustCompile(`Success! App '[0-9]+' fully installed`)
+	// errorPattern matches install errors, e.g. "ERROR! Failed to install app '896660' (No subscription)".
+	errorPattern = regexp.MustCompile(`(?i)^ERROR! (.+)$`)
+	// loginPattern matches login failures, e.g. "Logging in user 'name' to Steam Public...FAILED (Invalid Password)".
+	loginPattern = regexp.MustCompile(`(?:FAILED|ERROR) \(([^)]+)\)$|^Login Failure: (.+)$`)
+	// reasonPattern matches the parenthesized reason at the end of an error.
+	reasonPattern = regexp.MustCompile(`\(([^)]+)\)\.?$`)
+)
+
+// InstallOutput is what SteamCMD reported about an install.
+type InstallOutput struct {
+	// Attempt is the SteamCMD run of RetryScript the output ends in.
+	Attempt int32
+	// Attempts is the number of SteamCMD runs RetryScript makes at most.
+	Attempts int32
+	// UpdateState is the last update state, e.g. "downloading" or "verifying install".
+	UpdateState string
+	// Progress is the percentage of the last update state completed.
+	Progress int32
+	// BytesDownloaded is the number of bytes of the last update state completed.
+	BytesDownloaded int64
+	// BytesTotal is the number of bytes of the last update state.
+	BytesTotal int64
+	// Success is whether SteamCMD reported the app fully installed.
+	Success bool
+	// Reason is the CamelCase reason of the last error, e.g. "NoSubscription" or "InvalidPassword".
+	Reason string
+	// Error is the last error.
+	Error string
+}
+
+// ParseOutput parses the console output of SteamCMD, as in the logs of its container.
+// Later lines take precedence, so the output of a whole run reports where it ended.
+func ParseOutput(output string) InstallOutput {
+	var result InstallOutput
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+
+		// A new attempt starts over
+		if m := attemptPattern.FindStringSubmatch(line); m != nil {
+			attempt, _ := strconv.ParseInt(m[1], 10, 32)
+			attempts, _ := strconv.ParseInt(m[2], 10, 32)
+			result = InstallOutput{Attempt: int32(attempt), Attempts: int32(attempts)}
+			continue
+		}
+		if m := progressPattern.FindStringSubmatch(line); m != nil {
+			progress, _ := strconv.ParseFloat(m[2], 64)
+			result.UpdateState = m[1]
+			result.Progress = int32(min(max(progress, 0), 100))
+			result.BytesDownloaded, _ = strconv.ParseInt(m[3], 10, 64)
+			result.BytesTotal, _ = strconv.ParseInt(m[4], 10, 64)
+			continue
+		}
+		if successPattern.MatchString(line) {
+			result.Success = true
+			result.Progress = 100
+			result.Reason, result.Error = "", ""
+			continue
+		}
+		if m := errorPattern.FindStringSubmatch(line); m != nil {
+			result.Success = false
+			result.Error = m[1]
+			result.Reason = ErrorReason
+			if r := reasonPattern.FindStringSubmatch(m[1]); r != nil {
+				result.Reason = errorReason(r[1])
+			}
+			continue
+		}
+		if m := loginPattern.FindStringSubmatch(line); m != nil {
+			reason := m[1] + m[2]
+			result.Success = false
+			result.Error = "Login failed: " + reason
+			result.Reason = errorReason(reason)
+		}
+	}
+	return result
+}
+
+// errorReason converts a SteamCMD error reason to CamelCase, e.g. "No subscription" to "NoSubscription".
+// Returns ErrorReason if the result would not start with a letter.
+func errorReason(text string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range text {
+		if r > unicode.MaxASCII || (!unicode.IsLetter(r) && !unicode.IsDigit(r)) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	reason := b.String()
+	if reason == "" || !unicode.IsLetter(rune(reason[0])) {
+		return ErrorReason
+	}
+	return reason
+}
@@ -0,0 +1,86 @@
+package steamcmd
+
+import (
+	"testing"
+)
+
+func TestParseOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   InstallOutput
+	}{
+		{
+			name: "download in progress",
+			output: `Redirecting stderr to '/root/Steam/logs/stderr.txt'
+Logging in user 'anonymous' to Steam Public...OK
+Waiting for user info...OK
+ Update state (0x3) reconfiguring, progress: 0.00 (0 / 0)
+ Update state (0x61) downloading, progress: 12.50 (125000000 / 1000000000)
+ Update state (0x61) downloading, progress: 45.23 (452300000 / 1000000000)
+`,
+			want: InstallOutput{UpdateState: "downloading", Progress: 45, BytesDownloaded: 452300000, BytesTotal: 1000000000},
+		},
+		{
+			name: "successful install",
+			output: ` Update state (0x81) verifying update, progress: 99.70 (997000000 / 1000000000)
+Success! App '896660' fully installed.
+`,
+			want: InstallOutput{UpdateState: "verifying update", Progress: 100, BytesDownloaded: 997000000, BytesTotal: 1000000000, Success: true},
+		},
+		{
+			name:   "no subscription",
+			output: "ERROR! Failed to install app '1829350' (No subscription)\n",
+			want:   InstallOutput{Reason: "NoSubscription", Error: "Failed to install app '1829350' (No subscription)"},
+		},
+		{
+			name: "disk write failure while downloading",
+			output: ` Update state (0x61) downloading, progress: 80.00 (800 / 1000)
+Error! App '896660' state is 0x202 after update job.
+ERROR! Failed to install app '896660' (Disk write failure)
+`,
+			want: InstallOutput{
+				UpdateState: "downloading", Progress: 80, BytesDownloaded: 800, BytesTotal: 1000,
+				Reason: "DiskWriteFailure", Error: "Failed to install app '896660' (Disk write failure)",
+			},
+		},
+		{
+			name:   "error without a reason",
+			output: "ERROR! Timed out waiting for AppInfo update.\n",
+			want:   InstallOutput{Reason: ErrorReason, Error: "Timed out waiting for AppInfo update."},
+		},
+		{
+			name:   "invalid password",
+			output: "Logging in user 'gamer' [U:1:123456] to Steam Public...FAILED (Invalid Password)\n",
+			want:   InstallOutput{Reason: "InvalidPassword", Error: "Login failed: Invalid Password"},
+		},
+		{
+			name:   "steam guard code mismatch",
+			output: "Logging in user 'gamer' to Steam Public...ERROR (Two-factor code mismatch)\n",
+			want:   InstallOutput{Reason: "TwoFactorCodeMismatch", Error: "Login failed: Two-factor code mismatch"},
+		},
+		{
+			name: "retry after a failed attempt",
+			output: `SteamCMD attempt 1 of 4
+ERROR! Failed to install app '896660' (Disk write failure)
+SteamCMD exited with code 8, retrying in 30s
+SteamCMD attempt 2 of 4
+ Update state (0x61) downloading, progress: 10.00 (100 / 1000)
+`,
+			want: InstallOutput{Attempt: 2, Attempts: 4, UpdateState: "downloading", Progress: 10, BytesDownloaded: 100, BytesTotal: 1000},
+		},
+		{
+			name:   "no output",
+			output: "",
+			want:   InstallOutput{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseOutput(tt.output); got != tt.want {
+				t.Errorf("ParseOutput() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
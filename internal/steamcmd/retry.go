@@ -0,0 +1,79 @@
+package steamcmd
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// attemptFormat is the line RetryScript prints before each SteamCMD run, with the attempt and the number of attempts.
+const attemptFormat = "SteamCMD attempt %s of %s"
+
+// RetryConfig configures the retries of RetryScript.
+type RetryConfig struct {
+	// InstallDir is the install directory whose partial downloads are cleared before a retry.
+	// Defaults to DefaultInstallDir if empty.
+	InstallDir string
+
+	// Retries is how many times a failed run is retried.
+	Retries int32
+
+	// Backoff is the wait before the first retry, doubled for each further retry.
+	Backoff time.Duration
+
+	// MaxBackoff caps the wait between retries.
+	MaxBackoff time.Duration
+
+	// TerminationLog is where the output of the last run is written when all attempts failed.
+	TerminationLog string
+}
+
+// PartialDownloadDirs returns the directories SteamCMD keeps partial downloads of an app in.
+// A failed download can leave them in a state later runs don't recover from.
+func PartialDownloadDirs(installDir string) []string {
+	if installDir == "" {
+		installDir = DefaultInstallDir
+	}
+	return []string{
+		path.Join(installDir, "steamapps", "downloading"),
+		path.Join(installDir, "steamapps", "temp"),
+	}
+}
+
+// RetryScript returns a shell script that runs its positional parameters as the SteamCMD command,
+// retrying failed runs with exponential backoff and clearing partial downloads before each retry.
+// Each run is announced with an attempt line, which ParseOutput reports. When all attempts fail,
+// the attempt line and the end of the last run's output are written to the termination log.
+func RetryScript(config RetryConfig) string {
+	installDir := config.InstallDir
+	if installDir == "" {
+		installDir = DefaultInstallDir
+	}
+	attempts := config.Retries + 1
+	backoff := int64(config.Backoff / time.Second)
+	maxBackoff := int64(config.MaxBackoff / time.Second)
+
+	var clear []string
+	for _, dir := range PartialDownloadDirs(installDir) {
+		clear = append(clear, fmt.Sprintf("%q", dir))
+	}
+
+	attempt := fmt.Sprintf(attemptFormat, "$attempt", "$attempts")
+
+	var script strings.Builder
+	fmt.Fprintf(&script, `output="${TMPDIR:-/tmp}/steamcmd-output"; status="${TMPDIR:-/tmp}/steamcmd-status"; `+
+		`attempts=%d; delay=%d; attempt=1; `, attempts, backoff)
+	fmt.Fprintf(&script, `while :; do echo "%s"; rm -f "$status"; `+
+		`("$@"; echo $? > "$status") 2>&1 | tee "$output"; code=$(cat "$status" 2>/dev/null || echo 1); `, attempt)
+	script.WriteString(`if [ "$code" = 0 ]; then exit 0; fi; `)
+	fmt.Fprintf(&script, `if [ "$attempt" -ge "$attempts" ]; then `+
+		`{ echo "%s"; tail -n 40 "$output" | tail -c 3000; } > %s; exit "$code"; fi; `,
+		attempt, config.TerminationLog)
+	fmt.Fprintf(&script, `echo "SteamCMD exited with code $code, retrying in ${delay}s"; rm -rf %s; `,
+		strings.Join(clear, " "))
+	fmt.Fprintf(&script, `sleep "$delay"; delay=$((delay * 2)); if [ "$delay" -gt %d ]; then delay=%d; fi; `,
+		maxBackoff, maxBackoff)
+	script.WriteString(`attempt=$((attempt + 1)); done`)
+	return script.String()
+}
@@ -0,0 +1,66 @@
+package steamcmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRetryScript(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	// run runs the script with a fake SteamCMD that fails until its run number reaches succeedOn
+	run := func(t *testing.T, retries int32, succeedOn int) (string, string, error) {
+		installDir := t.TempDir()
+		terminationLog := filepath.Join(t.TempDir(), "termination-log")
+		counter := filepath.Join(t.TempDir(), "runs")
+		fake := `n=$(($(cat "$1" 2>/dev/null || echo 0) + 1)); echo "$n" > "$1"; ` +
+			`if [ -d "$2/steamapps/downloading" ]; then echo "partial download left over"; fi; ` +
+			`mkdir -p "$2/steamapps/downloading/896660"; ` +
+			`if [ "$n" -ge "$3" ]; then echo "Success! App '896660' fully installed."; exit 0; fi; ` +
+			`echo "ERROR! Failed to install app '896660' (Disk write failure)"; exit 8`
+
+		script := RetryScript(RetryConfig{InstallDir: installDir, Retries: retries, TerminationLog: terminationLog})
+		cmd := exec.Command("sh", "-c", script, "steamcmd", "sh", "-c", fake, "fake", counter, installDir, strconv.Itoa(succeedOn))
+		cmd.Env = append(os.Environ(), "TMPDIR="+t.TempDir())
+		out, err := cmd.CombinedOutput()
+		message, _ := os.ReadFile(terminationLog)
+		return string(out), string(message), err
+	}
+
+	t.Run("retries until SteamCMD succeeds", func(t *testing.T) {
+		out, message, err := run(t, 2, 3)
+		if err != nil {
+			t.Fatalf("script failed: %v: %s", err, out)
+		}
+		if !strings.Contains(out, "SteamCMD attempt 3 of 3") || !strings.Contains(out, "retrying in 0s") {
+			t.Errorf("expected 3 attempts, got %s", out)
+		}
+		if message != "" {
+			t.Errorf("expected no termination message, got %q", message)
+		}
+	})
+
+	t.Run("fails after the last retry", func(t *testing.T) {
+		out, message, err := run(t, 1, 99)
+		exitErr, ok := err.(*exec.ExitError)
+		if !ok || exitErr.ExitCode() != 8 {
+			t.Fatalf("expected exit code 8, got %v: %s", err, out)
+		}
+		result := ParseOutput(message)
+		if result.Attempt != 2 || result.Attempts != 2 || result.Reason != "DiskWriteFailure" {
+			t.Errorf("expected the failure of attempt 2 of 2, got %+v from %q", result, message)
+		}
+		if !strings.Contains(out, "SteamCMD exited with code 8, retrying in 0s") {
+			t.Errorf("expected a retry, got %s", out)
+		}
+		if strings.Contains(out, "partial download left over") {
+			t.Errorf("expected the partial download cleared before the retry, got %s", out)
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package steamcmd
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// WorkshopItem is a Steam Workshop item installed by SteamCMD.
+type WorkshopItem struct {
+	// ID is the Workshop item ID.
+	ID int64
+	// TimeUpdated is when the installed version was published, in Unix seconds.
+	TimeUpdated int64
+	// Manifest is the manifest ID of the installed version.
+	Manifest string
+}
+
+// WorkshopContentDir returns the directory SteamCMD downloads Workshop items of an app into,
+// one subdirectory per item ID.
+func WorkshopContentDir(installDir string, appID int32) string {
+	if installDir == "" {
+		installDir = DefaultInstallDir
+	}
+	return path.Join(installDir, "steamapps", "workshop", "content", fmt.Sprintf("%d", appID))
+}
+
+// WorkshopManifestPath returns the path of the manifest SteamCMD writes for the Workshop items of an app.
+func WorkshopManifestPath(installDir string, appID int32) string {
+	if installDir == "" {
+		installDir = DefaultInstallDir
+	}
+	return path.Join(installDir, "steamapps", "workshop", fmt.Sprintf("appworkshop_%d.acf", appID))
+}
+
+// WorkshopItemsScript returns a shell script that prints the installed items among ids from the
+// Workshop manifest, one "<id> <timeupdated> <manifest>" line each.
+// It prints nothing if the manifest does not exist.
+func WorkshopItemsScript(installDir string, appID int32, ids []int64) string {
+	wanted := make([]string, len(ids))
+	for i, id := range ids {
+		wanted[i] = strconv.FormatInt(id, 10)
+	}
+
+	// Items are the blocks nested in WorkshopItemsInstalled, keyed by item ID
+	program := `$1 == "\"WorkshopItemsInstalled\"" { section = 1; next } ` +
+		`!section { next } ` +
+		`$1 == "{" { depth++; next } ` +
+		`$1 == "}" { depth--; if (depth == 0) section = 0; else if (index(ids, " " id " ")) print id, updated, manifest; next } ` +
+		`{ gsub(/"/, "") } ` +
+		`depth == 1 { id = $1; updated = ""; manifest = "" } ` +
+		`depth == 2 && $1 == "timeupdated" { updated = $2 } ` +
+		`depth == 2 && $1 == "manifest" { manifest = $2 }`
+
+	return fmt.Sprintf(`manifest=%q; if [ -f "$manifest" ]; then awk -v ids=%q '%s' "$manifest"; fi`,
+		WorkshopManifestPath(installDir, appID), " "+strings.Join(wanted, " ")+" ", program)
+}
+
+// ParseWorkshopItems parses the output of WorkshopItemsScript.
+// Returns false if any line is not an installed item.
+func ParseWorkshopItems(output string) ([]WorkshopItem, bool) {
+	var items []WorkshopItem
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, false
+		}
+		id, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		updated, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		if _, ok := ParseBuildID(fields[2]); !ok {
+			return nil, false
+		}
+		items = append(items, WorkshopItem{ID: id, TimeUpdated: updated, Manifest: fields[2]})
+	}
+	return items, true
+}
@@ -0,0 +1,109 @@
+package steamcmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testWorkshopManifest = `"AppWorkshop"
+{
+	"appid"		"892970"
+	"SizeOnDisk"		"1048576"
+	"NeedsUpdate"		"0"
+	"WorkshopItemsInstalled"
+	{
+		"2875458925"
+		{
+			"size"		"524288"
+			"timeupdated"		"1700000000"
+			"manifest"		"5812736645813370522"
+		}
+		"1111111111"
+		{
+			"size"		"524288"
+			"timeupdated"		"1600000000"
+			"manifest"		"123"
+		}
+	}
+	"WorkshopItemDetails"
+	{
+		"2875458925"
+		{
+			"manifest"		"5812736645813370522"
+			"timeupdated"		"1700000000"
+			"timetouched"		"1700000100"
+		}
+	}
+}
+`
+
+func TestWorkshopPaths(t *testing.T) {
+	if got := WorkshopContentDir("/data/server", 892970); got != "/data/server/steamapps/workshop/content/892970" {
+		t.Errorf("WorkshopContentDir() = %q", got)
+	}
+	if got := WorkshopManifestPath("", 892970); got != DefaultInstallDir+"/steamapps/workshop/appworkshop_892970.acf" {
+		t.Errorf("WorkshopManifestPath() with default dir = %q", got)
+	}
+}
+
+func TestWorkshopItemsScript(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	t.Run("prints requested installed items", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.MkdirAll(filepath.Join(dir, "steamapps", "workshop"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(WorkshopManifestPath(dir, 892970), []byte(testWorkshopManifest), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := exec.Command("sh", "-c", WorkshopItemsScript(dir, 892970, []int64{2875458925, 2964470125})).Output()
+		if err != nil {
+			t.Fatalf("script failed: %v", err)
+		}
+		items, ok := ParseWorkshopItems(string(out))
+		want := []WorkshopItem{{ID: 2875458925, TimeUpdated: 1700000000, Manifest: "5812736645813370522"}}
+		if !ok || !reflect.DeepEqual(items, want) {
+			t.Errorf("got items %v (ok=%v) from %q, want %v", items, ok, out, want)
+		}
+	})
+
+	t.Run("missing manifest prints nothing", func(t *testing.T) {
+		out, err := exec.Command("sh", "-c", WorkshopItemsScript(t.TempDir(), 892970, []int64{1})).Output()
+		if err != nil {
+			t.Fatalf("script failed: %v", err)
+		}
+		if len(out) != 0 {
+			t.Errorf("expected no output, got %q", out)
+		}
+	})
+}
+
+func TestParseWorkshopItems(t *testing.T) {
+	tests := []struct {
+		output string
+		want   []WorkshopItem
+		wantOK bool
+	}{
+		{output: "", wantOK: true},
+		{output: "1 1700000000 42\n2 1600000000 43\n", want: []WorkshopItem{
+			{ID: 1, TimeUpdated: 1700000000, Manifest: "42"},
+			{ID: 2, TimeUpdated: 1600000000, Manifest: "43"},
+		}, wantOK: true},
+		{output: "Workshop item 1 was not downloaded", wantOK: false},
+		{output: "1 1700000000", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseWorkshopItems(tt.output)
+		if !reflect.DeepEqual(got, tt.want) || ok != tt.wantOK {
+			t.Errorf("ParseWorkshopItems(%q) = %v, %v, want %v, %v", tt.output, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
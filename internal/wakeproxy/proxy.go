@@ -0,0 +1,192 @@
+// Package wakeproxy listens on a stopped game server's ports and asks the operator to start it
+// when a player connects. A2S queries are answered with a placeholder so the server stays
+// visible in server browsers while it is stopped.
+package wakeproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/CraightonH/boilerr/internal/a2s"
+)
+
+// DefaultWakeInterval is the minimum time between wake requests.
+const DefaultWakeInterval = 10 * time.Second
+
+// maxPacketSize is the largest UDP packet read from clients.
+const maxPacketSize = 4096
+
+// Port is a port the proxy listens on.
+type Port struct {
+	Port     int32
+	Protocol string
+}
+
+// ParsePorts parses a comma-separated list of ports such as "2456/UDP,27015/TCP".
+// The protocol defaults to UDP.
+func ParsePorts(s string) ([]Port, error) {
+	var ports []Port
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		number, protocol, _ := strings.Cut(field, "/")
+		port, err := strconv.ParseInt(number, 10, 32)
+		if err != nil || port <= 0 || port > 65535 {
+			return nil, fmt.Errorf("invalid port %q", field)
+		}
+		protocol = strings.ToUpper(protocol)
+		switch protocol {
+		case "":
+			protocol = "UDP"
+		case "UDP", "TCP":
+		default:
+			return nil, fmt.Errorf("invalid protocol in %q", field)
+		}
+		ports = append(ports, Port{Port: int32(port), Protocol: protocol})
+	}
+	return ports, nil
+}
+
+// Proxy answers A2S queries with a placeholder and sends a wake request on any other traffic.
+type Proxy struct {
+	// Info is the A2S_INFO reply sent while the server is stopped.
+	Info a2s.Info
+
+	// WakeURL receives a POST when a player connects.
+	WakeURL string
+
+	// Token is sent as a bearer token with wake requests.
+	Token string
+
+	// HTTPClient sends wake requests. Defaults to a client with a 10 second timeout.
+	HTTPClient *http.Client
+
+	// WakeInterval is the minimum time between wake requests. Defaults to DefaultWakeInterval.
+	WakeInterval time.Duration
+
+	// ErrorLog logs failed wake requests. Defaults to the standard logger.
+	ErrorLog *log.Logger
+
+	mu       sync.Mutex
+	lastWake time.Time
+}
+
+// ServeUDP answers packets on conn until ctx is cancelled.
+func (p *Proxy) ServeUDP(ctx context.Context, conn net.PacketConn) error {
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+
+	reply := a2s.MarshalInfo(&p.Info)
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, from, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		packet := buf[:n]
+		switch {
+		case a2s.IsInfoRequest(packet):
+			_, _ = conn.WriteTo(reply, from)
+		case a2s.IsQuery(packet):
+			// Player and rules queries have nothing to report while stopped
+		default:
+			go p.wake(ctx)
+		}
+	}
+}
+
+// ServeTCP accepts connections on ln until ctx is cancelled.
+// Every connection is a player trying to join, so it triggers a wake request and is closed.
+func (p *Proxy) ServeTCP(ctx context.Context, ln net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		_ = ln.Close()
+	}()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		_ = conn.Close()
+		go p.wake(ctx)
+	}
+}
+
+// wake sends a wake request unless one was sent within WakeInterval.
+func (p *Proxy) wake(ctx context.Context) {
+	interval := p.WakeInterval
+	if interval <= 0 {
+		interval = DefaultWakeInterval
+	}
+
+	p.mu.Lock()
+	if time.Since(p.lastWake) < interval {
+		p.mu.Unlock()
+		return
+	}
+	p.lastWake = time.Now()
+	p.mu.Unlock()
+
+	if err := p.Wake(ctx); err != nil {
+		p.logf("%v", err)
+	}
+}
+
+// logf logs to ErrorLog, or the standard logger if it is nil.
+func (p *Proxy) logf(format string, args ...any) {
+	if p.ErrorLog != nil {
+		p.ErrorLog.Printf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
+
+// Wake asks the operator to start the server.
+func (p *Proxy) Wake(ctx context.Context) error {
+	if p.WakeURL == "" {
+		return errors.New("wakeproxy: no wake URL configured")
+	}
+
+	httpClient := p.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.WakeURL, nil)
+	if err != nil {
+		return err
+	}
+	if p.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.Token)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("wakeproxy: wake request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("wakeproxy: wake request returned %s", resp.Status)
+	}
+	return nil
+}
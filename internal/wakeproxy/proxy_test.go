@@ -0,0 +1,174 @@
+package wakeproxy
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/CraightonH/boilerr/internal/a2s"
+)
+
+func TestParsePorts(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []Port
+		wantErr bool
+	}{
+		{
+			name:  "mixed protocols",
+			input: "2456/UDP, 2457/udp,27015/TCP",
+			want:  []Port{{2456, "UDP"}, {2457, "UDP"}, {27015, "TCP"}},
+		},
+		{
+			name:  "default protocol",
+			input: "2456",
+			want:  []Port{{2456, "UDP"}},
+		},
+		{name: "invalid port", input: "abc/UDP", wantErr: true},
+		{name: "out of range", input: "70000/UDP", wantErr: true},
+		{name: "invalid protocol", input: "2456/SCTP", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePorts(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePorts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePorts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProxy_ServeUDP(t *testing.T) {
+	var wakes atomic.Int32
+	wakeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		wakes.Add(1)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer wakeServer.Close()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	proxy := &Proxy{
+		Info:    a2s.Info{Name: "My Server (stopped)", MaxPlayers: 10},
+		WakeURL: wakeServer.URL,
+	}
+	go func() { _ = proxy.ServeUDP(ctx, conn) }()
+
+	info, err := a2s.NewClient(time.Second).QueryInfo(ctx, conn.LocalAddr().String())
+	if err != nil {
+		t.Fatalf("QueryInfo() error = %v", err)
+	}
+	if info.Name != "My Server (stopped)" {
+		t.Errorf("expected placeholder name, got %q", info.Name)
+	}
+	if wakes.Load() != 0 {
+		t.Error("expected A2S queries not to wake the server")
+	}
+
+	client, err := net.Dial("udp", conn.LocalAddr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer func() { _ = client.Close() }()
+	for i := 0; i < 3; i++ {
+		if _, err := client.Write([]byte{0x01, 0x02, 0x03}); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for wakes.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if got := wakes.Load(); got != 1 {
+		t.Errorf("expected 1 wake request, got %d", got)
+	}
+}
+
+func TestProxy_ServeTCP(t *testing.T) {
+	woke := make(chan struct{}, 1)
+	wakeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		woke <- struct{}{}
+	}))
+	defer wakeServer.Close()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	proxy := &Proxy{WakeURL: wakeServer.URL}
+	go func() { _ = proxy.ServeTCP(ctx, ln) }()
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	_ = conn.Close()
+
+	select {
+	case <-woke:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a wake request")
+	}
+}
+
+func TestProxy_WakeToken(t *testing.T) {
+	var auth string
+	wakeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+	}))
+	defer wakeServer.Close()
+
+	proxy := &Proxy{WakeURL: wakeServer.URL, Token: "s3cret"}
+	if err := proxy.Wake(context.Background()); err != nil {
+		t.Fatalf("wake: %v", err)
+	}
+	if auth != "Bearer s3cret" {
+		t.Errorf("expected the token as a bearer token, got %q", auth)
+	}
+}
+
+func TestProxy_WakeError(t *testing.T) {
+	wakeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer wakeServer.Close()
+
+	proxy := &Proxy{WakeURL: wakeServer.URL}
+	if err := proxy.Wake(context.Background()); err == nil {
+		t.Error("expected an error for a non-2xx response")
+	}
+
+	// Wake requests sent on connect log their errors
+	var logged bytes.Buffer
+	proxy.ErrorLog = log.New(&logged, "", 0)
+	proxy.wake(context.Background())
+	if !strings.Contains(logged.String(), "404") {
+		t.Errorf("expected the failed wake request to be logged, got %q", logged.String())
+	}
+}